@@ -18,10 +18,15 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 	_ "time/tzdata"
 
 	"github.com/google/uuid"
@@ -46,6 +51,11 @@ const (
 	tlsCaENV         = "OPAMP_TLS_CA"
 	tlsCertENV       = "OPAMP_TLS_CERT"
 	tlsKeyENV        = "OPAMP_TLS_KEY"
+	proxyURLENV      = "OPAMP_PROXY_URL"
+	proxyUserENV     = "OPAMP_PROXY_USERNAME"
+	proxyPasswordENV = "OPAMP_PROXY_PASSWORD" //#nosec G101
+	headersENV       = "OPAMP_HEADERS"
+	pollingENV       = "OPAMP_POLLING_INTERVAL"
 	configPathENV    = "CONFIG_YAML_PATH"
 	managerPathENV   = "MANAGER_YAML_PATH"
 	loggingPathENV   = "LOGGING_YAML_PATH"
@@ -58,6 +68,8 @@ func main() {
 
 	_ = pflag.String("log-level", "", "not implemented") // TEMP(jsirianni): Required for OTEL k8s operator
 	var showVersion = pflag.BoolP("version", "v", false, "prints the version of the collector")
+	var showHistory = pflag.Bool("history", false, "lists the kept versions of the configs applied by remote management")
+	var revertVersion = pflag.Int("revert", 0, "restores the config version with the ID listed by --history and exits. The collector must not be running.")
 	pflag.Parse()
 
 	if *showVersion {
@@ -67,6 +79,14 @@ func main() {
 		return
 	}
 
+	if *showHistory || *revertVersion != 0 {
+		history := opamp.NewConfigHistory(filepath.Join(filepath.Dir(*managerConfigPath), opamp.ConfigHistoryDirName), opamp.DefaultConfigHistorySize)
+		if err := runConfigHistory(os.Stdout, history, *revertVersion); err != nil {
+			log.Fatalf("Config history failed: %v", err)
+		}
+		return
+	}
+
 	logOpts, err := logOptions(loggingConfigPath)
 	if err != nil {
 		log.Fatalf("Failed to get log options: %v", err)
@@ -215,6 +235,10 @@ func checkManagerConfig(configPath *string) error {
 			newConfig.TLS = tlsConfig
 		}
 
+		if _, err := configureConnection(newConfig); err != nil {
+			return fmt.Errorf("failed to configure connection: %w", err)
+		}
+
 		data, err := yaml.Marshal(newConfig)
 		if err != nil {
 			return fmt.Errorf("failed to marshal config: %w", err)
@@ -241,17 +265,26 @@ func ensureIdentity(configPath string) error {
 		return fmt.Errorf("unable to interpret config file: %w", err)
 	}
 
-	// If the AgentID is empty then we need to generate a new ID as the AgentID.
-	if candidateConfig.AgentID != opamp.EmptyAgentID {
-		return nil
+	// Connection environment variables override the file so they can be changed without editing it
+	changed, err := configureConnection(&candidateConfig)
+	if err != nil {
+		return fmt.Errorf("failed to configure connection: %w", err)
 	}
 
-	u, err := uuid.NewV7()
-	if err != nil {
-		return fmt.Errorf("new uuidv7: %w", err)
+	// If the AgentID is empty then we need to generate a new ID as the AgentID.
+	if candidateConfig.AgentID == opamp.EmptyAgentID {
+		u, err := uuid.NewV7()
+		if err != nil {
+			return fmt.Errorf("new uuidv7: %w", err)
+		}
+
+		candidateConfig.AgentID = opamp.AgentIDFromUUID(u)
+		changed = true
 	}
 
-	candidateConfig.AgentID = opamp.AgentIDFromUUID(u)
+	if !changed {
+		return nil
+	}
 
 	newBytes, err := yaml.Marshal(candidateConfig)
 	if err != nil {
@@ -329,3 +362,98 @@ func configureTLS() (*opamp.TLSConfig, error) {
 	}
 	return nil, nil
 }
+
+// configureConnection applies the proxy, header and polling interval environment variables to the config.
+// Returns true if the config was changed.
+func configureConnection(cfg *opamp.Config) (bool, error) {
+	changed := false
+
+	if proxyURL := os.Getenv(proxyURLENV); proxyURL != "" {
+		if cfg.Proxy == nil || cfg.Proxy.URL != proxyURL {
+			cfg.Proxy = &opamp.ProxyConfig{URL: proxyURL}
+			changed = true
+		}
+	}
+
+	if username, ok := os.LookupEnv(proxyUserENV); ok && cfg.Proxy != nil {
+		if !opamp.CmpStringPtr(cfg.Proxy.Username, &username) {
+			cfg.Proxy.Username = &username
+			changed = true
+		}
+	}
+
+	if password, ok := os.LookupEnv(proxyPasswordENV); ok && cfg.Proxy != nil {
+		if !opamp.CmpStringPtr(cfg.Proxy.Password, &password) {
+			cfg.Proxy.Password = &password
+			changed = true
+		}
+	}
+
+	if cfg.Proxy != nil {
+		if _, err := cfg.Proxy.ProxyURL(); err != nil {
+			return false, err
+		}
+	}
+
+	if headers := os.Getenv(headersENV); headers != "" {
+		parsed := map[string]string{}
+		for _, header := range strings.Split(headers, ",") {
+			key, value, ok := strings.Cut(header, "=")
+			if !ok || strings.TrimSpace(key) == "" {
+				return false, fmt.Errorf("invalid header '%s' in environment option '%s', expected key=value", header, headersENV)
+			}
+			parsed[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+
+		if !maps.Equal(cfg.Headers, parsed) {
+			cfg.Headers = parsed
+			changed = true
+		}
+	}
+
+	if interval := os.Getenv(pollingENV); interval != "" {
+		d, err := time.ParseDuration(interval)
+		if err != nil {
+			return false, fmt.Errorf("invalid value '%s' for environment option '%s': %w", interval, pollingENV, err)
+		}
+
+		if cfg.PollingInterval != d {
+			cfg.PollingInterval = d
+			changed = true
+		}
+	}
+
+	return changed, nil
+}
+
+// runConfigHistory reverts to the config version with the ID if it's set, otherwise it lists the kept versions
+func runConfigHistory(out io.Writer, history *opamp.ConfigHistory, revertVersion int) error {
+	if revertVersion < 0 {
+		return fmt.Errorf("invalid version %d", revertVersion)
+	}
+
+	if revertVersion > 0 {
+		version, err := history.Revert(revertVersion)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Reverted %s to version %d from %s\n", version.Path, version.ID, version.Timestamp.Format(time.RFC3339))
+		return nil
+	}
+
+	versions, err := history.Versions()
+	if err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		fmt.Fprintln(out, "No config versions have been kept")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tCONFIG\tAPPLIED\tHASH\tPATH")
+	for _, v := range versions {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", v.ID, v.Name, v.Timestamp.Format(time.RFC3339), v.Hash[:12], v.Path)
+	}
+	return w.Flush()
+}
@@ -15,12 +15,14 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/observiq/bindplane-otel-collector/opamp"
@@ -243,6 +245,138 @@ func TestCheckManagerConfigNoFileTLS(t *testing.T) {
 	}
 }
 
+func TestCheckManagerConfigNoFileConnection(t *testing.T) {
+	t.Setenv(endpointENV, "https://bindplane.localnet/v1/opamp")
+	t.Setenv(agentIDENV, "01HX2DWEQZ045KQR3VG0EYEZ94")
+	t.Setenv(proxyURLENV, "http://proxy.localnet:3128")
+	t.Setenv(proxyUserENV, "user")
+	t.Setenv(proxyPasswordENV, "pass")
+	t.Setenv(headersENV, "X-Gateway-Key=abc, X-Tenant=one")
+	t.Setenv(pollingENV, "10s")
+
+	tmpdir := t.TempDir()
+	manager := filepath.Join(tmpdir, "manager.yaml")
+	require.NoError(t, checkManagerConfig(&manager))
+
+	actual, err := opamp.ParseConfig(manager)
+	require.NoError(t, err)
+
+	username, password := "user", "pass"
+	expected := &opamp.Config{
+		Endpoint: "https://bindplane.localnet/v1/opamp",
+		AgentID:  Must(opamp.ParseAgentID("01HX2DWEQZ045KQR3VG0EYEZ94")),
+		Proxy: &opamp.ProxyConfig{
+			URL:      "http://proxy.localnet:3128",
+			Username: &username,
+			Password: &password,
+		},
+		Headers:         map[string]string{"X-Gateway-Key": "abc", "X-Tenant": "one"},
+		PollingInterval: 10 * time.Second,
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestManagerConfigConnectionENVOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	manager := filepath.Join(tmpDir, "manager.yaml")
+
+	id := ulid.Make()
+	data := []byte(fmt.Sprintf(`
+---
+endpoint: ws://bindplane.localnet/v1/opamp
+agent_id: %s
+proxy:
+  url: http://old-proxy.localnet:3128
+headers:
+  X-Gateway-Key: old
+`, id))
+	require.NoError(t, os.WriteFile(manager, data, 0600))
+
+	t.Setenv(proxyURLENV, "http://proxy.localnet:3128")
+	t.Setenv(headersENV, "X-Gateway-Key=new")
+	require.NoError(t, checkManagerConfig(&manager))
+
+	cfgBytes, err := os.ReadFile(manager)
+	require.NoError(t, err)
+
+	var config opamp.Config
+	require.NoError(t, yaml.Unmarshal(cfgBytes, &config))
+	require.Equal(t, id.String(), config.AgentID.String())
+	require.Equal(t, &opamp.ProxyConfig{URL: "http://proxy.localnet:3128"}, config.Proxy)
+	require.Equal(t, map[string]string{"X-Gateway-Key": "new"}, config.Headers)
+}
+
+func TestConfigureConnection(t *testing.T) {
+	testCases := []struct {
+		name        string
+		env         map[string]string
+		config      opamp.Config
+		expected    opamp.Config
+		changed     bool
+		expectedErr string
+	}{
+		{
+			name:     "no-env",
+			config:   opamp.Config{Headers: map[string]string{"a": "b"}},
+			expected: opamp.Config{Headers: map[string]string{"a": "b"}},
+		},
+		{
+			name:     "unchanged",
+			env:      map[string]string{proxyURLENV: "http://proxy.localnet:3128", pollingENV: "1m"},
+			config:   opamp.Config{Proxy: &opamp.ProxyConfig{URL: "http://proxy.localnet:3128"}, PollingInterval: time.Minute},
+			expected: opamp.Config{Proxy: &opamp.ProxyConfig{URL: "http://proxy.localnet:3128"}, PollingInterval: time.Minute},
+		},
+		{
+			name:     "credentials-for-configured-proxy",
+			env:      map[string]string{proxyUserENV: "user"},
+			config:   opamp.Config{Proxy: &opamp.ProxyConfig{URL: "http://proxy.localnet:3128"}},
+			expected: opamp.Config{Proxy: &opamp.ProxyConfig{URL: "http://proxy.localnet:3128", Username: ptr("user")}},
+			changed:  true,
+		},
+		{
+			name: "credentials-without-proxy",
+			env:  map[string]string{proxyUserENV: "user", proxyPasswordENV: "pass"},
+		},
+		{
+			name:        "invalid-proxy",
+			env:         map[string]string{proxyURLENV: "ftp://proxy.localnet"},
+			expectedErr: "invalid proxy URL",
+		},
+		{
+			name:        "invalid-header",
+			env:         map[string]string{headersENV: "X-Gateway-Key"},
+			expectedErr: "invalid header 'X-Gateway-Key' in environment option 'OPAMP_HEADERS'",
+		},
+		{
+			name:        "invalid-polling-interval",
+			env:         map[string]string{pollingENV: "soon"},
+			expectedErr: "invalid value 'soon' for environment option 'OPAMP_POLLING_INTERVAL'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			changed, err := configureConnection(&tc.config)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.changed, changed)
+			require.Equal(t, tc.expected, tc.config)
+		})
+	}
+}
+
+// ptr returns a pointer to a copy of v
+func ptr[T any](v T) *T {
+	return &v
+}
+
 func TestManagerConfigNoAgentIDWillSet(t *testing.T) {
 	tmpDir := t.TempDir()
 	manager := filepath.Join(tmpDir, "manager.yaml")
@@ -456,3 +590,35 @@ func Test_checkForCollectorRollbackConfig(t *testing.T) {
 		t.Run(tc.desc, tc.testFunc)
 	}
 }
+
+func TestRunConfigHistory(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	history := opamp.NewConfigHistory(filepath.Join(tmpDir, opamp.ConfigHistoryDirName), opamp.DefaultConfigHistorySize)
+
+	var out bytes.Buffer
+	require.NoError(t, runConfigHistory(&out, history, 0))
+	require.Equal(t, "No config versions have been kept\n", out.String())
+
+	first, err := history.Record("config.yaml", configPath, []byte("receivers: {}\n"))
+	require.NoError(t, err)
+	_, err = history.Record("config.yaml", configPath, []byte("exporters: {}\n"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configPath, []byte("exporters: {}\n"), 0600))
+
+	out.Reset()
+	require.NoError(t, runConfigHistory(&out, history, 0))
+	require.Contains(t, out.String(), "ID")
+	require.Contains(t, out.String(), first.Hash[:12])
+	require.Contains(t, out.String(), configPath)
+
+	out.Reset()
+	require.NoError(t, runConfigHistory(&out, history, first.ID))
+	require.Contains(t, out.String(), fmt.Sprintf("Reverted %s to version %d", configPath, first.ID))
+	contents, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	require.Equal(t, "receivers: {}\n", string(contents))
+
+	require.ErrorIs(t, runConfigHistory(&out, history, 100), opamp.ErrConfigVersionNotFound)
+	require.Error(t, runConfigHistory(&out, history, -1))
+}
@@ -107,8 +107,11 @@ func (g PluginDocGenerator) GeneratePluginDocs() {
 		log.Fatalln("Failed to read plugin directory", err)
 	}
 
-	// Loop through each plugin file
+	// Loop through each plugin file, skipping the Go package that embeds them
 	for _, pluginFile := range pluginFiles {
+		if filepath.Ext(pluginFile.Name()) != ".yaml" {
+			continue
+		}
 		g.generatePluginDoc(pluginFile)
 	}
 }
@@ -0,0 +1,79 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"gopkg.in/yaml.v3"
+)
+
+// errNoOutput is returned when a plugin emits nothing while updating golden files
+var errNoOutput = errors.New("plugin emitted no output")
+
+// writeLogs writes logs to the golden file at path
+func writeLogs(path string, logs plog.Logs) error {
+	if logs.LogRecordCount() == 0 {
+		return errNoOutput
+	}
+
+	data, err := marshalLogsYAML(logs)
+	if err != nil {
+		return fmt.Errorf("marshal logs: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// writeMetrics writes metrics to the golden file at path
+func writeMetrics(path string, metrics pmetric.Metrics) error {
+	if metrics.DataPointCount() == 0 {
+		return errNoOutput
+	}
+
+	data, err := golden.MarshalMetricsYAML(metrics)
+	if err != nil {
+		return fmt.Errorf("marshal metrics: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// marshalLogsYAML marshals logs to YAML in the format read by golden.ReadLogs
+func marshalLogsYAML(logs plog.Logs) ([]byte, error) {
+	marshaler := &plog.JSONMarshaler{}
+	data, err := marshaler.MarshalLogs(logs)
+	if err != nil {
+		return nil, err
+	}
+
+	var jsonVal map[string]any
+	if err := json.Unmarshal(data, &jsonVal); err != nil {
+		return nil, err
+	}
+
+	b := &bytes.Buffer{}
+	enc := yaml.NewEncoder(b)
+	enc.SetIndent(2)
+	if err := enc.Encode(jsonVal); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,57 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package main provides entry point for the plugin test harness.
+// It renders plugins with test parameters, validates the rendered config and runs
+// the plugin's pipeline against fixture files, comparing the output to golden files.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+)
+
+func main() {
+	update := pflag.Bool("update", false, "Write the output of each test to its expected file instead of comparing")
+	timeout := pflag.Duration("timeout", defaultTimeout, "The maximum time to wait for a plugin to emit its expected output")
+	pflag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: plugintest [flags] <test file>...\n")
+		pflag.PrintDefaults()
+	}
+	pflag.Parse()
+
+	if pflag.NArg() == 0 {
+		pflag.Usage()
+		os.Exit(2)
+	}
+
+	runner := newRunner(*timeout, *update)
+
+	failed := false
+	for _, path := range pflag.Args() {
+		if err := runner.Run(context.Background(), path); err != nil {
+			failed = true
+			fmt.Printf("FAIL %s: %s\n", path, err)
+			continue
+		}
+		fmt.Printf("PASS %s\n", path)
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
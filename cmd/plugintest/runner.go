@@ -0,0 +1,340 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/factories"
+	"github.com/observiq/bindplane-otel-collector/receiver/pluginreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/pmetrictest"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultTimeout is the default time to wait for a plugin to emit its expected output
+	defaultTimeout = 30 * time.Second
+
+	// pollInterval is how often the output of a plugin is checked
+	pollInterval = 100 * time.Millisecond
+
+	// settleTime is how long the output must stay unchanged before it is written to a golden file
+	settleTime = 2 * time.Second
+)
+
+// pluginID is the ID of the plugin receiver running a test
+var pluginID = component.MustNewIDWithName("plugin", "test")
+
+// outputType is the type of the exporter the plugin receiver adds to a rendered config
+var outputType = component.MustNewType("plugin_output")
+
+// Runner runs plugin test cases
+type Runner struct {
+	timeout   time.Duration
+	update    bool
+	logger    *zap.Logger
+	factories func() (otelcol.Factories, error)
+}
+
+// newRunner creates a Runner that waits up to timeout for output.
+// If update is set, the output is written to the golden files instead of being compared.
+func newRunner(timeout time.Duration, update bool) *Runner {
+	logger, err := zap.NewProduction(zap.IncreaseLevel(zap.WarnLevel))
+	if err != nil {
+		logger = zap.NewNop()
+	}
+
+	return &Runner{
+		timeout:   timeout,
+		update:    update,
+		logger:    logger,
+		factories: factories.DefaultFactories,
+	}
+}
+
+// Run runs the test case in the file at path
+func (r *Runner) Run(ctx context.Context, path string) error {
+	tc, err := LoadTestCase(path)
+	if err != nil {
+		return err
+	}
+
+	plugin, err := pluginreceiver.LoadPlugin(tc.PluginPath())
+	if err != nil {
+		return fmt.Errorf("load plugin: %w", err)
+	}
+
+	params := tc.RenderParameters()
+	if err := plugin.CheckParameters(params); err != nil {
+		return fmt.Errorf("check parameters: %w", err)
+	}
+
+	renderedCfg, err := plugin.Render(params, pluginID)
+	if err != nil {
+		return fmt.Errorf("render plugin: %w", err)
+	}
+
+	factories, err := r.factories()
+	if err != nil {
+		return fmt.Errorf("get factories: %w", err)
+	}
+	host := &factoryHost{factories: factories}
+
+	if err := validateRenderedConfig(ctx, renderedCfg, host); err != nil {
+		return fmt.Errorf("validate rendered config: %w", err)
+	}
+
+	switch tc.Signal {
+	case signalMetrics:
+		return r.runMetrics(ctx, tc, params, host)
+	default:
+		return r.runLogs(ctx, tc, params, host)
+	}
+}
+
+// validateRenderedConfig checks that the rendered config only uses components available
+// in the collector and that each component's config is valid, without starting the pipeline
+func validateRenderedConfig(ctx context.Context, renderedCfg *pluginreceiver.RenderedConfig, host component.Host) error {
+	outputFactory := exporter.NewFactory(outputType, func() component.Config { return &struct{}{} })
+	required, err := renderedCfg.GetRequiredFactories(host, outputFactory)
+	if err != nil {
+		return err
+	}
+
+	cfgProviderSettings, err := renderedCfg.GetConfigProviderSettings()
+	if err != nil {
+		return err
+	}
+
+	col, err := otelcol.NewCollector(otelcol.CollectorSettings{
+		Factories:              func() (otelcol.Factories, error) { return *required, nil },
+		ConfigProviderSettings: *cfgProviderSettings,
+	})
+	if err != nil {
+		return err
+	}
+
+	return col.DryRun(ctx)
+}
+
+// runLogs runs a plugin that emits logs and compares all emitted logs to the golden file
+func (r *Runner) runLogs(ctx context.Context, tc *TestCase, params map[string]any, host component.Host) error {
+	expectedCount := 0
+	var expected plog.Logs
+	if !r.update {
+		var err error
+		expected, err = golden.ReadLogs(tc.ExpectedPath())
+		if err != nil {
+			return fmt.Errorf("read expected logs: %w", err)
+		}
+		expectedCount = expected.LogRecordCount()
+	}
+
+	sink := &consumertest.LogsSink{}
+	err := r.runPlugin(ctx, tc, params, host, sink.LogRecordCount, expectedCount, func(f receiver.Factory, set receiver.Settings, cfg component.Config) (component.Component, error) {
+		return f.CreateLogs(ctx, set, cfg, sink)
+	})
+	if err != nil {
+		return err
+	}
+
+	actual := plog.NewLogs()
+	for _, logs := range sink.AllLogs() {
+		logs.ResourceLogs().MoveAndAppendTo(actual.ResourceLogs())
+	}
+
+	if r.update {
+		return writeLogs(tc.ExpectedPath(), actual)
+	}
+
+	opts := []plogtest.CompareLogsOption{
+		plogtest.IgnoreObservedTimestamp(),
+		plogtest.IgnoreResourceLogsOrder(),
+		plogtest.IgnoreScopeLogsOrder(),
+		plogtest.IgnoreLogRecordsOrder(),
+	}
+	if tc.IgnoreTimestamps {
+		opts = append(opts, plogtest.IgnoreTimestamp())
+	}
+	for _, name := range tc.IgnoreAttributes {
+		opts = append(opts, plogtest.IgnoreLogRecordAttributeValue(name))
+	}
+	for _, name := range tc.IgnoreResourceAttributes {
+		opts = append(opts, plogtest.IgnoreResourceAttributeValue(name))
+	}
+
+	return plogtest.CompareLogs(expected, actual, opts...)
+}
+
+// runMetrics runs a plugin that emits metrics and compares the first batch of emitted metrics,
+// usually a single scrape, to the golden file
+func (r *Runner) runMetrics(ctx context.Context, tc *TestCase, params map[string]any, host component.Host) error {
+	var expected pmetric.Metrics
+	if !r.update {
+		var err error
+		expected, err = golden.ReadMetrics(tc.ExpectedPath())
+		if err != nil {
+			return fmt.Errorf("read expected metrics: %w", err)
+		}
+	}
+
+	sink := &consumertest.MetricsSink{}
+	batches := func() int { return len(sink.AllMetrics()) }
+	err := r.runPlugin(ctx, tc, params, host, batches, 1, func(f receiver.Factory, set receiver.Settings, cfg component.Config) (component.Component, error) {
+		return f.CreateMetrics(ctx, set, cfg, sink)
+	})
+	if err != nil {
+		return err
+	}
+
+	actual := pmetric.NewMetrics()
+	if all := sink.AllMetrics(); len(all) > 0 {
+		actual = all[0]
+	}
+
+	if r.update {
+		return writeMetrics(tc.ExpectedPath(), actual)
+	}
+
+	opts := []pmetrictest.CompareMetricsOption{
+		pmetrictest.IgnoreTimestamp(),
+		pmetrictest.IgnoreStartTimestamp(),
+		pmetrictest.IgnoreResourceMetricsOrder(),
+		pmetrictest.IgnoreMetricsOrder(),
+		pmetrictest.IgnoreMetricDataPointsOrder(),
+	}
+	for _, name := range tc.IgnoreAttributes {
+		opts = append(opts, pmetrictest.IgnoreMetricAttributeValue(name))
+	}
+	for _, name := range tc.IgnoreResourceAttributes {
+		opts = append(opts, pmetrictest.IgnoreResourceAttributeValue(name))
+	}
+
+	return pmetrictest.CompareMetrics(expected, actual, opts...)
+}
+
+// createFunc creates a plugin receiver for the signal under test
+type createFunc func(receiver.Factory, receiver.Settings, component.Config) (component.Component, error)
+
+// runPlugin runs the plugin receiver until count reaches expectedCount or the timeout is reached.
+// When updating golden files, it runs until the output stops changing instead.
+func (r *Runner) runPlugin(ctx context.Context, tc *TestCase, params map[string]any, host component.Host, count func() int, expectedCount int, create createFunc) error {
+	factory := pluginreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*pluginreceiver.Config)
+	cfg.Path = tc.PluginPath()
+	cfg.Parameters = params
+
+	set := receivertest.NewNopSettings()
+	set.ID = pluginID
+	set.Logger = r.logger
+
+	rcv, err := create(factory, set, cfg)
+	if err != nil {
+		return fmt.Errorf("create plugin receiver: %w", err)
+	}
+
+	if err := rcv.Start(ctx, host); err != nil {
+		return fmt.Errorf("start plugin receiver: %w", err)
+	}
+
+	r.await(ctx, count, expectedCount)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+	if err := rcv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown plugin receiver: %w", err)
+	}
+
+	return nil
+}
+
+// await waits until the output of the plugin is complete or the timeout is reached
+func (r *Runner) await(ctx context.Context, count func() int, expectedCount int) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	timeout := time.NewTimer(r.timeout)
+	defer timeout.Stop()
+
+	last, lastChange := 0, time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timeout.C:
+			return
+		case <-ticker.C:
+			current := count()
+			if !r.update && expectedCount > 0 && current >= expectedCount {
+				return
+			}
+
+			if current != last {
+				last, lastChange = current, time.Now()
+				continue
+			}
+
+			if r.update && current > 0 && time.Since(lastChange) >= settleTime {
+				return
+			}
+		}
+	}
+}
+
+// factoryHost is a component.Host that provides the collector's factories to the plugin receiver
+type factoryHost struct {
+	factories otelcol.Factories
+}
+
+// GetExtensions returns no extensions
+func (h *factoryHost) GetExtensions() map[component.ID]component.Component {
+	return nil
+}
+
+// GetFactory returns the factory of the given kind and type
+func (h *factoryHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	var factory component.Factory
+	var ok bool
+
+	switch kind {
+	case component.KindReceiver:
+		factory, ok = h.factories.Receivers[componentType]
+	case component.KindProcessor:
+		factory, ok = h.factories.Processors[componentType]
+	case component.KindExporter:
+		factory, ok = h.factories.Exporters[componentType]
+	case component.KindExtension:
+		factory, ok = h.factories.Extensions[componentType]
+	case component.KindConnector:
+		factory, ok = h.factories.Connectors[componentType]
+	}
+
+	if !ok {
+		return nil
+	}
+	return factory
+}
@@ -0,0 +1,113 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+)
+
+func TestRunnerLogs(t *testing.T) {
+	runner := newRunner(10*time.Second, false)
+	require.NoError(t, runner.Run(context.Background(), filepath.Join("testdata", "logs", "test.yaml")))
+}
+
+func TestRunnerLogsMismatch(t *testing.T) {
+	dir := writeLogsTest(t, `
+plugin: `+absTestdata(t, "logs", "plugin.yaml")+`
+parameters:
+  file_path:
+    - `+absTestdata(t, "logs", "app.log")+`
+  service_name: payments
+expected: `+absTestdata(t, "logs", "expected.yaml")+`
+`)
+
+	runner := newRunner(10*time.Second, false)
+	err := runner.Run(context.Background(), filepath.Join(dir, "test.yaml"))
+	require.ErrorContains(t, err, "service.name")
+}
+
+func TestRunnerUpdate(t *testing.T) {
+	dir := writeLogsTest(t, `
+plugin: `+absTestdata(t, "logs", "plugin.yaml")+`
+parameters:
+  file_path:
+    - `+absTestdata(t, "logs", "app.log")+`
+`)
+	testPath := filepath.Join(dir, "test.yaml")
+
+	// The expected file doesn't exist until it is written by an update
+	runner := newRunner(10*time.Second, false)
+	require.ErrorContains(t, runner.Run(context.Background(), testPath), "read expected logs")
+
+	runner = newRunner(10*time.Second, true)
+	require.NoError(t, runner.Run(context.Background(), testPath))
+	require.FileExists(t, filepath.Join(dir, "expected.yaml"))
+
+	runner = newRunner(10*time.Second, false)
+	require.NoError(t, runner.Run(context.Background(), testPath))
+}
+
+func TestRunnerInvalidConfig(t *testing.T) {
+	runner := newRunner(10*time.Second, false)
+	err := runner.Run(context.Background(), filepath.Join("testdata", "invalid_config", "test.yaml"))
+	require.ErrorContains(t, err, "validate rendered config")
+	require.ErrorContains(t, err, "send_batch_max_size must be greater or equal to send_batch_size")
+}
+
+func TestRunnerInvalidParameters(t *testing.T) {
+	dir := writeLogsTest(t, `
+plugin: `+absTestdata(t, "logs", "plugin.yaml")+`
+parameters:
+  service_name: payments
+`)
+
+	runner := newRunner(10*time.Second, false)
+	err := runner.Run(context.Background(), filepath.Join(dir, "test.yaml"))
+	require.ErrorContains(t, err, "check parameters")
+}
+
+func TestFactoryHost(t *testing.T) {
+	runner := newRunner(time.Second, false)
+	factories, err := runner.factories()
+	require.NoError(t, err)
+
+	host := &factoryHost{factories: factories}
+	require.Nil(t, host.GetExtensions())
+	for receiverType, factory := range factories.Receivers {
+		require.Equal(t, factory, host.GetFactory(component.KindReceiver, receiverType))
+	}
+	require.Nil(t, host.GetFactory(component.KindReceiver, component.MustNewType("missing")))
+}
+
+// writeLogsTest writes a test file with contents to a temporary directory and returns the directory
+func writeLogsTest(t *testing.T, contents string) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(contents), 0o600))
+	return dir
+}
+
+// absTestdata returns the absolute path of a file in testdata
+func absTestdata(t *testing.T, elem ...string) string {
+	path, err := filepath.Abs(filepath.Join(append([]string{"testdata"}, elem...)...))
+	require.NoError(t, err)
+	return path
+}
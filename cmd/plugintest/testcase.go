@@ -0,0 +1,154 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	signalLogs    = "logs"
+	signalMetrics = "metrics"
+
+	// testDirVariable is expanded to the directory of the test file in string parameters
+	testDirVariable = "${TEST_DIR}"
+)
+
+// TestCase describes a plugin, the parameters to render it with and the output it is expected to emit
+type TestCase struct {
+	// Plugin is the path to the plugin file, relative to the test file
+	Plugin string `yaml:"plugin"`
+
+	// Parameters are the parameters used to render the plugin.
+	// ${TEST_DIR} in string values is replaced by the directory of the test file.
+	Parameters map[string]any `yaml:"parameters"`
+
+	// Signal is the type of telemetry the plugin emits, either logs or metrics
+	Signal string `yaml:"signal"`
+
+	// Expected is the path to the golden file, relative to the test file
+	Expected string `yaml:"expected"`
+
+	// IgnoreTimestamps ignores log record timestamps when comparing to the golden file.
+	// Observed timestamps and metric timestamps are always ignored.
+	IgnoreTimestamps bool `yaml:"ignore_timestamps"`
+
+	// IgnoreAttributes are log record or data point attributes whose values are ignored
+	IgnoreAttributes []string `yaml:"ignore_attributes"`
+
+	// IgnoreResourceAttributes are resource attributes whose values are ignored
+	IgnoreResourceAttributes []string `yaml:"ignore_resource_attributes"`
+
+	// dir is the directory of the test file
+	dir string
+}
+
+// LoadTestCase loads a test case from the file at path
+func LoadTestCase(path string) (*TestCase, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read test file: %w", err)
+	}
+
+	tc := &TestCase{
+		Signal:   signalLogs,
+		Expected: "expected.yaml",
+	}
+	if err := yaml.Unmarshal(data, tc); err != nil {
+		return nil, fmt.Errorf("unmarshal test file: %w", err)
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("resolve test directory: %w", err)
+	}
+	tc.dir = dir
+
+	if err := tc.validate(); err != nil {
+		return nil, fmt.Errorf("invalid test file: %w", err)
+	}
+
+	return tc, nil
+}
+
+// validate validates the test case
+func (tc *TestCase) validate() error {
+	if tc.Plugin == "" {
+		return errors.New("plugin is required")
+	}
+
+	switch tc.Signal {
+	case signalLogs, signalMetrics:
+	default:
+		return fmt.Errorf("signal must be %s or %s, got %q", signalLogs, signalMetrics, tc.Signal)
+	}
+
+	return nil
+}
+
+// PluginPath returns the absolute path to the plugin file
+func (tc *TestCase) PluginPath() string {
+	return tc.resolve(tc.Plugin)
+}
+
+// ExpectedPath returns the absolute path to the golden file
+func (tc *TestCase) ExpectedPath() string {
+	return tc.resolve(tc.Expected)
+}
+
+// RenderParameters returns the parameters with ${TEST_DIR} expanded in string values
+func (tc *TestCase) RenderParameters() map[string]any {
+	params := make(map[string]any, len(tc.Parameters))
+	for key, value := range tc.Parameters {
+		params[key] = tc.expand(value)
+	}
+	return params
+}
+
+// expand expands ${TEST_DIR} in string values, including values nested in lists and maps
+func (tc *TestCase) expand(value any) any {
+	switch v := value.(type) {
+	case string:
+		return strings.ReplaceAll(v, testDirVariable, tc.dir)
+	case []any:
+		expanded := make([]any, 0, len(v))
+		for _, item := range v {
+			expanded = append(expanded, tc.expand(item))
+		}
+		return expanded
+	case map[string]any:
+		expanded := make(map[string]any, len(v))
+		for key, item := range v {
+			expanded[key] = tc.expand(item)
+		}
+		return expanded
+	default:
+		return value
+	}
+}
+
+// resolve resolves a path relative to the test file
+func (tc *TestCase) resolve(path string) string {
+	if filepath.IsAbs(path) || strings.TrimSpace(path) == "" {
+		return path
+	}
+	return filepath.Join(tc.dir, path)
+}
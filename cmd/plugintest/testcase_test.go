@@ -0,0 +1,108 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTestCase(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name        string
+		contents    string
+		expected    func(tc *TestCase)
+		expectedErr string
+	}{
+		{
+			name: "defaults",
+			contents: `
+plugin: plugin.yaml
+parameters:
+  file_path: ["${TEST_DIR}/app.log"]
+  pattern: '^\d+$'
+  nested:
+    path: ${TEST_DIR}/nested.log
+`,
+			expected: func(tc *TestCase) {
+				require.Equal(t, signalLogs, tc.Signal)
+				require.Equal(t, filepath.Join(dir, "plugin.yaml"), tc.PluginPath())
+				require.Equal(t, filepath.Join(dir, "expected.yaml"), tc.ExpectedPath())
+				require.Equal(t, map[string]any{
+					"file_path": []any{filepath.Join(dir, "app.log")},
+					"pattern":   `^\d+$`,
+					"nested": map[string]any{
+						"path": filepath.Join(dir, "nested.log"),
+					},
+				}, tc.RenderParameters())
+			},
+		},
+		{
+			name: "absolute paths and metrics",
+			contents: `
+plugin: /plugins/plugin.yaml
+signal: metrics
+expected: /golden/metrics.yaml
+ignore_attributes: [host.name]
+`,
+			expected: func(tc *TestCase) {
+				require.Equal(t, signalMetrics, tc.Signal)
+				require.Equal(t, "/plugins/plugin.yaml", tc.PluginPath())
+				require.Equal(t, "/golden/metrics.yaml", tc.ExpectedPath())
+				require.Equal(t, []string{"host.name"}, tc.IgnoreAttributes)
+			},
+		},
+		{
+			name:        "missing plugin",
+			contents:    "signal: logs",
+			expectedErr: "plugin is required",
+		},
+		{
+			name:        "invalid signal",
+			contents:    "plugin: plugin.yaml\nsignal: traces",
+			expectedErr: `signal must be logs or metrics, got "traces"`,
+		},
+		{
+			name:        "invalid yaml",
+			contents:    "plugin: [",
+			expectedErr: "unmarshal test file",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, "test.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.contents), 0o600))
+
+			testCase, err := LoadTestCase(path)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			tc.expected(testCase)
+		})
+	}
+}
+
+func TestLoadTestCaseMissingFile(t *testing.T) {
+	_, err := LoadTestCase(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.ErrorContains(t, err, "read test file")
+}
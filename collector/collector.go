@@ -43,6 +43,9 @@ type Collector interface {
 	SetLoggingOpts([]zap.Option)
 	GetLoggingOpts() []zap.Option
 	Status() <-chan *Status
+	ComponentHealth() map[string]*ComponentHealth
+	ValidateConfig(context.Context, []byte) error
+	SetOwnTelemetry(metrics, logs *TelemetryDestination)
 }
 
 // collector is the standard implementation of the Collector interface.
@@ -55,8 +58,13 @@ type collector struct {
 	mux        sync.Mutex
 	svc        *otelcol.Collector
 	statusChan chan *Status
+	health     *healthTracker
 	wg         *sync.WaitGroup
 
+	// ownMetrics and ownLogs are where the collector sends its own telemetry, if set
+	ownMetrics *TelemetryDestination
+	ownLogs    *TelemetryDestination
+
 	// collectorCtx is the context that is fed into collector.Run
 	// Cancelling it will force shutdown
 	collectorCtx       context.Context
@@ -70,11 +78,15 @@ func New(configPaths []string, version string, loggingOpts []zap.Option) (Collec
 		return nil, fmt.Errorf("error while setting up default factories: %w", err)
 	}
 
+	health := newHealthTracker()
+	factories.Extensions[healthType] = newHealthExtensionFactory(health)
+
 	return &collector{
 		configPaths: configPaths,
 		version:     version,
 		loggingOpts: loggingOpts,
 		statusChan:  make(chan *Status, 10),
+		health:      health,
 		wg:          &sync.WaitGroup{},
 		factories:   factories,
 	}, nil
@@ -90,6 +102,16 @@ func (c *collector) SetLoggingOpts(opts []zap.Option) {
 	c.loggingOpts = opts
 }
 
+// SetOwnTelemetry sets where the collector sends its own metrics and logs. A nil destination is not sent to.
+// These will take effect on next restart
+func (c *collector) SetOwnTelemetry(metrics, logs *TelemetryDestination) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.ownMetrics = metrics
+	c.ownLogs = logs
+}
+
 // Run will run the collector. This function will return an error
 // if the collector was unable to startup.
 func (c *collector) Run(ctx context.Context) error {
@@ -100,6 +122,11 @@ func (c *collector) Run(ctx context.Context) error {
 		return errors.New("service already running")
 	}
 
+	// Forget the health of components from the previous run
+	if c.health != nil {
+		c.health.Reset()
+	}
+
 	// The OT collector only supports using settings once during the lifetime
 	// of a single collector instance. We must remake the settings on each startup.
 	settings, err := NewSettings(c.configPaths, c.version, c.loggingOpts, c.factories)
@@ -107,6 +134,13 @@ func (c *collector) Run(ctx context.Context) error {
 		return err
 	}
 
+	if c.ownMetrics != nil || c.ownLogs != nil {
+		settings.ConfigProviderSettings.ResolverSettings.ConverterFactories = append(
+			settings.ConfigProviderSettings.ResolverSettings.ConverterFactories,
+			newOwnTelemetryConverterFactory(c.ownMetrics, c.ownLogs),
+		)
+	}
+
 	// The OT collector only supports calling run once during the lifetime
 	// of a service. We must make a new instance each time we run the collector.
 	svc, err := otelcol.NewCollector(*settings)
@@ -252,6 +286,15 @@ func (c *collector) Status() <-chan *Status {
 	return c.statusChan
 }
 
+// ComponentHealth returns the health of each component in the running collector,
+// keyed by kind and ID such as "receiver:otlp"
+func (c *collector) ComponentHealth() map[string]*ComponentHealth {
+	if c.health == nil {
+		return map[string]*ComponentHealth{}
+	}
+	return c.health.Components()
+}
+
 // sendStatus will set the status of the collector
 func (c *collector) sendStatus(running, panicked bool, err error) {
 	select {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 )
@@ -46,6 +47,28 @@ func TestCollectorRunValid(t *testing.T) {
 	require.False(t, status.Running)
 }
 
+func TestCollectorComponentHealth(t *testing.T) {
+	ctx := context.Background()
+
+	collector, err := New([]string{"./test/valid.yaml"}, "0.0.0", nil)
+	require.NoError(t, err)
+	require.Empty(t, collector.ComponentHealth())
+
+	err = collector.Run(ctx)
+	require.NoError(t, err)
+	defer collector.Stop(ctx)
+
+	health := collector.ComponentHealth()
+	require.Contains(t, health, "receiver:filelog")
+	require.Contains(t, health, "exporter:nop")
+	require.NotContains(t, health, "extension:bindplane_health")
+
+	receiverHealth := health["receiver:filelog"]
+	require.Equal(t, componentstatus.StatusOK, receiverHealth.Status)
+	require.True(t, receiverHealth.Healthy())
+	require.False(t, receiverHealth.StartTime.IsZero())
+}
+
 func TestCollectorRunMultiple(t *testing.T) {
 	collector, err := New([]string{"./test/valid.yaml"}, "0.0.0", nil)
 	require.NoError(t, err)
@@ -0,0 +1,212 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// healthType is the type of the extension added to the collector's config to track component health
+var healthType = component.MustNewType("bindplane_health")
+
+// ComponentHealth is the health of a component running in the collector
+type ComponentHealth struct {
+	// ID is the ID of the component
+	ID component.ID
+
+	// Kind is the kind of the component
+	Kind component.Kind
+
+	// Pipelines are the IDs of the pipelines the component runs in, sorted.
+	// Extensions don't run in a pipeline.
+	Pipelines []string
+
+	// Status is the most recent status reported for the component
+	Status componentstatus.Status
+
+	// LastError is the most recent error reported for the component.
+	// It is kept after the component recovers.
+	LastError error
+
+	// StartTime is when the component started
+	StartTime time.Time
+
+	// StatusTime is when the most recent status was reported
+	StatusTime time.Time
+}
+
+// Healthy returns true if the component is not in an error state
+func (h ComponentHealth) Healthy() bool {
+	return !componentstatus.StatusIsError(h.Status)
+}
+
+// healthTracker tracks the health of the components in the running collector
+type healthTracker struct {
+	mu         sync.Mutex
+	components map[string]*ComponentHealth
+}
+
+// newHealthTracker creates a new healthTracker
+func newHealthTracker() *healthTracker {
+	return &healthTracker{
+		components: make(map[string]*ComponentHealth),
+	}
+}
+
+// Reset forgets the health of all components
+func (h *healthTracker) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.components = make(map[string]*ComponentHealth)
+}
+
+// Components returns a copy of the health of each component, keyed by kind and ID such as "receiver:otlp"
+func (h *healthTracker) Components() map[string]*ComponentHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	components := make(map[string]*ComponentHealth, len(h.components))
+	for key, health := range h.components {
+		healthCopy := *health
+		healthCopy.Pipelines = slices.Clone(health.Pipelines)
+		components[key] = &healthCopy
+	}
+	return components
+}
+
+// ComponentStatusChanged records a status event for a component
+func (h *healthTracker) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	// Don't report the health of the extension tracking it
+	if source.ComponentID().Type() == healthType {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := componentHealthKey(source.Kind(), source.ComponentID())
+	health, ok := h.components[key]
+	if !ok {
+		health = &ComponentHealth{
+			ID:   source.ComponentID(),
+			Kind: source.Kind(),
+		}
+		h.components[key] = health
+	}
+
+	// Instances of a component in different pipelines share its health
+	source.AllPipelineIDs(func(id pipeline.ID) bool {
+		if p := id.String(); !slices.Contains(health.Pipelines, p) {
+			health.Pipelines = append(health.Pipelines, p)
+			slices.Sort(health.Pipelines)
+		}
+		return true
+	})
+
+	health.Status = event.Status()
+	health.StatusTime = event.Timestamp()
+	if event.Status() == componentstatus.StatusStarting {
+		health.StartTime = event.Timestamp()
+	}
+	if event.Err() != nil {
+		health.LastError = event.Err()
+	}
+}
+
+// componentHealthKey returns the key of a component's health
+func componentHealthKey(kind component.Kind, id component.ID) string {
+	return strings.ToLower(kind.String()) + ":" + id.String()
+}
+
+// healthExtension is an extension that records the status of every component in a healthTracker
+type healthExtension struct {
+	*healthTracker
+}
+
+// Start is a no-op that fulfills the component.Component interface
+func (healthExtension) Start(context.Context, component.Host) error {
+	return nil
+}
+
+// Shutdown is a no-op that fulfills the component.Component interface
+func (healthExtension) Shutdown(context.Context) error {
+	return nil
+}
+
+// newHealthExtensionFactory creates a factory for an extension that records component health in tracker
+func newHealthExtensionFactory(tracker *healthTracker) extension.Factory {
+	return extension.NewFactory(
+		healthType,
+		func() component.Config { return &struct{}{} },
+		func(context.Context, extension.Settings, component.Config) (extension.Extension, error) {
+			return healthExtension{tracker}, nil
+		},
+		component.StabilityLevelStable,
+	)
+}
+
+// newHealthConverterFactory creates a factory for a converter that enables the health extension in the collector's config
+func newHealthConverterFactory() confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+		return healthConverter{}
+	})
+}
+
+// healthConverter enables the health extension in the collector's config
+type healthConverter struct{}
+
+// Convert adds the health extension to the extensions of the service
+func (healthConverter) Convert(_ context.Context, conf *confmap.Conf) error {
+	// Leave configs without a service alone so they fail validation as usual
+	if !conf.IsSet("service") {
+		return nil
+	}
+
+	id := component.NewID(healthType).String()
+
+	var extensions []any
+	if raw := conf.Get("service::extensions"); raw != nil {
+		list, ok := raw.([]any)
+		if !ok {
+			return nil
+		}
+		extensions = list
+	}
+
+	for _, ext := range extensions {
+		if ext == id {
+			return nil
+		}
+	}
+
+	return conf.Merge(confmap.NewFromStringMap(map[string]any{
+		"extensions": map[string]any{
+			id: map[string]any{},
+		},
+		"service": map[string]any{
+			"extensions": append(extensions, id),
+		},
+	}))
+}
@@ -0,0 +1,175 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func TestHealthTracker(t *testing.T) {
+	tracker := newHealthTracker()
+	otlp := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver)
+
+	starting := componentstatus.NewEvent(componentstatus.StatusStarting)
+	tracker.ComponentStatusChanged(otlp, starting)
+	tracker.ComponentStatusChanged(otlp, componentstatus.NewEvent(componentstatus.StatusOK))
+
+	health := tracker.Components()
+	require.Len(t, health, 1)
+	require.Equal(t, component.MustNewID("otlp"), health["receiver:otlp"].ID)
+	require.Equal(t, component.KindReceiver, health["receiver:otlp"].Kind)
+	require.Equal(t, componentstatus.StatusOK, health["receiver:otlp"].Status)
+	require.Equal(t, starting.Timestamp(), health["receiver:otlp"].StartTime)
+	require.True(t, health["receiver:otlp"].Healthy())
+
+	// The last error is kept after the component recovers
+	tracker.ComponentStatusChanged(otlp, componentstatus.NewRecoverableErrorEvent(errors.New("bind failed")))
+	require.False(t, tracker.Components()["receiver:otlp"].Healthy())
+	tracker.ComponentStatusChanged(otlp, componentstatus.NewEvent(componentstatus.StatusOK))
+	health = tracker.Components()
+	require.True(t, health["receiver:otlp"].Healthy())
+	require.EqualError(t, health["receiver:otlp"].LastError, "bind failed")
+
+	// Returned health is a copy
+	health["receiver:otlp"].Status = componentstatus.StatusFatalError
+	require.Equal(t, componentstatus.StatusOK, tracker.Components()["receiver:otlp"].Status)
+
+	// The health extension itself isn't tracked
+	tracker.ComponentStatusChanged(componentstatus.NewInstanceID(component.NewID(healthType), component.KindExtension), componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Len(t, tracker.Components(), 1)
+
+	tracker.Reset()
+	require.Empty(t, tracker.Components())
+}
+
+func TestHealthTrackerPipelines(t *testing.T) {
+	tracker := newHealthTracker()
+	exporterID := component.MustNewID("otlp")
+	traces := pipeline.NewID(pipeline.SignalTraces)
+	metrics := pipeline.NewIDWithName(pipeline.SignalMetrics, "custom")
+
+	// Each pipeline's instance of the component reports its pipelines
+	tracker.ComponentStatusChanged(componentstatus.NewInstanceID(exporterID, component.KindExporter, traces), componentstatus.NewEvent(componentstatus.StatusOK))
+	tracker.ComponentStatusChanged(componentstatus.NewInstanceID(exporterID, component.KindExporter, metrics), componentstatus.NewEvent(componentstatus.StatusOK))
+	tracker.ComponentStatusChanged(componentstatus.NewInstanceID(exporterID, component.KindExporter, traces), componentstatus.NewEvent(componentstatus.StatusOK))
+
+	health := tracker.Components()
+	require.Equal(t, []string{"metrics/custom", "traces"}, health["exporter:otlp"].Pipelines)
+
+	// Returned pipelines are a copy
+	health["exporter:otlp"].Pipelines[0] = "logs"
+	require.Equal(t, []string{"metrics/custom", "traces"}, tracker.Components()["exporter:otlp"].Pipelines)
+}
+
+func TestHealthConverter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		conf     map[string]any
+		expected map[string]any
+	}{
+		{
+			name: "no service",
+			conf: map[string]any{
+				"receivers": map[string]any{"otlp": nil},
+			},
+			expected: map[string]any{
+				"receivers": map[string]any{"otlp": nil},
+			},
+		},
+		{
+			name: "no extensions",
+			conf: map[string]any{
+				"service": map[string]any{
+					"pipelines": map[string]any{},
+				},
+			},
+			expected: map[string]any{
+				"extensions": map[string]any{"bindplane_health": map[string]any{}},
+				"service": map[string]any{
+					"pipelines":  map[string]any{},
+					"extensions": []any{"bindplane_health"},
+				},
+			},
+		},
+		{
+			name: "existing extensions",
+			conf: map[string]any{
+				"extensions": map[string]any{"pprof": nil},
+				"service": map[string]any{
+					"extensions": []any{"pprof"},
+				},
+			},
+			expected: map[string]any{
+				"extensions": map[string]any{"pprof": nil, "bindplane_health": map[string]any{}},
+				"service": map[string]any{
+					"extensions": []any{"pprof", "bindplane_health"},
+				},
+			},
+		},
+		{
+			name: "already enabled",
+			conf: map[string]any{
+				"extensions": map[string]any{"bindplane_health": nil},
+				"service": map[string]any{
+					"extensions": []any{"bindplane_health"},
+				},
+			},
+			expected: map[string]any{
+				"extensions": map[string]any{"bindplane_health": nil},
+				"service": map[string]any{
+					"extensions": []any{"bindplane_health"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(tc.conf)
+			converter := newHealthConverterFactory().Create(confmap.ConverterSettings{})
+			require.NoError(t, converter.Convert(context.Background(), conf))
+			require.Equal(t, tc.expected, conf.ToStringMap())
+		})
+	}
+}
+
+func TestHealthExtension(t *testing.T) {
+	tracker := newHealthTracker()
+	factory := newHealthExtensionFactory(tracker)
+	require.Equal(t, healthType, factory.Type())
+
+	ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(), factory.CreateDefaultConfig())
+	require.NoError(t, err)
+	require.Implements(t, (*componentstatus.Watcher)(nil), ext)
+	require.NoError(t, ext.Start(context.Background(), nil))
+
+	ext.(componentstatus.Watcher).ComponentStatusChanged(
+		componentstatus.NewInstanceID(component.MustNewID("nop"), component.KindExporter),
+		componentstatus.NewEvent(componentstatus.StatusOK),
+	)
+	require.Contains(t, tracker.Components(), "exporter:nop")
+	require.WithinDuration(t, time.Now(), tracker.Components()["exporter:nop"].StatusTime, time.Minute)
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -17,6 +17,26 @@ type MockCollector struct {
 	mock.Mock
 }
 
+// ComponentHealth provides a mock function with no fields
+func (_m *MockCollector) ComponentHealth() map[string]*collector.ComponentHealth {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ComponentHealth")
+	}
+
+	var r0 map[string]*collector.ComponentHealth
+	if rf, ok := ret.Get(0).(func() map[string]*collector.ComponentHealth); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*collector.ComponentHealth)
+		}
+	}
+
+	return r0
+}
+
 // GetLoggingOpts provides a mock function with no fields
 func (_m *MockCollector) GetLoggingOpts() []zap.Option {
 	ret := _m.Called()
@@ -78,6 +98,11 @@ func (_m *MockCollector) SetLoggingOpts(_a0 []zap.Option) {
 	_m.Called(_a0)
 }
 
+// SetOwnTelemetry provides a mock function with given fields: metrics, logs
+func (_m *MockCollector) SetOwnTelemetry(metrics *collector.TelemetryDestination, logs *collector.TelemetryDestination) {
+	_m.Called(metrics, logs)
+}
+
 // Status provides a mock function with no fields
 func (_m *MockCollector) Status() <-chan *collector.Status {
 	ret := _m.Called()
@@ -103,6 +128,24 @@ func (_m *MockCollector) Stop(_a0 context.Context) {
 	_m.Called(_a0)
 }
 
+// ValidateConfig provides a mock function with given fields: _a0, _a1
+func (_m *MockCollector) ValidateConfig(_a0 context.Context, _a1 []byte) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockCollector creates a new instance of MockCollector. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCollector(t interface {
@@ -0,0 +1,154 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// ownTelemetryProtocol is the OTLP protocol used to send the collector's own telemetry
+const ownTelemetryProtocol = "http/protobuf"
+
+// TelemetryDestination is an OTLP/HTTP endpoint the collector sends its own telemetry to
+type TelemetryDestination struct {
+	// Endpoint is the full URL of the OTLP/HTTP receiver, including the path
+	Endpoint string
+
+	// Headers are sent with each export request
+	Headers map[string]string
+}
+
+// defaultMetricsReader is the Prometheus reader the collector uses when no readers or address are configured.
+// Setting readers replaces it, so it's kept to leave the local telemetry endpoint available.
+var defaultMetricsReader = map[string]any{
+	"pull": map[string]any{
+		"exporter": map[string]any{
+			"prometheus": map[string]any{
+				"host": "localhost",
+				"port": 8888,
+			},
+		},
+	},
+}
+
+// ownMetricsLevel is the level metrics are recorded at when they were disabled and a destination is offered
+const ownMetricsLevel = "normal"
+
+func newOwnTelemetryConverterFactory(metrics, logs *TelemetryDestination) confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+		return ownTelemetryConverter{metrics: metrics, logs: logs}
+	})
+}
+
+// ownTelemetryConverter adds OTLP exporters for the collector's own metrics and logs to the service telemetry
+type ownTelemetryConverter struct {
+	metrics *TelemetryDestination
+	logs    *TelemetryDestination
+}
+
+// Convert appends a periodic metric reader and a batch log processor for the destinations
+func (o ownTelemetryConverter) Convert(_ context.Context, conf *confmap.Conf) error {
+	// Leave configs without a service alone so they fail validation as usual
+	if !conf.IsSet("service") {
+		return nil
+	}
+
+	telemetry := map[string]any{}
+
+	if o.metrics != nil {
+		level, _ := conf.Get("service::telemetry::metrics::level").(string)
+		disabled := strings.EqualFold(level, "none")
+
+		var readers []any
+		switch raw := conf.Get("service::telemetry::metrics::readers"); {
+		case raw != nil:
+			list, ok := raw.([]any)
+			if !ok {
+				return nil
+			}
+			readers = list
+		case conf.IsSet("service::telemetry::metrics::address"), disabled:
+			// An address gets its Prometheus reader added after these readers when it is unmarshaled,
+			// and a collector with metrics disabled had no readers to keep
+		default:
+			readers = []any{defaultMetricsReader}
+		}
+
+		metrics := map[string]any{
+			"readers": append(readers, map[string]any{
+				"periodic": map[string]any{
+					"exporter": map[string]any{
+						"otlp": o.metrics.exporter(),
+					},
+				},
+			}),
+		}
+
+		// The collector disables all readers at level none, so raise it for the metrics to be sent
+		if disabled {
+			metrics["level"] = ownMetricsLevel
+		}
+
+		telemetry["metrics"] = metrics
+	}
+
+	if o.logs != nil {
+		var processors []any
+		if raw := conf.Get("service::telemetry::logs::processors"); raw != nil {
+			list, ok := raw.([]any)
+			if !ok {
+				return nil
+			}
+			processors = list
+		}
+
+		telemetry["logs"] = map[string]any{
+			"processors": append(processors, map[string]any{
+				"batch": map[string]any{
+					"exporter": map[string]any{
+						"otlp": o.logs.exporter(),
+					},
+				},
+			}),
+		}
+	}
+
+	return conf.Merge(confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"telemetry": telemetry,
+		},
+	}))
+}
+
+// exporter returns the OTLP exporter config for the destination
+func (t *TelemetryDestination) exporter() map[string]any {
+	exporter := map[string]any{
+		"protocol": ownTelemetryProtocol,
+		"endpoint": t.Endpoint,
+	}
+
+	if len(t.Headers) > 0 {
+		headers := make(map[string]any, len(t.Headers))
+		for k, v := range t.Headers {
+			headers[k] = v
+		}
+		exporter["headers"] = headers
+	}
+
+	return exporter
+}
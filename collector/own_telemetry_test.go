@@ -0,0 +1,287 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/observiq/bindplane-otel-collector/factories"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+func TestOwnTelemetryConverter(t *testing.T) {
+	metrics := &TelemetryDestination{
+		Endpoint: "https://telemetry.example.com:4318/v1/metrics",
+		Headers:  map[string]string{"Authorization": "Bearer abc"},
+	}
+	logs := &TelemetryDestination{
+		Endpoint: "https://telemetry.example.com:4318/v1/logs",
+	}
+
+	metricsReader := map[string]any{
+		"periodic": map[string]any{
+			"exporter": map[string]any{
+				"otlp": map[string]any{
+					"protocol": "http/protobuf",
+					"endpoint": "https://telemetry.example.com:4318/v1/metrics",
+					"headers":  map[string]any{"Authorization": "Bearer abc"},
+				},
+			},
+		},
+	}
+	logsProcessor := map[string]any{
+		"batch": map[string]any{
+			"exporter": map[string]any{
+				"otlp": map[string]any{
+					"protocol": "http/protobuf",
+					"endpoint": "https://telemetry.example.com:4318/v1/logs",
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		metrics  *TelemetryDestination
+		logs     *TelemetryDestination
+		input    map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "no service",
+			metrics:  metrics,
+			logs:     logs,
+			input:    map[string]any{"receivers": map[string]any{"otlp": nil}},
+			expected: map[string]any{"receivers": map[string]any{"otlp": nil}},
+		},
+		{
+			name:    "default telemetry",
+			metrics: metrics,
+			logs:    logs,
+			input: map[string]any{
+				"service": map[string]any{"pipelines": map[string]any{}},
+			},
+			expected: map[string]any{
+				"service": map[string]any{
+					"pipelines": map[string]any{},
+					"telemetry": map[string]any{
+						"metrics": map[string]any{"readers": []any{defaultMetricsReader, metricsReader}},
+						"logs":    map[string]any{"processors": []any{logsProcessor}},
+					},
+				},
+			},
+		},
+		{
+			name:    "existing readers kept",
+			metrics: metrics,
+			input: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{
+							"level":   "detailed",
+							"readers": []any{map[string]any{"pull": "existing"}},
+						},
+					},
+				},
+			},
+			expected: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{
+							"level":   "detailed",
+							"readers": []any{map[string]any{"pull": "existing"}, metricsReader},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "default reader kept with level",
+			metrics: metrics,
+			input: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{"level": "detailed"},
+					},
+				},
+			},
+			expected: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{
+							"level":   "detailed",
+							"readers": []any{defaultMetricsReader, metricsReader},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "level none raised",
+			metrics: metrics,
+			input: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{"level": "none"},
+					},
+				},
+			},
+			expected: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{
+							"level":   "normal",
+							"readers": []any{metricsReader},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "address kept",
+			metrics: metrics,
+			input: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{"address": "0.0.0.0:8888"},
+					},
+				},
+			},
+			expected: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"metrics": map[string]any{
+							"address": "0.0.0.0:8888",
+							"readers": []any{metricsReader},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "logs only",
+			logs: logs,
+			input: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"logs": map[string]any{"level": "debug"},
+					},
+				},
+			},
+			expected: map[string]any{
+				"service": map[string]any{
+					"telemetry": map[string]any{
+						"logs": map[string]any{"level": "debug", "processors": []any{logsProcessor}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(tc.input)
+			converter := ownTelemetryConverter{metrics: tc.metrics, logs: tc.logs}
+			require.NoError(t, converter.Convert(context.Background(), conf))
+			require.Equal(t, tc.expected, conf.ToStringMap())
+		})
+	}
+}
+
+func TestOwnTelemetryConverterAddress(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{
+			"telemetry": map[string]any{
+				"metrics": map[string]any{
+					"level":   "none",
+					"address": "0.0.0.0:8888",
+				},
+			},
+		},
+	})
+	converter := ownTelemetryConverter{metrics: &TelemetryDestination{Endpoint: "https://localhost:4318/v1/metrics"}}
+	require.NoError(t, converter.Convert(context.Background(), conf))
+
+	telemetryConf, err := conf.Sub("service::telemetry")
+	require.NoError(t, err)
+
+	var cfg telemetry.Config
+	require.NoError(t, telemetryConf.Unmarshal(&cfg))
+	require.NoError(t, cfg.Validate())
+	require.Equal(t, configtelemetry.LevelNormal, cfg.Metrics.Level)
+
+	// Only the address binds a Prometheus server
+	require.Len(t, cfg.Metrics.Readers, 2)
+	require.NotNil(t, cfg.Metrics.Readers[0].Periodic)
+	require.NotNil(t, cfg.Metrics.Readers[1].Pull)
+	require.Equal(t, 8888, *cfg.Metrics.Readers[1].Pull.Exporter.Prometheus.Port)
+	require.Equal(t, "0.0.0.0", *cfg.Metrics.Readers[1].Pull.Exporter.Prometheus.Host)
+}
+
+func TestOwnTelemetryConverterDefaultReader(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"service": map[string]any{"pipelines": map[string]any{}},
+	})
+	converter := ownTelemetryConverter{metrics: &TelemetryDestination{Endpoint: "https://localhost:4318/v1/metrics"}}
+	require.NoError(t, converter.Convert(context.Background(), conf))
+
+	telemetryConf, err := conf.Sub("service::telemetry")
+	require.NoError(t, err)
+
+	var cfg telemetry.Config
+	require.NoError(t, telemetryConf.Unmarshal(&cfg))
+	require.NoError(t, cfg.Validate())
+
+	// The local telemetry endpoint scraped by the config health check is still served
+	require.Len(t, cfg.Metrics.Readers, 2)
+	require.NotNil(t, cfg.Metrics.Readers[0].Pull)
+	require.Equal(t, 8888, *cfg.Metrics.Readers[0].Pull.Exporter.Prometheus.Port)
+	require.Equal(t, "localhost", *cfg.Metrics.Readers[0].Pull.Exporter.Prometheus.Host)
+	require.NotNil(t, cfg.Metrics.Readers[1].Periodic)
+}
+
+func TestOwnTelemetryConfigValid(t *testing.T) {
+	facts, err := factories.DefaultFactories()
+	require.NoError(t, err)
+
+	settings, err := NewSettings([]string{"./test/valid.yaml"}, "0.0.0", nil, facts)
+	require.NoError(t, err)
+
+	settings.ConfigProviderSettings.ResolverSettings.ConverterFactories = append(
+		settings.ConfigProviderSettings.ResolverSettings.ConverterFactories,
+		newOwnTelemetryConverterFactory(
+			&TelemetryDestination{Endpoint: "https://localhost:4318/v1/metrics", Headers: map[string]string{"key": "value"}},
+			&TelemetryDestination{Endpoint: "https://localhost:4318/v1/logs"},
+		),
+	)
+
+	require.NoError(t, validateConfig(context.Background(), settings))
+}
+
+func TestCollectorSetOwnTelemetry(t *testing.T) {
+	c, err := New([]string{"./test/valid.yaml"}, "0.0.0", nil)
+	require.NoError(t, err)
+
+	metrics := &TelemetryDestination{Endpoint: "https://localhost:4318/v1/metrics"}
+	c.SetOwnTelemetry(metrics, nil)
+
+	col := c.(*collector)
+	require.Equal(t, metrics, col.ownMetrics)
+	require.Nil(t, col.ownLogs)
+}
@@ -54,6 +54,11 @@ func NewSettings(configPaths []string, version string, loggingOpts []zap.Option,
 		},
 	}
 
+	// Track component health when the collector provides the health extension
+	if _, ok := factories.Extensions[healthType]; ok {
+		configProviderSettings.ResolverSettings.ConverterFactories = append(configProviderSettings.ResolverSettings.ConverterFactories, newHealthConverterFactory())
+	}
+
 	return &otelcol.CollectorSettings{
 		Factories:               func() (otelcol.Factories, error) { return factories, nil },
 		BuildInfo:               buildInfo,
@@ -0,0 +1,117 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/service"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ValidateConfig checks the collector config contents without touching the running collector.
+// The config is unmarshaled with the collector's factories, each component config is validated,
+// and the pipelines are built but never started.
+func (c *collector) ValidateConfig(ctx context.Context, contents []byte) error {
+	settings, err := NewSettings([]string{"yaml:" + string(contents)}, c.version, nil, c.factories)
+	if err != nil {
+		return fmt.Errorf("failed to create collector settings: %w", err)
+	}
+
+	return validateConfig(ctx, settings)
+}
+
+// validateConfig resolves and validates the config described by settings and builds its pipelines
+func validateConfig(ctx context.Context, settings *otelcol.CollectorSettings) error {
+	factories, err := settings.Factories()
+	if err != nil {
+		return fmt.Errorf("failed to initialize factories: %w", err)
+	}
+
+	provider, err := otelcol.NewConfigProvider(settings.ConfigProviderSettings)
+	if err != nil {
+		return fmt.Errorf("failed to create config provider: %w", err)
+	}
+
+	cfg, err := provider.Get(ctx, factories)
+	if err != nil {
+		return fmt.Errorf("failed to get config: %w", err)
+	}
+
+	// Validates the service and every component config with component.ValidateConfig
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	conf := confmap.New()
+	if err := conf.Marshal(cfg); err != nil {
+		return fmt.Errorf("could not marshal configuration: %w", err)
+	}
+
+	// Internal telemetry is disabled so building the service doesn't bind the telemetry
+	// endpoint or replace global providers in use by the running collector
+	serviceCfg := cfg.Service
+	serviceCfg.Telemetry.Logs.OutputPaths = nil
+	serviceCfg.Telemetry.Logs.ErrorOutputPaths = nil
+	serviceCfg.Telemetry.Logs.Processors = nil
+	serviceCfg.Telemetry.Metrics.Level = configtelemetry.LevelNone
+	serviceCfg.Telemetry.Metrics.Readers = nil
+	serviceCfg.Telemetry.Traces.Level = configtelemetry.LevelNone
+	serviceCfg.Telemetry.Traces.Processors = nil
+
+	// Creates every component and connects the pipeline graph without starting anything
+	svc, err := service.New(ctx, service.Settings{
+		BuildInfo:     settings.BuildInfo,
+		CollectorConf: conf,
+
+		ReceiversConfigs:    cfg.Receivers,
+		ReceiversFactories:  factories.Receivers,
+		ProcessorsConfigs:   cfg.Processors,
+		ProcessorsFactories: factories.Processors,
+		ExportersConfigs:    cfg.Exporters,
+		ExportersFactories:  factories.Exporters,
+		ConnectorsConfigs:   cfg.Connectors,
+		ConnectorsFactories: factories.Connectors,
+		ExtensionsConfigs:   cfg.Extensions,
+		ExtensionsFactories: factories.Extensions,
+
+		ModuleInfo: extension.ModuleInfo{
+			Receiver:  factories.ReceiverModules,
+			Processor: factories.ProcessorModules,
+			Exporter:  factories.ExporterModules,
+			Extension: factories.ExtensionModules,
+			Connector: factories.ConnectorModules,
+		},
+		AsyncErrorChannel: make(chan error, 1),
+		LoggingOptions: []zap.Option{
+			zap.WrapCore(func(zapcore.Core) zapcore.Core { return zapcore.NewNopCore() }),
+		},
+	}, serviceCfg)
+	if err != nil {
+		return fmt.Errorf("failed to build pipelines: %w", err)
+	}
+
+	// Components must support shutdown without being started, so this only releases what was created.
+	// A failure here says nothing about the config so it is not returned.
+	_ = svc.Shutdown(ctx)
+
+	return nil
+}
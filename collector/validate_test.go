@@ -0,0 +1,164 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectorValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			config: `
+receivers:
+  filelog:
+    include: ["./var/log/syslog.log"]
+processors:
+  batch:
+exporters:
+  nop:
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      processors: [batch]
+      exporters: [nop]
+`,
+		},
+		{
+			name:        "malformed yaml",
+			config:      "receivers: [",
+			expectedErr: "failed to get config",
+		},
+		{
+			name: "unknown component",
+			config: `
+receivers:
+  notreal:
+exporters:
+  nop:
+service:
+  pipelines:
+    logs:
+      receivers: [notreal]
+      exporters: [nop]
+`,
+			expectedErr: "failed to get config",
+		},
+		{
+			name: "invalid component config",
+			config: `
+receivers:
+  filelog:
+    include: ["./var/log/syslog.log"]
+processors:
+  batch:
+    send_batch_size: 100
+    send_batch_max_size: 10
+exporters:
+  nop:
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      processors: [batch]
+      exporters: [nop]
+`,
+			expectedErr: "invalid configuration: processors::batch",
+		},
+		{
+			name: "undefined pipeline component",
+			config: `
+receivers:
+  filelog:
+    include: ["./var/log/syslog.log"]
+exporters:
+  nop:
+service:
+  pipelines:
+    logs:
+      receivers: [filelog]
+      exporters: [nop, debug]
+`,
+			expectedErr: "invalid configuration: service::pipelines::logs",
+		},
+		{
+			name: "unsupported signal",
+			config: `
+receivers:
+  filelog:
+    include: ["./var/log/syslog.log"]
+exporters:
+  nop:
+service:
+  pipelines:
+    metrics:
+      receivers: [filelog]
+      exporters: [nop]
+`,
+			expectedErr: "failed to build pipelines",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			collector, err := New([]string{"./test/valid.yaml"}, "0.0.0", nil)
+			require.NoError(t, err)
+
+			err = collector.ValidateConfig(context.Background(), []byte(tc.config))
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCollectorValidateConfigWhileRunning(t *testing.T) {
+	ctx := context.Background()
+
+	collector, err := New([]string{"./test/valid.yaml"}, "0.0.0", nil)
+	require.NoError(t, err)
+
+	err = collector.Run(ctx)
+	require.NoError(t, err)
+	defer collector.Stop(ctx)
+
+	status := <-collector.Status()
+	require.True(t, status.Running)
+
+	contents, err := os.ReadFile("./test/valid.yaml")
+	require.NoError(t, err)
+	require.NoError(t, collector.ValidateConfig(ctx, contents))
+	require.Error(t, collector.ValidateConfig(ctx, []byte("receivers: [")))
+
+	// The running collector is untouched by validation
+	select {
+	case status := <-collector.Status():
+		t.Fatalf("unexpected status change: %+v", status)
+	default:
+	}
+	require.True(t, collector.ComponentHealth()["receiver:filelog"].Healthy())
+}
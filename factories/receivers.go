@@ -15,8 +15,11 @@
 package factories
 
 import (
+	"github.com/observiq/bindplane-otel-collector/plugins"
 	"github.com/observiq/bindplane-otel-collector/receiver/awss3rehydrationreceiver"
 	"github.com/observiq/bindplane-otel-collector/receiver/azureblobrehydrationreceiver"
+	"github.com/observiq/bindplane-otel-collector/receiver/filerehydrationreceiver"
+	"github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver"
 	"github.com/observiq/bindplane-otel-collector/receiver/httpreceiver"
 	"github.com/observiq/bindplane-otel-collector/receiver/m365receiver"
 	"github.com/observiq/bindplane-otel-collector/receiver/oktareceiver"
@@ -118,8 +121,10 @@ var defaultReceivers = []receiver.Factory{
 	dockerstatsreceiver.NewFactory(),
 	elasticsearchreceiver.NewFactory(),
 	filelogreceiver.NewFactory(),
+	filerehydrationreceiver.NewFactory(),
 	flinkmetricsreceiver.NewFactory(),
 	fluentforwardreceiver.NewFactory(),
+	gcsrehydrationreceiver.NewFactory(),
 	googlecloudpubsubreceiver.NewFactory(),
 	googlecloudspannerreceiver.NewFactory(),
 	hostmetricsreceiver.NewFactory(),
@@ -144,7 +149,7 @@ var defaultReceivers = []receiver.Factory{
 	oktareceiver.NewFactory(),
 	opencensusreceiver.NewFactory(),
 	otlpreceiver.NewFactory(),
-	pluginreceiver.NewFactory(),
+	pluginreceiver.NewFactoryWithCatalog(plugins.Catalog),
 	podmanreceiver.NewFactory(),
 	postgresqlreceiver.NewFactory(),
 	prometheusreceiver.NewFactory(),
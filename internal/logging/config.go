@@ -94,8 +94,11 @@ func (l *LoggerConfig) Options() ([]zap.Option, error) {
 		return nil, err
 	}
 
+	// Keep recent entries in memory so they can be retrieved for diagnostics
+	recentCore := zapcore.NewCore(newEncoder(), recentLogs, l.Level)
+
 	opt := zap.WrapCore(func(_ zapcore.Core) zapcore.Core {
-		return core
+		return zapcore.NewTee(core, recentCore)
 	})
 
 	return []zap.Option{opt}, nil
@@ -0,0 +1,82 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"sync"
+)
+
+// recentLogsSize is the number of log entries kept for diagnostics
+const recentLogsSize = 1000
+
+// recentLogs holds the most recent entries written by loggers built from a LoggerConfig
+var recentLogs = newRecentLogBuffer(recentLogsSize)
+
+// RecentLogs returns up to n of the most recent encoded log entries, oldest first.
+// All retained entries are returned if n is less than or equal to zero.
+func RecentLogs(n int) []string {
+	return recentLogs.Entries(n)
+}
+
+// recentLogBuffer is a fixed size ring buffer of log entries. It is used as the
+// sink of a zapcore.Core, which writes one encoded entry per Write call.
+type recentLogBuffer struct {
+	mux     sync.Mutex
+	entries []string
+	next    int
+	full    bool
+}
+
+func newRecentLogBuffer(size int) *recentLogBuffer {
+	return &recentLogBuffer{
+		entries: make([]string, size),
+	}
+}
+
+// Write records p as a single log entry
+func (r *recentLogBuffer) Write(p []byte) (int, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.entries[r.next] = string(p)
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+
+	return len(p), nil
+}
+
+// Sync is a noop as entries are kept in memory
+func (r *recentLogBuffer) Sync() error {
+	return nil
+}
+
+// Entries returns up to n of the most recent entries, oldest first
+func (r *recentLogBuffer) Entries(n int) []string {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	var ordered []string
+	if r.full {
+		ordered = append(ordered, r.entries[r.next:]...)
+	}
+	ordered = append(ordered, r.entries[:r.next]...)
+
+	if n > 0 && n < len(ordered) {
+		ordered = ordered[len(ordered)-n:]
+	}
+	return ordered
+}
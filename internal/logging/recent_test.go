@@ -0,0 +1,63 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRecentLogBuffer(t *testing.T) {
+	buffer := newRecentLogBuffer(3)
+	require.Empty(t, buffer.Entries(0))
+
+	for i := 0; i < 2; i++ {
+		_, err := buffer.Write([]byte(fmt.Sprintf("entry %d", i)))
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"entry 0", "entry 1"}, buffer.Entries(0))
+	require.Equal(t, []string{"entry 1"}, buffer.Entries(1))
+
+	// Oldest entries are overwritten once full
+	for i := 2; i < 5; i++ {
+		_, err := buffer.Write([]byte(fmt.Sprintf("entry %d", i)))
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"entry 2", "entry 3", "entry 4"}, buffer.Entries(0))
+	require.Equal(t, []string{"entry 3", "entry 4"}, buffer.Entries(2))
+	require.Equal(t, []string{"entry 2", "entry 3", "entry 4"}, buffer.Entries(10))
+}
+
+func TestLoggerConfigRecentLogs(t *testing.T) {
+	conf, err := NewLoggerConfig("testdata/stdout.yaml")
+	require.NoError(t, err)
+	conf.Level = zap.InfoLevel
+
+	opts, err := conf.Options()
+	require.NoError(t, err)
+
+	logger, err := zap.NewProduction(opts...)
+	require.NoError(t, err)
+
+	logger.Debug("below the configured level")
+	logger.Info("recent log entry")
+
+	entries := RecentLogs(1)
+	require.Len(t, entries, 1)
+	require.Contains(t, entries[0], "recent log entry")
+}
@@ -0,0 +1,223 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package os
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNoCloud is returned when no cloud metadata endpoint responded
+var ErrNoCloud = errors.New("no cloud metadata endpoint found")
+
+// Cloud providers reported in CloudInfo
+const (
+	CloudProviderAWS   = "aws"
+	CloudProviderGCP   = "gcp"
+	CloudProviderAzure = "azure"
+)
+
+// CloudInfo is the metadata of the cloud instance the host is running on
+type CloudInfo struct {
+	Provider         string
+	Region           string
+	AvailabilityZone string
+	InstanceID       string
+}
+
+// CloudEndpoints are the base URLs of the local metadata endpoints of each cloud provider
+type CloudEndpoints struct {
+	AWS   string
+	GCP   string
+	Azure string
+}
+
+// DefaultCloudEndpoints are the link-local metadata endpoints of the cloud providers
+var DefaultCloudEndpoints = CloudEndpoints{
+	AWS:   "http://169.254.169.254",
+	GCP:   "http://169.254.169.254",
+	Azure: "http://169.254.169.254",
+}
+
+// metadataClient is shared by every detection. Metadata endpoints are link-local so they must never go through a proxy,
+// and keep-alives are disabled so connections aren't left idle between the periodic refreshes.
+var metadataClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy:             nil,
+		DisableKeepAlives: true,
+	},
+}
+
+// DetectCloud queries the metadata endpoints of each cloud provider and returns the instance metadata of the first to respond.
+// The context should have a short deadline as the endpoints don't respond outside of their cloud.
+func DetectCloud(ctx context.Context, endpoints CloudEndpoints) (*CloudInfo, error) {
+	detectors := []func(context.Context, *http.Client, string) (*CloudInfo, error){
+		detectAWS,
+		detectGCP,
+		detectAzure,
+	}
+	baseURLs := []string{endpoints.AWS, endpoints.GCP, endpoints.Azure}
+
+	// Query all providers at once so a host outside of a cloud waits for at most one timeout
+	results := make([]chan *CloudInfo, len(detectors))
+	for i, detect := range detectors {
+		results[i] = make(chan *CloudInfo, 1)
+		go func(detect func(context.Context, *http.Client, string) (*CloudInfo, error), baseURL string, result chan<- *CloudInfo) {
+			info, err := detect(ctx, metadataClient, baseURL)
+			if err != nil {
+				info = nil
+			}
+			result <- info
+		}(detect, baseURLs[i], results[i])
+	}
+
+	var found *CloudInfo
+	for _, result := range results {
+		if info := <-result; info != nil && found == nil {
+			found = info
+		}
+	}
+
+	if found == nil {
+		return nil, ErrNoCloud
+	}
+	return found, nil
+}
+
+// detectAWS reads the instance identity document using IMDSv2
+func detectAWS(ctx context.Context, client *http.Client, baseURL string) (*CloudInfo, error) {
+	token, err := metadataRequest(ctx, client, http.MethodPut, baseURL+"/latest/api/token", map[string]string{
+		"X-aws-ec2-metadata-token-ttl-seconds": "60",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := metadataRequest(ctx, client, http.MethodGet, baseURL+"/latest/dynamic/instance-identity/document", map[string]string{
+		"X-aws-ec2-metadata-token": string(token),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var document struct {
+		InstanceID       string `json:"instanceId"`
+		Region           string `json:"region"`
+		AvailabilityZone string `json:"availabilityZone"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse instance identity document: %w", err)
+	}
+
+	return &CloudInfo{
+		Provider:         CloudProviderAWS,
+		Region:           document.Region,
+		AvailabilityZone: document.AvailabilityZone,
+		InstanceID:       document.InstanceID,
+	}, nil
+}
+
+// detectGCP reads the instance ID and zone from the compute metadata server
+func detectGCP(ctx context.Context, client *http.Client, baseURL string) (*CloudInfo, error) {
+	headers := map[string]string{"Metadata-Flavor": "Google"}
+
+	id, err := metadataRequest(ctx, client, http.MethodGet, baseURL+"/computeMetadata/v1/instance/id", headers)
+	if err != nil {
+		return nil, err
+	}
+
+	// The zone is in the form projects/<project number>/zones/<zone>
+	zone, err := metadataRequest(ctx, client, http.MethodGet, baseURL+"/computeMetadata/v1/instance/zone", headers)
+	if err != nil {
+		return nil, err
+	}
+	availabilityZone := string(zone[strings.LastIndex(string(zone), "/")+1:])
+
+	region := availabilityZone
+	if i := strings.LastIndex(availabilityZone, "-"); i > 0 {
+		region = availabilityZone[:i]
+	}
+
+	return &CloudInfo{
+		Provider:         CloudProviderGCP,
+		Region:           region,
+		AvailabilityZone: availabilityZone,
+		InstanceID:       string(id),
+	}, nil
+}
+
+// detectAzure reads the compute metadata from the instance metadata service
+func detectAzure(ctx context.Context, client *http.Client, baseURL string) (*CloudInfo, error) {
+	body, err := metadataRequest(ctx, client, http.MethodGet, baseURL+"/metadata/instance/compute?api-version=2021-02-01&format=json", map[string]string{
+		"Metadata": "true",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var compute struct {
+		VMID     string `json:"vmId"`
+		Location string `json:"location"`
+		Zone     string `json:"zone"`
+	}
+	if err := json.Unmarshal(body, &compute); err != nil {
+		return nil, fmt.Errorf("failed to parse compute metadata: %w", err)
+	}
+
+	return &CloudInfo{
+		Provider:         CloudProviderAzure,
+		Region:           compute.Location,
+		AvailabilityZone: compute.Zone,
+		InstanceID:       compute.VMID,
+	}, nil
+}
+
+// metadataRequest makes a request to a metadata endpoint and returns the trimmed body
+func metadataRequest(ctx context.Context, client *http.Client, method, url string, headers map[string]string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+
+	// Metadata responses are small, limit reads in case something else is listening
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	body = []byte(strings.TrimSpace(string(body)))
+	if len(body) == 0 {
+		return nil, fmt.Errorf("empty response from %s", url)
+	}
+	return body, nil
+}
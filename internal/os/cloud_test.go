@@ -0,0 +1,188 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package os
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newMetadataServer is a stand-in for a cloud provider's metadata endpoint
+func newMetadataServer(t *testing.T, handler http.HandlerFunc) string {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func awsHandler(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token" && r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds") != "":
+		_, _ = w.Write([]byte("token"))
+	case r.URL.Path == "/latest/dynamic/instance-identity/document" && r.Header.Get("X-aws-ec2-metadata-token") == "token":
+		_, _ = w.Write([]byte(`{"instanceId":"i-0123456789","region":"us-east-2","availabilityZone":"us-east-2b"}`))
+	default:
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+}
+
+func gcpHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Metadata-Flavor") != "Google" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/computeMetadata/v1/instance/id":
+		_, _ = w.Write([]byte("4520031799277581759"))
+	case "/computeMetadata/v1/instance/zone":
+		_, _ = w.Write([]byte("projects/123456789/zones/us-central1-a"))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func azureHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Metadata") != "true" || r.URL.Path != "/metadata/instance/compute" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	_, _ = w.Write([]byte(`{"vmId":"02aab8a4-74ef-476e-8182-f6d2ba4166a6","location":"westus","zone":"1"}`))
+}
+
+func notFoundHandler(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func TestDetectCloud(t *testing.T) {
+	testCases := []struct {
+		name        string
+		aws         http.HandlerFunc
+		gcp         http.HandlerFunc
+		azure       http.HandlerFunc
+		expected    *CloudInfo
+		expectedErr error
+	}{
+		{
+			name:  "AWS",
+			aws:   awsHandler,
+			gcp:   notFoundHandler,
+			azure: notFoundHandler,
+			expected: &CloudInfo{
+				Provider:         CloudProviderAWS,
+				Region:           "us-east-2",
+				AvailabilityZone: "us-east-2b",
+				InstanceID:       "i-0123456789",
+			},
+		},
+		{
+			name:  "GCP",
+			aws:   notFoundHandler,
+			gcp:   gcpHandler,
+			azure: notFoundHandler,
+			expected: &CloudInfo{
+				Provider:         CloudProviderGCP,
+				Region:           "us-central1",
+				AvailabilityZone: "us-central1-a",
+				InstanceID:       "4520031799277581759",
+			},
+		},
+		{
+			name:  "Azure",
+			aws:   notFoundHandler,
+			gcp:   notFoundHandler,
+			azure: azureHandler,
+			expected: &CloudInfo{
+				Provider:         CloudProviderAzure,
+				Region:           "westus",
+				AvailabilityZone: "1",
+				InstanceID:       "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+			},
+		},
+		{
+			name:        "No cloud",
+			aws:         notFoundHandler,
+			gcp:         notFoundHandler,
+			azure:       notFoundHandler,
+			expectedErr: ErrNoCloud,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoints := CloudEndpoints{
+				AWS:   newMetadataServer(t, tc.aws),
+				GCP:   newMetadataServer(t, tc.gcp),
+				Azure: newMetadataServer(t, tc.azure),
+			}
+
+			info, err := DetectCloud(context.Background(), endpoints)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, info)
+		})
+	}
+}
+
+func TestDetectCloudTimeout(t *testing.T) {
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+	endpoint := newMetadataServer(t, func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-block:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := DetectCloud(ctx, CloudEndpoints{AWS: endpoint, GCP: endpoint, Azure: endpoint})
+	require.ErrorIs(t, err, ErrNoCloud)
+}
+
+func TestDetectCloudClosesConnections(t *testing.T) {
+	var open atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(awsHandler))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			open.Add(1)
+		case http.StateClosed, http.StateHijacked:
+			open.Add(-1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+
+	empty := newMetadataServer(t, notFoundHandler)
+	_, err := DetectCloud(context.Background(), CloudEndpoints{AWS: server.URL, GCP: empty, Azure: empty})
+	require.NoError(t, err)
+
+	// No connections are kept idle waiting for the next refresh
+	require.Eventually(t, func() bool {
+		return open.Load() == 0
+	}, time.Second, 10*time.Millisecond)
+}
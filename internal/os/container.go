@@ -0,0 +1,109 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContainerInfo describes the container the host is running in, if any
+type ContainerInfo struct {
+	// Runtime is the container runtime, empty if not running in a container
+	Runtime string
+	// Kubernetes is true if running in a Kubernetes pod
+	Kubernetes          bool
+	KubernetesNamespace string
+	KubernetesPod       string
+}
+
+// cgroupRuntimes maps markers in the cgroup of the init process to their container runtime
+var cgroupRuntimes = []struct {
+	marker  string
+	runtime string
+}{
+	{marker: "libpod", runtime: "podman"},
+	{marker: "crio", runtime: "cri-o"},
+	{marker: "containerd", runtime: "containerd"},
+	{marker: "docker", runtime: "docker"},
+	{marker: "lxc", runtime: "lxc"},
+	{marker: "kubepods", runtime: "kubernetes"},
+}
+
+// Container detects whether the host is running in a container or a Kubernetes pod
+func Container() ContainerInfo {
+	return detectContainer("/", os.Getenv)
+}
+
+func detectContainer(root string, getenv func(string) string) ContainerInfo {
+	info := ContainerInfo{
+		Runtime: containerRuntime(root, getenv),
+	}
+
+	// Kubernetes always sets the service environment variables in pods
+	if getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return info
+	}
+
+	info.Kubernetes = true
+	if info.Runtime == "" {
+		info.Runtime = "kubernetes"
+	}
+
+	info.KubernetesNamespace = getenv("POD_NAMESPACE")
+	if info.KubernetesNamespace == "" {
+		namespace, err := os.ReadFile(filepath.Join(root, "var", "run", "secrets", "kubernetes.io", "serviceaccount", "namespace"))
+		if err == nil {
+			info.KubernetesNamespace = strings.TrimSpace(string(namespace))
+		}
+	}
+
+	// The hostname of a pod is its name unless overridden
+	info.KubernetesPod = getenv("POD_NAME")
+	if info.KubernetesPod == "" {
+		info.KubernetesPod = getenv("HOSTNAME")
+	}
+
+	return info
+}
+
+func containerRuntime(root string, getenv func(string) string) string {
+	if _, err := os.Stat(filepath.Join(root, ".dockerenv")); err == nil {
+		return "docker"
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "run", ".containerenv")); err == nil {
+		return "podman"
+	}
+
+	// systemd-nspawn, podman and others set this for the init process
+	if runtime := getenv("container"); runtime != "" {
+		return runtime
+	}
+
+	cgroup, err := os.ReadFile(filepath.Join(root, "proc", "1", "cgroup"))
+	if err != nil {
+		return ""
+	}
+
+	for _, r := range cgroupRuntimes {
+		if strings.Contains(string(cgroup), r.marker) {
+			return r.runtime
+		}
+	}
+
+	return ""
+}
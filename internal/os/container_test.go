@@ -0,0 +1,105 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package os
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectContainer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		env      map[string]string
+		expected ContainerInfo
+	}{
+		{
+			name:     "Not in a container",
+			files:    map[string]string{"proc/1/cgroup": "0::/init.scope\n"},
+			expected: ContainerInfo{},
+		},
+		{
+			name:     "Docker env file",
+			files:    map[string]string{".dockerenv": ""},
+			expected: ContainerInfo{Runtime: "docker"},
+		},
+		{
+			name:     "Podman env file",
+			files:    map[string]string{"run/.containerenv": ""},
+			expected: ContainerInfo{Runtime: "podman"},
+		},
+		{
+			name:     "Container env variable",
+			env:      map[string]string{"container": "systemd-nspawn"},
+			expected: ContainerInfo{Runtime: "systemd-nspawn"},
+		},
+		{
+			name:     "Containerd cgroup",
+			files:    map[string]string{"proc/1/cgroup": "0::/system.slice/containerd.service/kubepods-abc\n"},
+			expected: ContainerInfo{Runtime: "containerd"},
+		},
+		{
+			name: "Kubernetes from service account",
+			files: map[string]string{
+				"proc/1/cgroup": "0::/kubepods/besteffort/pod1234\n",
+				"var/run/secrets/kubernetes.io/serviceaccount/namespace": "observability\n",
+			},
+			env: map[string]string{
+				"KUBERNETES_SERVICE_HOST": "10.96.0.1",
+				"HOSTNAME":                "agent-7d9f8",
+			},
+			expected: ContainerInfo{
+				Runtime:             "kubernetes",
+				Kubernetes:          true,
+				KubernetesNamespace: "observability",
+				KubernetesPod:       "agent-7d9f8",
+			},
+		},
+		{
+			name:  "Kubernetes from downward API",
+			files: map[string]string{"proc/1/cgroup": "0::/kubepods/burstable/pod1234/cri-containerd-abc\n"},
+			env: map[string]string{
+				"KUBERNETES_SERVICE_HOST": "10.96.0.1",
+				"HOSTNAME":                "agent-7d9f8",
+				"POD_NAME":                "agent-0",
+				"POD_NAMESPACE":           "default",
+			},
+			expected: ContainerInfo{
+				Runtime:             "containerd",
+				Kubernetes:          true,
+				KubernetesNamespace: "default",
+				KubernetesPod:       "agent-0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, contents := range tc.files {
+				path := filepath.Join(root, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+				require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+			}
+
+			info := detectContainer(root, func(key string) string { return tc.env[key] })
+			require.Equal(t, tc.expected, info)
+		})
+	}
+}
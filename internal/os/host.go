@@ -0,0 +1,94 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package os
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/mem"
+)
+
+// CPUCount returns the number of logical CPUs usable by the process
+func CPUCount() int {
+	return runtime.NumCPU()
+}
+
+// TotalMemory returns the total physical memory of the host in bytes
+func TotalMemory() (uint64, error) {
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return 0, err
+	}
+	return vm.Total, nil
+}
+
+// IPAddresses returns the sorted non-loopback, non-link-local IP addresses of the host
+func IPAddresses() []string {
+	return findIPAddresses(net.InterfaceAddrs)
+}
+
+func findIPAddresses(interfaceAddrs func() ([]net.Addr, error)) []string {
+	addrs, err := interfaceAddrs()
+	if err != nil {
+		return nil
+	}
+
+	ips := []string{}
+	for _, addr := range addrs {
+		address := addr.String()
+		if i := strings.Index(address, "/"); i >= 0 {
+			address = address[:i]
+		}
+
+		ip := net.ParseIP(address)
+		if ip == nil || !ip.IsGlobalUnicast() {
+			continue
+		}
+		ips = append(ips, ip.String())
+	}
+
+	sort.Strings(ips)
+	return ips
+}
+
+// Timezone returns the IANA name of the host's timezone if it can be found, otherwise the zone abbreviation
+func Timezone() string {
+	return findTimezone(os.Getenv, os.Readlink, time.Now())
+}
+
+func findTimezone(getenv func(string) string, readlink func(string) (string, error), now time.Time) string {
+	if tz := strings.TrimPrefix(getenv("TZ"), ":"); tz != "" {
+		if _, err := time.LoadLocation(tz); err == nil {
+			return tz
+		}
+	}
+
+	// Unix hosts link /etc/localtime into the zoneinfo database
+	if target, err := readlink("/etc/localtime"); err == nil {
+		target = filepath.ToSlash(target)
+		if i := strings.Index(target, "zoneinfo/"); i >= 0 {
+			return target[i+len("zoneinfo/"):]
+		}
+	}
+
+	name, _ := now.Zone()
+	return name
+}
@@ -0,0 +1,109 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package os
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCPUCount(t *testing.T) {
+	require.Positive(t, CPUCount())
+}
+
+func TestTotalMemory(t *testing.T) {
+	total, err := TotalMemory()
+	require.NoError(t, err)
+	require.Positive(t, total)
+}
+
+func TestFindIPAddresses(t *testing.T) {
+	testCases := []struct {
+		name     string
+		addrs    func() ([]net.Addr, error)
+		expected []string
+	}{
+		{
+			name: "Failed to get addresses",
+			addrs: func() ([]net.Addr, error) {
+				return nil, errors.New("failure")
+			},
+			expected: nil,
+		},
+		{
+			name: "Skips loopback and link-local addresses",
+			addrs: func() ([]net.Addr, error) {
+				return []net.Addr{
+					&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+					&net.IPNet{IP: net.ParseIP("::1"), Mask: net.CIDRMask(128, 128)},
+					&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+					&net.IPNet{IP: net.ParseIP("192.168.1.20"), Mask: net.CIDRMask(24, 32)},
+					&net.IPNet{IP: net.ParseIP("10.0.0.5"), Mask: net.CIDRMask(8, 32)},
+					&net.IPNet{IP: net.ParseIP("2001:db8::5"), Mask: net.CIDRMask(64, 128)},
+				}, nil
+			},
+			expected: []string{"10.0.0.5", "192.168.1.20", "2001:db8::5"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, findIPAddresses(tc.addrs))
+		})
+	}
+}
+
+func TestFindTimezone(t *testing.T) {
+	noLink := func(string) (string, error) { return "", errors.New("not a link") }
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	testCases := []struct {
+		name     string
+		env      map[string]string
+		readlink func(string) (string, error)
+		expected string
+	}{
+		{
+			name:     "TZ environment variable",
+			env:      map[string]string{"TZ": "America/Chicago"},
+			readlink: noLink,
+			expected: "America/Chicago",
+		},
+		{
+			name: "Localtime link",
+			env:  map[string]string{"TZ": "Not/AZone"},
+			readlink: func(string) (string, error) {
+				return "/usr/share/zoneinfo/Europe/Berlin", nil
+			},
+			expected: "Europe/Berlin",
+		},
+		{
+			name:     "Zone abbreviation",
+			readlink: noLink,
+			expected: "EST",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tz := findTimezone(func(key string) string { return tc.env[key] }, tc.readlink, now)
+			require.Equal(t, tc.expected, tz)
+		})
+	}
+}
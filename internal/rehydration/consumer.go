@@ -28,28 +28,39 @@ import (
 //
 //go:generate mockery --name Consumer --inpackage --with-expecter --filename mock_consumer.go --structname MockConsumer
 type Consumer interface {
-	// Consume consumes entity contents at the path and unmarshals it.
-	Consume(ctx context.Context, entityContent []byte) error
+	// Consume consumes entity contents at the path and unmarshals it using the given format.
+	Consume(ctx context.Context, entityContent []byte, format Format) error
 }
 
 // MetricsConsumer consumes rehydrated metric entities and marshals them into pdata structures
 type MetricsConsumer struct {
 	nextConsumer consumer.Metrics
 
-	unmarshaler *pmetric.JSONUnmarshaler
+	unmarshaler      *pmetric.JSONUnmarshaler
+	protoUnmarshaler *pmetric.ProtoUnmarshaler
 }
 
 // NewMetricsConsumer creates a new metrics consumer
 func NewMetricsConsumer(nextConsumer consumer.Metrics) *MetricsConsumer {
 	return &MetricsConsumer{
-		nextConsumer: nextConsumer,
-		unmarshaler:  &pmetric.JSONUnmarshaler{},
+		nextConsumer:     nextConsumer,
+		unmarshaler:      &pmetric.JSONUnmarshaler{},
+		protoUnmarshaler: &pmetric.ProtoUnmarshaler{},
 	}
 }
 
 // Consume unmarshals entityContent into pmetrics and consumes it
-func (m *MetricsConsumer) Consume(ctx context.Context, entityContent []byte) error {
-	payload, err := m.unmarshaler.UnmarshalMetrics(entityContent)
+func (m *MetricsConsumer) Consume(ctx context.Context, entityContent []byte, format Format) error {
+	var payload pmetric.Metrics
+	var err error
+	switch format {
+	case FormatOTLPJSON, FormatAuto, "":
+		payload, err = m.unmarshaler.UnmarshalMetrics(entityContent)
+	case FormatOTLPProto:
+		payload, err = m.protoUnmarshaler.UnmarshalMetrics(entityContent)
+	default:
+		err = fmt.Errorf("%w for metrics: %s", errUnsupportedFormat, format)
+	}
 	if err != nil {
 		return fmt.Errorf("metrics consume: %w", err)
 	}
@@ -61,20 +72,35 @@ func (m *MetricsConsumer) Consume(ctx context.Context, entityContent []byte) err
 type LogsConsumer struct {
 	nextConsumer consumer.Logs
 
-	unmarshaler *plog.JSONUnmarshaler
+	unmarshaler      *plog.JSONUnmarshaler
+	protoUnmarshaler *plog.ProtoUnmarshaler
 }
 
 // NewLogsConsumer creates a new logs consumer
 func NewLogsConsumer(nextConsumer consumer.Logs) *LogsConsumer {
 	return &LogsConsumer{
-		nextConsumer: nextConsumer,
-		unmarshaler:  &plog.JSONUnmarshaler{},
+		nextConsumer:     nextConsumer,
+		unmarshaler:      &plog.JSONUnmarshaler{},
+		protoUnmarshaler: &plog.ProtoUnmarshaler{},
 	}
 }
 
 // Consume unmarshals entityContent into plogs and consumes it
-func (l *LogsConsumer) Consume(ctx context.Context, entityContent []byte) error {
-	payload, err := l.unmarshaler.UnmarshalLogs(entityContent)
+func (l *LogsConsumer) Consume(ctx context.Context, entityContent []byte, format Format) error {
+	var payload plog.Logs
+	var err error
+	switch format {
+	case FormatOTLPJSON, FormatAuto, "":
+		payload, err = l.unmarshaler.UnmarshalLogs(entityContent)
+	case FormatOTLPProto:
+		payload, err = l.protoUnmarshaler.UnmarshalLogs(entityContent)
+	case FormatNDJSON:
+		payload, err = unmarshalNDJSONLogs(entityContent)
+	case FormatParquet:
+		payload, err = unmarshalParquetLogs(entityContent)
+	default:
+		err = fmt.Errorf("%w for logs: %s", errUnsupportedFormat, format)
+	}
 	if err != nil {
 		return fmt.Errorf("logs consume: %w", err)
 	}
@@ -86,20 +112,31 @@ func (l *LogsConsumer) Consume(ctx context.Context, entityContent []byte) error
 type TracesConsumer struct {
 	nextConsumer consumer.Traces
 
-	unmarshaler *ptrace.JSONUnmarshaler
+	unmarshaler      *ptrace.JSONUnmarshaler
+	protoUnmarshaler *ptrace.ProtoUnmarshaler
 }
 
 // NewTracesConsumer creates a new trace consumer
 func NewTracesConsumer(nextConsumer consumer.Traces) *TracesConsumer {
 	return &TracesConsumer{
-		nextConsumer: nextConsumer,
-		unmarshaler:  &ptrace.JSONUnmarshaler{},
+		nextConsumer:     nextConsumer,
+		unmarshaler:      &ptrace.JSONUnmarshaler{},
+		protoUnmarshaler: &ptrace.ProtoUnmarshaler{},
 	}
 }
 
 // Consume unmarshals entityContent into ptrace and consumes it
-func (l *TracesConsumer) Consume(ctx context.Context, entityContent []byte) error {
-	payload, err := l.unmarshaler.UnmarshalTraces(entityContent)
+func (l *TracesConsumer) Consume(ctx context.Context, entityContent []byte, format Format) error {
+	var payload ptrace.Traces
+	var err error
+	switch format {
+	case FormatOTLPJSON, FormatAuto, "":
+		payload, err = l.unmarshaler.UnmarshalTraces(entityContent)
+	case FormatOTLPProto:
+		payload, err = l.protoUnmarshaler.UnmarshalTraces(entityContent)
+	default:
+		err = fmt.Errorf("%w for traces: %s", errUnsupportedFormat, format)
+	}
 	if err != nil {
 		return fmt.Errorf("traces consume: %w", err)
 	}
@@ -21,6 +21,7 @@ import (
 	"github.com/observiq/bindplane-otel-collector/internal/testutils"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 func Test_metricsConsumer(t *testing.T) {
@@ -29,13 +30,13 @@ func Test_metricsConsumer(t *testing.T) {
 
 	metrics, jsonBytes := testutils.GenerateTestMetrics(t)
 
-	err := con.Consume(context.Background(), jsonBytes)
+	err := con.Consume(context.Background(), jsonBytes, FormatOTLPJSON)
 	require.NoError(t, err)
 
 	require.Equal(t, metrics.DataPointCount(), testConsumer.DataPointCount())
 
 	// Test case of failed unmarshal
-	err = con.Consume(context.Background(), []byte("nope"))
+	err = con.Consume(context.Background(), []byte("nope"), FormatOTLPJSON)
 	require.Error(t, err)
 }
 
@@ -45,13 +46,13 @@ func Test_logsConsumer(t *testing.T) {
 
 	logs, jsonBytes := testutils.GenerateTestLogs(t)
 
-	err := con.Consume(context.Background(), jsonBytes)
+	err := con.Consume(context.Background(), jsonBytes, FormatOTLPJSON)
 	require.NoError(t, err)
 
 	require.Equal(t, logs.LogRecordCount(), testConsumer.LogRecordCount())
 
 	// Test case of failed unmarshal
-	err = con.Consume(context.Background(), []byte("nope"))
+	err = con.Consume(context.Background(), []byte("nope"), FormatOTLPJSON)
 	require.Error(t, err)
 }
 
@@ -61,12 +62,37 @@ func Test_tracesConsumer(t *testing.T) {
 
 	traces, jsonBytes := testutils.GenerateTestTraces(t)
 
-	err := con.Consume(context.Background(), jsonBytes)
+	err := con.Consume(context.Background(), jsonBytes, FormatOTLPJSON)
 	require.NoError(t, err)
 
 	require.Equal(t, traces.SpanCount(), testConsumer.SpanCount())
 
 	// Test case of failed unmarshal
-	err = con.Consume(context.Background(), []byte("nope"))
+	err = con.Consume(context.Background(), []byte("nope"), FormatOTLPJSON)
 	require.Error(t, err)
 }
+
+func Test_consumerFormats(t *testing.T) {
+	logsSink := &consumertest.LogsSink{}
+	logsConsumer := NewLogsConsumer(logsSink)
+
+	logs, _ := testutils.GenerateTestLogs(t)
+	protoBytes, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	require.NoError(t, err)
+
+	err = logsConsumer.Consume(context.Background(), protoBytes, FormatOTLPProto)
+	require.NoError(t, err)
+	require.Equal(t, logs.LogRecordCount(), logsSink.LogRecordCount())
+
+	err = logsConsumer.Consume(context.Background(), []byte("line one\nline two\n"), FormatNDJSON)
+	require.NoError(t, err)
+	require.Equal(t, logs.LogRecordCount()+2, logsSink.LogRecordCount())
+
+	metricsConsumer := NewMetricsConsumer(&consumertest.MetricsSink{})
+	err = metricsConsumer.Consume(context.Background(), []byte("line one\n"), FormatNDJSON)
+	require.ErrorIs(t, err, errUnsupportedFormat)
+
+	tracesConsumer := NewTracesConsumer(&consumertest.TracesSink{})
+	err = tracesConsumer.Consume(context.Background(), []byte("nope"), FormatParquet)
+	require.ErrorIs(t, err, errUnsupportedFormat)
+}
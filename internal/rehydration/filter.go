@@ -0,0 +1,272 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/expr"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// conditionErrorLogInterval is how often a filter logs that the condition failed to evaluate
+const conditionErrorLogInterval = 10 * time.Second
+
+// WithMetricsCondition returns a consumer that only passes metrics matching the OTTL condition on to nextConsumer.
+// Metrics the condition fails to evaluate against are counted as dropped in progress.
+// nextConsumer is returned as is if the condition is empty.
+func WithMetricsCondition(condition string, set component.TelemetrySettings, progress *Progress, nextConsumer consumer.Metrics) (consumer.Metrics, error) {
+	if condition == "" {
+		return nextConsumer, nil
+	}
+
+	ottlCondition, err := expr.NewOTTLMetricCondition(condition, set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+
+	filter, err := NewMetricsFilter(ottlCondition, set.Logger, progress, nextConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("new filter: %w", err)
+	}
+	return filter, nil
+}
+
+// WithLogsCondition returns a consumer that only passes log records matching the OTTL condition on to nextConsumer.
+// Log records the condition fails to evaluate against are counted as dropped in progress.
+// nextConsumer is returned as is if the condition is empty.
+func WithLogsCondition(condition string, set component.TelemetrySettings, progress *Progress, nextConsumer consumer.Logs) (consumer.Logs, error) {
+	if condition == "" {
+		return nextConsumer, nil
+	}
+
+	ottlCondition, err := expr.NewOTTLLogRecordCondition(condition, set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+
+	filter, err := NewLogsFilter(ottlCondition, set.Logger, progress, nextConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("new filter: %w", err)
+	}
+	return filter, nil
+}
+
+// WithTracesCondition returns a consumer that only passes spans matching the OTTL condition on to nextConsumer.
+// Spans the condition fails to evaluate against are counted as dropped in progress.
+// nextConsumer is returned as is if the condition is empty.
+func WithTracesCondition(condition string, set component.TelemetrySettings, progress *Progress, nextConsumer consumer.Traces) (consumer.Traces, error) {
+	if condition == "" {
+		return nextConsumer, nil
+	}
+
+	ottlCondition, err := expr.NewOTTLSpanCondition(condition, set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+
+	filter, err := NewTracesFilter(ottlCondition, set.Logger, progress, nextConsumer)
+	if err != nil {
+		return nil, fmt.Errorf("new filter: %w", err)
+	}
+	return filter, nil
+}
+
+// NewMetricsFilter returns a consumer that only passes metrics matching the condition on to nextConsumer.
+// Metrics where the condition fails to evaluate are dropped, counted in progress and logged at debug level.
+func NewMetricsFilter(condition *expr.OTTLCondition[ottlmetric.TransformContext], logger *zap.Logger, progress *Progress, nextConsumer consumer.Metrics) (consumer.Metrics, error) {
+	logger = sampledLogger(logger)
+	return consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		var dropped int
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			resourceMetrics := md.ResourceMetrics().At(i)
+			for j := 0; j < resourceMetrics.ScopeMetrics().Len(); j++ {
+				scopeMetrics := resourceMetrics.ScopeMetrics().At(j)
+				scopeMetrics.Metrics().RemoveIf(func(metric pmetric.Metric) bool {
+					metricCtx := ottlmetric.NewTransformContext(
+						metric,
+						scopeMetrics.Metrics(),
+						scopeMetrics.Scope(),
+						resourceMetrics.Resource(),
+						scopeMetrics,
+						resourceMetrics,
+					)
+					match, err := condition.Match(ctx, metricCtx)
+					if err != nil {
+						dropped++
+						logger.Debug("Dropping metric where the condition failed to evaluate", zap.Error(err))
+						return true
+					}
+					return !match
+				})
+			}
+			resourceMetrics.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+				return sm.Metrics().Len() == 0
+			})
+		}
+		md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+			return rm.ScopeMetrics().Len() == 0
+		})
+
+		progress.AddDropped(dropped)
+
+		if md.ResourceMetrics().Len() == 0 {
+			return nil
+		}
+		return nextConsumer.ConsumeMetrics(ctx, md)
+	})
+}
+
+// NewLogsFilter returns a consumer that only passes log records matching the condition on to nextConsumer.
+// Log records where the condition fails to evaluate are dropped, counted in progress and logged at debug level.
+func NewLogsFilter(condition *expr.OTTLCondition[ottllog.TransformContext], logger *zap.Logger, progress *Progress, nextConsumer consumer.Logs) (consumer.Logs, error) {
+	logger = sampledLogger(logger)
+	return consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		var dropped int
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			resourceLogs := ld.ResourceLogs().At(i)
+			for j := 0; j < resourceLogs.ScopeLogs().Len(); j++ {
+				scopeLogs := resourceLogs.ScopeLogs().At(j)
+				scopeLogs.LogRecords().RemoveIf(func(logRecord plog.LogRecord) bool {
+					logCtx := ottllog.NewTransformContext(
+						logRecord,
+						scopeLogs.Scope(),
+						resourceLogs.Resource(),
+						scopeLogs,
+						resourceLogs,
+					)
+					match, err := condition.Match(ctx, logCtx)
+					if err != nil {
+						dropped++
+						logger.Debug("Dropping log record where the condition failed to evaluate", zap.Error(err))
+						return true
+					}
+					return !match
+				})
+			}
+			resourceLogs.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+				return sl.LogRecords().Len() == 0
+			})
+		}
+		ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+			return rl.ScopeLogs().Len() == 0
+		})
+
+		progress.AddDropped(dropped)
+
+		if ld.ResourceLogs().Len() == 0 {
+			return nil
+		}
+		return nextConsumer.ConsumeLogs(ctx, ld)
+	})
+}
+
+// NewTracesFilter returns a consumer that only passes spans matching the condition on to nextConsumer.
+// Spans where the condition fails to evaluate are dropped, counted in progress and logged at debug level.
+func NewTracesFilter(condition *expr.OTTLCondition[ottlspan.TransformContext], logger *zap.Logger, progress *Progress, nextConsumer consumer.Traces) (consumer.Traces, error) {
+	logger = sampledLogger(logger)
+	return consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		var dropped int
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			resourceSpans := td.ResourceSpans().At(i)
+			for j := 0; j < resourceSpans.ScopeSpans().Len(); j++ {
+				scopeSpans := resourceSpans.ScopeSpans().At(j)
+				scopeSpans.Spans().RemoveIf(func(span ptrace.Span) bool {
+					spanCtx := ottlspan.NewTransformContext(
+						span,
+						scopeSpans.Scope(),
+						resourceSpans.Resource(),
+						scopeSpans,
+						resourceSpans,
+					)
+					match, err := condition.Match(ctx, spanCtx)
+					if err != nil {
+						dropped++
+						logger.Debug("Dropping span where the condition failed to evaluate", zap.Error(err))
+						return true
+					}
+					return !match
+				})
+			}
+			resourceSpans.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+				return ss.Spans().Len() == 0
+			})
+		}
+		td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+			return rs.ScopeSpans().Len() == 0
+		})
+
+		progress.AddDropped(dropped)
+
+		if td.ResourceSpans().Len() == 0 {
+			return nil
+		}
+		return nextConsumer.ConsumeTraces(ctx, td)
+	})
+}
+
+// sampledLogger returns a logger that logs each message at most once per conditionErrorLogInterval,
+// as a condition that fails to evaluate usually fails for every record
+func sampledLogger(logger *zap.Logger) *zap.Logger {
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewSamplerWithOptions(core, conditionErrorLogInterval, 1, 0)
+	}))
+}
+
+// prefixTemplateRegex matches placeholders in a prefix template such as {service.name}
+var prefixTemplateRegex = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// RenderPrefix renders the prefix template by replacing each {attribute} placeholder with the
+// matching value from resourceAttributes, and appends the result to root.
+// This allows only the entities an exporter partitioned under those resource attributes to be listed.
+// An empty template returns root unchanged.
+func RenderPrefix(root, template string, resourceAttributes map[string]string) (string, error) {
+	if template == "" {
+		return root, nil
+	}
+
+	var missing []string
+	rendered := prefixTemplateRegex.ReplaceAllStringFunc(template, func(placeholder string) string {
+		key := strings.TrimSpace(placeholder[1 : len(placeholder)-1])
+		value, ok := resourceAttributes[key]
+		if !ok {
+			missing = append(missing, key)
+		}
+		return value
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("resource attributes not set for prefix template: %s", strings.Join(missing, ", "))
+	}
+
+	if root == "" {
+		return rendered, nil
+	}
+
+	return strings.TrimSuffix(root, "/") + "/" + strings.TrimPrefix(rendered, "/"), nil
+}
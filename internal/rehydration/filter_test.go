@@ -0,0 +1,205 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/expr"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogsFilter(t *testing.T) {
+	condition, err := expr.NewOTTLLogRecordCondition(`resource.attributes["host.name"] == "web-1" and severity_text == "ERROR"`, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	sink := &consumertest.LogsSink{}
+	filter, err := NewLogsFilter(condition, zap.NewNop(), newTestProgress(t), sink)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	for _, host := range []string{"web-1", "web-2"} {
+		resourceLogs := logs.ResourceLogs().AppendEmpty()
+		resourceLogs.Resource().Attributes().PutStr("host.name", host)
+		records := resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+		records.AppendEmpty().SetSeverityText("ERROR")
+		records.AppendEmpty().SetSeverityText("INFO")
+	}
+
+	require.NoError(t, filter.ConsumeLogs(context.Background(), logs))
+	require.Equal(t, 1, sink.LogRecordCount())
+
+	host, ok := sink.AllLogs()[0].ResourceLogs().At(0).Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	require.Equal(t, "web-1", host.Str())
+
+	// Nothing matching isn't passed on
+	sink.Reset()
+	require.NoError(t, filter.ConsumeLogs(context.Background(), plog.NewLogs()))
+	require.Empty(t, sink.AllLogs())
+}
+
+func TestLogsFilterEvaluationError(t *testing.T) {
+	condition, err := expr.NewOTTLLogRecordCondition(`ParseJSON(body)["level"] == "error"`, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	core, observedLogs := observer.New(zap.DebugLevel)
+	progress := newTestProgress(t)
+	sink := &consumertest.LogsSink{}
+	filter, err := NewLogsFilter(condition, zap.New(core), progress, sink)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr(`{"level": "error"}`)
+	records.AppendEmpty().Body().SetStr("not json")
+	records.AppendEmpty().Body().SetStr("also not json")
+
+	require.NoError(t, filter.ConsumeLogs(context.Background(), logs))
+	require.Equal(t, 1, sink.LogRecordCount())
+
+	// Records that fail to evaluate are counted, but only logged once per interval
+	require.Equal(t, int64(2), progress.Snapshot().RecordsDropped)
+	require.Equal(t, 1, observedLogs.FilterMessage("Dropping log record where the condition failed to evaluate").Len())
+}
+
+func TestWithLogsCondition(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+
+	// No condition passes everything on
+	next, err := WithLogsCondition("", componenttest.NewNopTelemetrySettings(), newTestProgress(t), sink)
+	require.NoError(t, err)
+	require.Equal(t, consumer.Logs(sink), next)
+
+	_, err = WithLogsCondition(`severity_text ==`, componenttest.NewNopTelemetrySettings(), newTestProgress(t), sink)
+	require.ErrorContains(t, err, "invalid condition")
+
+	filter, err := WithLogsCondition(`severity_text == "ERROR"`, componenttest.NewNopTelemetrySettings(), newTestProgress(t), sink)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().SetSeverityText("ERROR")
+	records.AppendEmpty().SetSeverityText("INFO")
+
+	require.NoError(t, filter.ConsumeLogs(context.Background(), logs))
+	require.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestMetricsFilter(t *testing.T) {
+	condition, err := expr.NewOTTLMetricCondition(`name == "keep"`, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	sink := &consumertest.MetricsSink{}
+	filter, err := NewMetricsFilter(condition, zap.NewNop(), newTestProgress(t), sink)
+	require.NoError(t, err)
+
+	metrics := pmetric.NewMetrics()
+	metricSlice := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, name := range []string{"keep", "drop"} {
+		metric := metricSlice.AppendEmpty()
+		metric.SetName(name)
+		metric.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	}
+
+	require.NoError(t, filter.ConsumeMetrics(context.Background(), metrics))
+	require.Equal(t, 1, sink.DataPointCount())
+	require.Equal(t, "keep", sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
+
+func TestTracesFilter(t *testing.T) {
+	condition, err := expr.NewOTTLSpanCondition(`name == "keep"`, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	sink := &consumertest.TracesSink{}
+	filter, err := NewTracesFilter(condition, zap.NewNop(), newTestProgress(t), sink)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("keep")
+	spans.AppendEmpty().SetName("drop")
+
+	require.NoError(t, filter.ConsumeTraces(context.Background(), traces))
+	require.Equal(t, 1, sink.SpanCount())
+}
+
+func TestRenderPrefix(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		root        string
+		template    string
+		attributes  map[string]string
+		expected    string
+		expectedErr error
+	}{
+		{
+			desc:     "No template",
+			root:     "root",
+			expected: "root",
+		},
+		{
+			desc:       "Template without root",
+			template:   "service.name={service.name}/",
+			attributes: map[string]string{"service.name": "checkout"},
+			expected:   "service.name=checkout/",
+		},
+		{
+			desc:       "Template with root",
+			root:       "root/",
+			template:   "{cloud.region}/{host.name}/",
+			attributes: map[string]string{"host.name": "web-1", "cloud.region": "us-east-1"},
+			expected:   "root/us-east-1/web-1/",
+		},
+		{
+			desc:        "Missing attribute",
+			template:    "{host.name}/{service.name}/",
+			attributes:  map[string]string{"host.name": "web-1"},
+			expectedErr: errors.New("resource attributes not set for prefix template: service.name"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			prefix, err := RenderPrefix(tc.root, tc.template, tc.attributes)
+			if tc.expectedErr != nil {
+				require.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, prefix)
+		})
+	}
+}
+
+func newTestProgress(t *testing.T) *Progress {
+	progress, err := NewProgress(noop.NewMeterProvider(), component.MustNewID("filerehydration"), time.Now(), time.Now())
+	require.NoError(t, err)
+	return progress
+}
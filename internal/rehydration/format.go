@@ -0,0 +1,299 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/parquet-go/parquet-go"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Format is the encoding of the telemetry inside an entity
+type Format string
+
+const (
+	// FormatAuto detects the format from the entity extension
+	FormatAuto Format = "auto"
+
+	// FormatOTLPJSON is OTLP encoded as JSON
+	FormatOTLPJSON Format = "otlp_json"
+
+	// FormatOTLPProto is OTLP encoded as protobuf
+	FormatOTLPProto Format = "otlp_proto"
+
+	// FormatNDJSON is newline delimited raw log lines. Only supported for logs.
+	FormatNDJSON Format = "ndjson"
+
+	// FormatParquet is a Parquet file where each row is a log record. Only supported for logs.
+	FormatParquet Format = "parquet"
+)
+
+// Validate returns an error if the format is not a known format.
+// An empty format is treated as auto.
+func (f Format) Validate() error {
+	switch f {
+	case "", FormatAuto, FormatOTLPJSON, FormatOTLPProto, FormatNDJSON, FormatParquet:
+		return nil
+	default:
+		return fmt.Errorf("invalid format %q: must be one of auto, otlp_json, otlp_proto, ndjson or parquet", f)
+	}
+}
+
+// Compression is the compression applied to an entity
+type Compression string
+
+const (
+	// CompressionAuto detects the compression from the entity extension
+	CompressionAuto Compression = "auto"
+
+	// CompressionNone is an uncompressed entity
+	CompressionNone Compression = "none"
+
+	// CompressionGzip is a gzip compressed entity
+	CompressionGzip Compression = "gzip"
+
+	// CompressionZstd is a zstd compressed entity
+	CompressionZstd Compression = "zstd"
+)
+
+// Validate returns an error if the compression is not a known compression.
+// An empty compression is treated as auto.
+func (c Compression) Validate() error {
+	switch c {
+	case "", CompressionAuto, CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	default:
+		return fmt.Errorf("invalid compression %q: must be one of auto, none, gzip or zstd", c)
+	}
+}
+
+// compressionExtensions maps entity extensions to the compression they indicate
+var compressionExtensions = map[string]Compression{
+	".gz":   CompressionGzip,
+	".zst":  CompressionZstd,
+	".zstd": CompressionZstd,
+}
+
+// formatExtensions maps entity extensions to the format they indicate
+var formatExtensions = map[string]Format{
+	".json":    FormatOTLPJSON,
+	".pb":      FormatOTLPProto,
+	".binpb":   FormatOTLPProto,
+	".proto":   FormatOTLPProto,
+	".ndjson":  FormatNDJSON,
+	".jsonl":   FormatNDJSON,
+	".parquet": FormatParquet,
+}
+
+// DetectEncoding determines the format and compression of the entity.
+// Values configured explicitly take precedence over the entity extension.
+// A compressed entity without an inner format extension is treated as OTLP JSON
+// as that is what our exporters write.
+func DetectEncoding(entityName string, format Format, compression Compression) (Format, Compression, error) {
+	name := entityName
+	ext := filepath.Ext(name)
+
+	// Strip the compression extension so the format extension can be inspected
+	detectedCompression, compressed := compressionExtensions[ext]
+	if compressed {
+		name = strings.TrimSuffix(name, ext)
+	} else {
+		detectedCompression = CompressionNone
+	}
+
+	if compression != CompressionAuto && compression != "" {
+		detectedCompression = compression
+	}
+
+	if format != FormatAuto && format != "" {
+		return format, detectedCompression, nil
+	}
+
+	innerExt := filepath.Ext(name)
+	if detectedFormat, ok := formatExtensions[innerExt]; ok {
+		return detectedFormat, detectedCompression, nil
+	}
+
+	if compressed && innerExt == "" {
+		return FormatOTLPJSON, detectedCompression, nil
+	}
+
+	return "", "", fmt.Errorf("unsupported file type: %s", ext)
+}
+
+// Decompress decompresses the contents using the given compression
+func Decompress(contents []byte, compression Compression) ([]byte, error) {
+	switch compression {
+	case CompressionGzip:
+		result, err := GzipDecompress(contents)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		return result, nil
+	case CompressionZstd:
+		result, err := ZstdDecompress(contents)
+		if err != nil {
+			return nil, fmt.Errorf("zstd: %w", err)
+		}
+		return result, nil
+	case CompressionNone, CompressionAuto, "":
+		return contents, nil
+	default:
+		return nil, fmt.Errorf("unsupported compression: %s", compression)
+	}
+}
+
+// ZstdDecompress does a zstd decompression on the passed in contents
+func ZstdDecompress(contents []byte) ([]byte, error) {
+	zr, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("new reader: %w", err)
+	}
+	defer zr.Close()
+
+	result, err := zr.DecodeAll(contents, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decompression: %w", err)
+	}
+
+	return result, nil
+}
+
+// errUnsupportedFormat is returned when a format is not supported for a signal
+var errUnsupportedFormat = errors.New("unsupported format")
+
+// unmarshalNDJSONLogs creates a log record for each non-empty line in the contents.
+// Lines that are JSON objects become map bodies, all other lines become string bodies.
+func unmarshalNDJSONLogs(contents []byte) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(contents)+1)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		record := records.AppendEmpty()
+		var body map[string]any
+		if err := json.Unmarshal(line, &body); err == nil {
+			if err := record.Body().SetEmptyMap().FromRaw(body); err != nil {
+				return plog.Logs{}, fmt.Errorf("set body: %w", err)
+			}
+			continue
+		}
+		record.Body().SetStr(string(line))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return plog.Logs{}, fmt.Errorf("read lines: %w", err)
+	}
+
+	return logs, nil
+}
+
+// parquetReadBatchSize is the number of rows read from a Parquet file at a time
+const parquetReadBatchSize = 100
+
+// unmarshalParquetLogs creates a log record for each row of the Parquet file.
+// The body of each record is a map keyed by the dot separated column path.
+func unmarshalParquetLogs(contents []byte) (plog.Logs, error) {
+	file, err := parquet.OpenFile(bytes.NewReader(contents), int64(len(contents)))
+	if err != nil {
+		return plog.Logs{}, fmt.Errorf("open parquet: %w", err)
+	}
+
+	reader := parquet.NewReader(file)
+	defer reader.Close()
+
+	columns := reader.Schema().Columns()
+	columnNames := make([]string, len(columns))
+	for i, path := range columns {
+		columnNames[i] = strings.Join(path, ".")
+	}
+
+	logs := plog.NewLogs()
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+
+	rows := make([]parquet.Row, parquetReadBatchSize)
+	for {
+		n, err := reader.ReadRows(rows)
+		for _, row := range rows[:n] {
+			body := records.AppendEmpty().Body().SetEmptyMap()
+			for _, value := range row {
+				if value.IsNull() || value.Column() < 0 || value.Column() >= len(columnNames) {
+					continue
+				}
+				putParquetValue(body, columnNames[value.Column()], value)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return plog.Logs{}, fmt.Errorf("read rows: %w", err)
+		}
+	}
+
+	return logs, nil
+}
+
+// putParquetValue sets the value under key in m, collecting repeated values into a slice
+func putParquetValue(m pcommon.Map, key string, value parquet.Value) {
+	if existing, ok := m.Get(key); ok {
+		if existing.Type() != pcommon.ValueTypeSlice {
+			prev := pcommon.NewValueEmpty()
+			existing.CopyTo(prev)
+			prev.CopyTo(existing.SetEmptySlice().AppendEmpty())
+		}
+		setParquetValue(existing.Slice().AppendEmpty(), value)
+		return
+	}
+
+	setParquetValue(m.PutEmpty(key), value)
+}
+
+// setParquetValue converts a Parquet value into a pcommon value
+func setParquetValue(dest pcommon.Value, value parquet.Value) {
+	switch value.Kind() {
+	case parquet.Boolean:
+		dest.SetBool(value.Boolean())
+	case parquet.Int32:
+		dest.SetInt(int64(value.Int32()))
+	case parquet.Int64:
+		dest.SetInt(value.Int64())
+	case parquet.Float:
+		dest.SetDouble(float64(value.Float()))
+	case parquet.Double:
+		dest.SetDouble(value.Double())
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		dest.SetStr(string(value.ByteArray()))
+	default:
+		dest.SetStr(value.String())
+	}
+}
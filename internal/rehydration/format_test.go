@@ -0,0 +1,169 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestDetectEncoding(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		entityName          string
+		format              Format
+		compression         Compression
+		expectedFormat      Format
+		expectedCompression Compression
+		expectedErr         error
+	}{
+		{
+			desc:                "JSON",
+			entityName:          "year=2023/month=10/day=01/hour=13/logs_1.json",
+			format:              FormatAuto,
+			compression:         CompressionAuto,
+			expectedFormat:      FormatOTLPJSON,
+			expectedCompression: CompressionNone,
+		},
+		{
+			desc:                "Gzip without inner extension",
+			entityName:          "year=2023/month=10/day=01/hour=13/logs_1.gz",
+			format:              FormatAuto,
+			compression:         CompressionAuto,
+			expectedFormat:      FormatOTLPJSON,
+			expectedCompression: CompressionGzip,
+		},
+		{
+			desc:                "Zstd protobuf",
+			entityName:          "year=2023/month=10/day=01/hour=13/logs_1.binpb.zst",
+			format:              FormatAuto,
+			compression:         CompressionAuto,
+			expectedFormat:      FormatOTLPProto,
+			expectedCompression: CompressionZstd,
+		},
+		{
+			desc:                "NDJSON",
+			entityName:          "year=2023/month=10/day=01/hour=13/app.ndjson.gz",
+			format:              FormatAuto,
+			compression:         CompressionAuto,
+			expectedFormat:      FormatNDJSON,
+			expectedCompression: CompressionGzip,
+		},
+		{
+			desc:                "Parquet",
+			entityName:          "year=2023/month=10/day=01/hour=13/part-0001.parquet",
+			format:              FormatAuto,
+			compression:         CompressionAuto,
+			expectedFormat:      FormatParquet,
+			expectedCompression: CompressionNone,
+		},
+		{
+			desc:                "Explicit values override extension",
+			entityName:          "year=2023/month=10/day=01/hour=13/part-0001",
+			format:              FormatNDJSON,
+			compression:         CompressionZstd,
+			expectedFormat:      FormatNDJSON,
+			expectedCompression: CompressionZstd,
+		},
+		{
+			desc:        "Unsupported extension",
+			entityName:  "year=2023/month=10/day=01/hour=13/logs_1.nope",
+			format:      FormatAuto,
+			compression: CompressionAuto,
+			expectedErr: errors.New("unsupported file type: .nope"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			format, compression, err := DetectEncoding(tc.entityName, tc.format, tc.compression)
+			if tc.expectedErr != nil {
+				require.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedFormat, format)
+			require.Equal(t, tc.expectedCompression, compression)
+		})
+	}
+}
+
+func TestFormatValidate(t *testing.T) {
+	require.NoError(t, FormatParquet.Validate())
+	require.EqualError(t, Format("csv").Validate(), `invalid format "csv": must be one of auto, otlp_json, otlp_proto, ndjson or parquet`)
+	require.NoError(t, CompressionZstd.Validate())
+	require.EqualError(t, Compression("bz2").Validate(), `invalid compression "bz2": must be one of auto, none, gzip or zstd`)
+}
+
+func TestZstdDecompress(t *testing.T) {
+	raw := []byte("some raw content")
+
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := encoder.EncodeAll(raw, nil)
+	require.NoError(t, encoder.Close())
+
+	result, err := Decompress(compressed, CompressionZstd)
+	require.NoError(t, err)
+	require.Equal(t, raw, result)
+
+	_, err = Decompress([]byte("nope"), CompressionZstd)
+	require.Error(t, err)
+}
+
+func TestUnmarshalNDJSONLogs(t *testing.T) {
+	contents := []byte("{\"message\":\"hello\",\"level\":\"info\"}\n\nplain line\n")
+
+	logs, err := unmarshalNDJSONLogs(contents)
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, pcommon.ValueTypeMap, records.At(0).Body().Type())
+	require.Equal(t, map[string]any{"message": "hello", "level": "info"}, records.At(0).Body().Map().AsRaw())
+	require.Equal(t, "plain line", records.At(1).Body().Str())
+}
+
+type testParquetRow struct {
+	Message string  `parquet:"message"`
+	Status  int64   `parquet:"status"`
+	Latency float64 `parquet:"latency"`
+}
+
+func TestUnmarshalParquetLogs(t *testing.T) {
+	var buf bytes.Buffer
+	rows := []testParquetRow{
+		{Message: "GET /", Status: 200, Latency: 0.5},
+		{Message: "GET /missing", Status: 404, Latency: 1.25},
+	}
+	require.NoError(t, parquet.Write(&buf, rows))
+
+	logs, err := unmarshalParquetLogs(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, 2, logs.LogRecordCount())
+
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, map[string]any{"message": "GET /missing", "status": int64(404), "latency": 1.25}, records.At(1).Body().Map().AsRaw())
+
+	_, err = unmarshalParquetLogs([]byte("nope"))
+	require.Error(t, err)
+}
@@ -41,7 +41,7 @@ const (
 )
 
 // entityNameRegex is the regex used to detect if an entity matches the expected path
-var entityNameRegex = regexp.MustCompile(`^(?:[^/]*/)?year=(\d{4})/month=(\d{2})/day=(\d{2})/hour=(\d{2})/(?:minute=(\d{2})/)?([^/].*)$`)
+var entityNameRegex = regexp.MustCompile(`^(?:.*/)?year=(\d{4})/month=(\d{2})/day=(\d{2})/hour=(\d{2})/(?:minute=(\d{2})/)?([^/].*)$`)
 
 // ParseEntityPath returns true if the entity is within the existing time range
 func ParseEntityPath(entityName string) (entityTime *time.Time, telemetryType pipeline.Signal, err error) {
@@ -91,6 +91,17 @@ func IsInTimeRange(entityTime, startingTime, endingTime time.Time) bool {
 		(entityTime.Equal(endingTime) || entityTime.Before(endingTime))
 }
 
+// SupportsTelemetry returns true if an entity's telemetry type can be consumed by a receiver of the supported type.
+// Entities without a telemetry type in their name are accepted when the format is configured explicitly
+// as archives written by other tools do not follow our naming.
+func SupportsTelemetry(telemetryType, supported pipeline.Signal, format Format) bool {
+	if telemetryType == supported {
+		return true
+	}
+
+	return telemetryType == (pipeline.Signal{}) && format != FormatAuto
+}
+
 // GzipDecompress does a gzip decompression on the passed in contents
 func GzipDecompress(contents []byte) ([]byte, error) {
 	gr, err := gzip.NewReader(bytes.NewBuffer(contents))
@@ -64,6 +64,13 @@ func TestParseEntityPath(t *testing.T) {
 			expectedType: pipeline.SignalMetrics,
 			expectedErr:  nil,
 		},
+		{
+			desc:         "Nested prefix, minute, logs",
+			entityName:   "root/service.name=checkout/year=2023/month=01/day=04/hour=12/minute=02/entitylogs_12345.json",
+			expectedTime: &expectedTimeMinute,
+			expectedType: pipeline.SignalLogs,
+			expectedErr:  nil,
+		},
 		{
 			desc:         "No Prefix, minute, metrics",
 			entityName:   "year=2023/month=01/day=04/hour=12/minute=02/entitymetrics_12345.json",
@@ -163,6 +170,45 @@ func TestIsInTimeRange(t *testing.T) {
 	}
 }
 
+func TestSupportsTelemetry(t *testing.T) {
+	testcases := []struct {
+		name          string
+		telemetryType pipeline.Signal
+		format        Format
+		expected      bool
+	}{
+		{
+			name:          "Same telemetry type",
+			telemetryType: pipeline.SignalLogs,
+			format:        FormatAuto,
+			expected:      true,
+		},
+		{
+			name:          "Different telemetry type",
+			telemetryType: pipeline.SignalMetrics,
+			format:        FormatOTLPJSON,
+			expected:      false,
+		},
+		{
+			name:     "No telemetry type with explicit format",
+			format:   FormatNDJSON,
+			expected: true,
+		},
+		{
+			name:     "No telemetry type with auto format",
+			format:   FormatAuto,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := SupportsTelemetry(tc.telemetryType, pipeline.SignalLogs, tc.format)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
 func TestGzipDecompress(t *testing.T) {
 	raw := []byte{31, 139, 8, 0, 0, 0, 0, 0, 0, 255, 0, 99}
 
@@ -21,17 +21,17 @@ func (_m *MockConsumer) EXPECT() *MockConsumer_Expecter {
 	return &MockConsumer_Expecter{mock: &_m.Mock}
 }
 
-// Consume provides a mock function with given fields: ctx, entityContent
-func (_m *MockConsumer) Consume(ctx context.Context, entityContent []byte) error {
-	ret := _m.Called(ctx, entityContent)
+// Consume provides a mock function with given fields: ctx, entityContent, format
+func (_m *MockConsumer) Consume(ctx context.Context, entityContent []byte, format Format) error {
+	ret := _m.Called(ctx, entityContent, format)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Consume")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
-		r0 = rf(ctx, entityContent)
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, Format) error); ok {
+		r0 = rf(ctx, entityContent, format)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -47,13 +47,14 @@ type MockConsumer_Consume_Call struct {
 // Consume is a helper method to define mock.On call
 //   - ctx context.Context
 //   - entityContent []byte
-func (_e *MockConsumer_Expecter) Consume(ctx interface{}, entityContent interface{}) *MockConsumer_Consume_Call {
-	return &MockConsumer_Consume_Call{Call: _e.mock.On("Consume", ctx, entityContent)}
+//   - format Format
+func (_e *MockConsumer_Expecter) Consume(ctx interface{}, entityContent interface{}, format interface{}) *MockConsumer_Consume_Call {
+	return &MockConsumer_Consume_Call{Call: _e.mock.On("Consume", ctx, entityContent, format)}
 }
 
-func (_c *MockConsumer_Consume_Call) Run(run func(ctx context.Context, entityContent []byte)) *MockConsumer_Consume_Call {
+func (_c *MockConsumer_Consume_Call) Run(run func(ctx context.Context, entityContent []byte, format Format)) *MockConsumer_Consume_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]byte))
+		run(args[0].(context.Context), args[1].([]byte), args[2].(Format))
 	})
 	return _c
 }
@@ -63,7 +64,7 @@ func (_c *MockConsumer_Consume_Call) Return(_a0 error) *MockConsumer_Consume_Cal
 	return _c
 }
 
-func (_c *MockConsumer_Consume_Call) RunAndReturn(run func(context.Context, []byte) error) *MockConsumer_Consume_Call {
+func (_c *MockConsumer_Consume_Call) RunAndReturn(run func(context.Context, []byte, Format) error) *MockConsumer_Consume_Call {
 	_c.Call.Return(run)
 	return _c
 }
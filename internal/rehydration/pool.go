@@ -0,0 +1,82 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Entity is an entity that has been selected for rehydration
+type Entity struct {
+	// Name is the full path of the entity
+	Name string
+
+	// Time is the time parsed from the entity path
+	Time time.Time
+
+	// Size is the size of the entity in bytes
+	Size int64
+}
+
+// errNotScheduled is used internally to signal an entity was never processed
+var errNotScheduled = errors.New("entity not scheduled")
+
+// ProcessEntities processes entities using at most concurrency workers at a time.
+//
+// commit is called from the calling goroutine in the same order as entities, once the entity
+// and every entity before it has finished processing. This allows checkpoints to be committed in order
+// even though entities complete out of order. The error passed to commit is the error returned by process.
+//
+// If ctx is done no new entities are scheduled and ProcessEntities returns once in flight entities have been committed.
+func ProcessEntities(ctx context.Context, entities []Entity, concurrency int, process func(context.Context, Entity) error, commit func(Entity, error)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]chan error, len(entities))
+	for i := range results {
+		results[i] = make(chan error, 1)
+	}
+
+	go func() {
+		sem := make(chan struct{}, concurrency)
+		for i, entity := range entities {
+			select {
+			case <-ctx.Done():
+				for _, result := range results[i:] {
+					result <- errNotScheduled
+				}
+				return
+			case sem <- struct{}{}:
+			}
+
+			go func() {
+				defer func() { <-sem }()
+				results[i] <- process(ctx, entity)
+			}()
+		}
+	}()
+
+	for i, entity := range entities {
+		err := <-results[i]
+		if errors.Is(err, errNotScheduled) {
+			return
+		}
+
+		commit(entity, err)
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessEntities(t *testing.T) {
+	entities := make([]Entity, 10)
+	for i := range entities {
+		entities[i] = Entity{Name: fmt.Sprintf("entity_%d", i), Size: int64(i)}
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	process := func(_ context.Context, entity Entity) error {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			prev := maxInFlight.Load()
+			if current <= prev || maxInFlight.CompareAndSwap(prev, current) {
+				break
+			}
+		}
+
+		// Finish earlier entities last so completion order is reversed
+		time.Sleep(time.Duration(int64(len(entities))-entity.Size) * time.Millisecond)
+		if entity.Name == "entity_3" {
+			return errors.New("bad")
+		}
+		return nil
+	}
+
+	committed := []string{}
+	commitErrs := map[string]error{}
+	commit := func(entity Entity, err error) {
+		committed = append(committed, entity.Name)
+		commitErrs[entity.Name] = err
+	}
+
+	ProcessEntities(context.Background(), entities, 3, process, commit)
+
+	expected := make([]string, len(entities))
+	for i, entity := range entities {
+		expected[i] = entity.Name
+	}
+	require.Equal(t, expected, committed)
+	require.EqualError(t, commitErrs["entity_3"], "bad")
+	require.NoError(t, commitErrs["entity_4"])
+	require.LessOrEqual(t, maxInFlight.Load(), int32(3))
+}
+
+func TestProcessEntitiesCanceled(t *testing.T) {
+	entities := []Entity{{Name: "one"}, {Name: "two"}, {Name: "three"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	process := func(_ context.Context, entity Entity) error {
+		if entity.Name == "one" {
+			cancel()
+		}
+		return nil
+	}
+
+	committed := []string{}
+	commit := func(entity Entity, _ error) {
+		committed = append(committed, entity.Name)
+	}
+
+	ProcessEntities(ctx, entities, 1, process, commit)
+
+	require.Equal(t, []string{"one"}, committed)
+}
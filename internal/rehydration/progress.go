@@ -0,0 +1,248 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+// Progress tracks how far a rehydration receiver has progressed through its time range
+// and reports it as internal telemetry.
+type Progress struct {
+	startingTime time.Time
+	endingTime   time.Time
+
+	mu           sync.Mutex
+	started      time.Time
+	objectsTotal int64
+	objectsDone  int64
+	bytes        int64
+	cursor       time.Time
+	dropped      int64
+
+	registration metric.Registration
+
+	// now is used to get the current time. Meant to be overwritten for tests.
+	now func() time.Time
+}
+
+// ProgressSnapshot is the state of a Progress at a point in time
+type ProgressSnapshot struct {
+	// ObjectsTotal is the number of entities found that need to be rehydrated
+	ObjectsTotal int64
+
+	// ObjectsDone is the number of entities that have been rehydrated
+	ObjectsDone int64
+
+	// Bytes is the number of bytes that have been rehydrated
+	Bytes int64
+
+	// TimeCursor is the time of the latest entity that has been rehydrated
+	TimeCursor time.Time
+
+	// RecordsDropped is the number of records dropped because the condition failed to evaluate against them
+	RecordsDropped int64
+
+	// ETA is the estimated time remaining until the ending time is reached.
+	// Zero if no estimate can be made yet.
+	ETA time.Duration
+}
+
+// NewProgress creates a new Progress for the given receiver and time range
+// and registers its metrics with the meter provider.
+func NewProgress(mp metric.MeterProvider, id component.ID, startingTime, endingTime time.Time) (*Progress, error) {
+	p := &Progress{
+		startingTime: startingTime,
+		endingTime:   endingTime,
+		now:          time.Now,
+	}
+
+	meter := mp.Meter("github.com/observiq/bindplane-otel-collector/internal/rehydration")
+
+	objectsTotal, err := meter.Int64ObservableGauge(
+		progressMetricName("objects_total"),
+		metric.WithDescription("Number of objects found that need to be rehydrated"),
+		metric.WithUnit("{objects}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create objects_total gauge: %w", err)
+	}
+
+	objectsDone, err := meter.Int64ObservableGauge(
+		progressMetricName("objects_done"),
+		metric.WithDescription("Number of objects that have been rehydrated"),
+		metric.WithUnit("{objects}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create objects_done gauge: %w", err)
+	}
+
+	bytes, err := meter.Int64ObservableGauge(
+		progressMetricName("bytes"),
+		metric.WithDescription("Number of bytes that have been rehydrated"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create bytes gauge: %w", err)
+	}
+
+	cursor, err := meter.Int64ObservableGauge(
+		progressMetricName("time_cursor"),
+		metric.WithDescription("Unix time of the latest object that has been rehydrated"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create time_cursor gauge: %w", err)
+	}
+
+	dropped, err := meter.Int64ObservableGauge(
+		progressMetricName("records_dropped"),
+		metric.WithDescription("Number of records dropped because the condition failed to evaluate against them"),
+		metric.WithUnit("{records}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create records_dropped gauge: %w", err)
+	}
+
+	eta, err := meter.Float64ObservableGauge(
+		progressMetricName("eta"),
+		metric.WithDescription("Estimated time remaining until rehydration reaches the ending time"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create eta gauge: %w", err)
+	}
+
+	attrs := metric.WithAttributeSet(attribute.NewSet(attribute.String("receiver", id.String())))
+	p.registration, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		snapshot := p.Snapshot()
+		o.ObserveInt64(objectsTotal, snapshot.ObjectsTotal, attrs)
+		o.ObserveInt64(objectsDone, snapshot.ObjectsDone, attrs)
+		o.ObserveInt64(bytes, snapshot.Bytes, attrs)
+		if !snapshot.TimeCursor.IsZero() {
+			o.ObserveInt64(cursor, snapshot.TimeCursor.Unix(), attrs)
+		}
+		o.ObserveInt64(dropped, snapshot.RecordsDropped, attrs)
+		o.ObserveFloat64(eta, snapshot.ETA.Seconds(), attrs)
+		return nil
+	}, objectsTotal, objectsDone, bytes, cursor, dropped, eta)
+	if err != nil {
+		return nil, fmt.Errorf("register progress callback: %w", err)
+	}
+
+	return p, nil
+}
+
+// AddTotal adds n entities to the number of entities that need to be rehydrated
+func (p *Progress) AddTotal(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.started.IsZero() {
+		p.started = p.now()
+	}
+	p.objectsTotal += int64(n)
+}
+
+// Done records the entity as rehydrated and advances the time cursor
+func (p *Progress) Done(entity Entity) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.objectsDone++
+	p.bytes += entity.Size
+	if entity.Time.After(p.cursor) {
+		p.cursor = entity.Time
+	}
+}
+
+// AddDropped adds n records to the number dropped because the condition failed to evaluate against them
+func (p *Progress) AddDropped(n int) {
+	if n == 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.dropped += int64(n)
+}
+
+// Snapshot returns the current progress
+func (p *Progress) Snapshot() ProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return ProgressSnapshot{
+		ObjectsTotal:   p.objectsTotal,
+		ObjectsDone:    p.objectsDone,
+		Bytes:          p.bytes,
+		TimeCursor:     p.cursor,
+		RecordsDropped: p.dropped,
+		ETA:            p.eta(),
+	}
+}
+
+// Log logs the current progress
+func (p *Progress) Log(logger *zap.Logger) {
+	snapshot := p.Snapshot()
+	logger.Info("Rehydration progress",
+		zap.Int64("objects_total", snapshot.ObjectsTotal),
+		zap.Int64("objects_done", snapshot.ObjectsDone),
+		zap.Int64("bytes", snapshot.Bytes),
+		zap.Time("time_cursor", snapshot.TimeCursor),
+		zap.Int64("records_dropped", snapshot.RecordsDropped),
+		zap.Duration("eta", snapshot.ETA),
+	)
+}
+
+// eta estimates the time remaining based on how far the time cursor has moved through the time range.
+// Entities are listed in path order so the cursor is a better measure of progress than the
+// object count, which only includes entities listed so far.
+func (p *Progress) eta() time.Duration {
+	timeRange := p.endingTime.Sub(p.startingTime)
+	covered := p.cursor.Sub(p.startingTime)
+	if p.started.IsZero() || p.cursor.IsZero() || timeRange <= 0 || covered <= 0 {
+		return 0
+	}
+
+	if covered >= timeRange {
+		return 0
+	}
+
+	elapsed := p.now().Sub(p.started)
+	return time.Duration(float64(elapsed) * float64(timeRange-covered) / float64(covered))
+}
+
+// Close unregisters the progress metrics
+func (p *Progress) Close() error {
+	if p.registration == nil {
+		return nil
+	}
+	return p.registration.Unregister()
+}
+
+// progressMetricName returns the full name of a progress metric
+func progressMetricName(metric string) string {
+	return fmt.Sprintf("otelcol_receiver_rehydration_%s", metric)
+}
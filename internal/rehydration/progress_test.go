@@ -0,0 +1,99 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rehydration //import "github.com/observiq/bindplane-otel-collector/internal/rehydration"
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestProgress(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	startingTime := time.Date(2023, 10, 2, 0, 0, 0, 0, time.UTC)
+	endingTime := startingTime.Add(4 * time.Hour)
+
+	id := component.MustNewID("awss3rehydration")
+	p, err := NewProgress(mp, id, startingTime, endingTime)
+	require.NoError(t, err)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	// No progress yet so no estimate can be made
+	require.Equal(t, ProgressSnapshot{}, p.Snapshot())
+
+	p.AddTotal(4)
+	p.Done(Entity{Name: "one", Time: startingTime.Add(time.Hour), Size: 10})
+	now = now.Add(time.Minute)
+
+	snapshot := p.Snapshot()
+	require.Equal(t, ProgressSnapshot{
+		ObjectsTotal: 4,
+		ObjectsDone:  1,
+		Bytes:        10,
+		TimeCursor:   startingTime.Add(time.Hour),
+		ETA:          3 * time.Minute,
+	}, snapshot)
+
+	p.AddDropped(3)
+	require.Equal(t, int64(3), p.Snapshot().RecordsDropped)
+
+	// An older entity finishing doesn't move the cursor backwards
+	p.Done(Entity{Name: "two", Time: startingTime, Size: 5})
+	require.Equal(t, startingTime.Add(time.Hour), p.Snapshot().TimeCursor)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	values := map[string]any{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Gauge[int64]:
+			values[m.Name] = data.DataPoints[0].Value
+		case metricdata.Gauge[float64]:
+			values[m.Name] = data.DataPoints[0].Value
+		}
+	}
+	require.Equal(t, map[string]any{
+		"otelcol_receiver_rehydration_objects_total":   int64(4),
+		"otelcol_receiver_rehydration_objects_done":    int64(2),
+		"otelcol_receiver_rehydration_bytes":           int64(15),
+		"otelcol_receiver_rehydration_time_cursor":     startingTime.Add(time.Hour).Unix(),
+		"otelcol_receiver_rehydration_records_dropped": int64(3),
+		"otelcol_receiver_rehydration_eta":             float64(180),
+	}, values)
+
+	core, logs := observer.New(zap.InfoLevel)
+	p.Log(zap.New(core))
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	require.Equal(t, "Rehydration progress", entry.Message)
+	require.Equal(t, int64(2), entry.ContextMap()["objects_done"])
+	require.Equal(t, int64(15), entry.ContextMap()["bytes"])
+	require.Equal(t, int64(3), entry.ContextMap()["records_dropped"])
+
+	require.NoError(t, p.Close())
+}
@@ -21,8 +21,10 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -54,6 +56,15 @@ var (
 
 	// errInvalidCAFile for ca file that is not readable
 	errInvalidCAFile = "failed to read TLS CA file"
+
+	// errInvalidProxyURL for a proxy URL that can't be used
+	errInvalidProxyURL = "invalid proxy URL"
+
+	// errInvalidMirrorURL for a package mirror URL that can't be used
+	errInvalidMirrorURL = "invalid package mirror URL"
+
+	// errInvalidPackageSigningKey for package signing key file that is not readable
+	errInvalidPackageSigningKey = "failed to read package signing key file"
 )
 
 type agentIDType string
@@ -196,6 +207,31 @@ type Config struct {
 	AgentID   AgentID    `yaml:"agent_id" mapstructure:"agent_id"`
 	TLS       *TLSConfig `yaml:"tls_config,omitempty" mapstructure:"tls_config,omitempty"`
 
+	// Proxy is the proxy used to connect to the server. The proxy environment variables are used if not set.
+	Proxy *ProxyConfig `yaml:"proxy,omitempty" mapstructure:"proxy,omitempty"`
+
+	// Headers are additional headers sent when connecting to the server
+	Headers map[string]string `yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
+
+	// PollingInterval is how often the server is polled when connecting over plain HTTP
+	PollingInterval time.Duration `yaml:"polling_interval,omitempty" mapstructure:"polling_interval,omitempty"`
+
+	// ConfigHealthCheck configures the health window watched after a remote collector config is applied
+	ConfigHealthCheck *ConfigHealthCheck `yaml:"config_health_check,omitempty" mapstructure:"config_health_check,omitempty"`
+
+	// OwnMetrics and OwnLogs are where the collector sends its own telemetry, as offered by the server
+	OwnMetrics *TelemetryConnection `yaml:"own_metrics,omitempty" mapstructure:"own_metrics,omitempty"`
+	OwnLogs    *TelemetryConnection `yaml:"own_logs,omitempty" mapstructure:"own_logs,omitempty"`
+
+	// PackageDownload configures how agent packages are downloaded
+	PackageDownload *PackageDownloadConfig `yaml:"package_download,omitempty" mapstructure:"package_download,omitempty"`
+
+	// PackageSigningKeys are paths to PEM encoded public keys trusted to sign agent packages, in addition to the release key
+	PackageSigningKeys []string `yaml:"package_signing_keys,omitempty" mapstructure:"package_signing_keys,omitempty"`
+
+	// UpdateHealthCheck configures the checks the updater makes before committing an agent update
+	UpdateHealthCheck *UpdateHealthCheck `yaml:"update_health_check,omitempty" mapstructure:"update_health_check,omitempty"`
+
 	// Updatable fields
 	Labels                      *string           `yaml:"labels,omitempty" mapstructure:"labels,omitempty"`
 	AgentName                   *string           `yaml:"agent_name,omitempty" mapstructure:"agent_name,omitempty"`
@@ -211,6 +247,202 @@ type TLSConfig struct {
 	CAFile             *string `yaml:"ca_file" mapstructure:"ca_file"`
 }
 
+// ProxyConfig represents the proxy used to connect to the OpAmp server
+type ProxyConfig struct {
+	// URL of the proxy. The http, https and socks5 schemes are supported.
+	URL      string  `yaml:"url" mapstructure:"url"`
+	Username *string `yaml:"username,omitempty" mapstructure:"username,omitempty"`
+	Password *string `yaml:"password,omitempty" mapstructure:"password,omitempty"`
+}
+
+// ProxyURL parses the proxy URL and adds the basic auth credentials to it
+func (p ProxyConfig) ProxyURL() (*url.URL, error) {
+	proxyURL, err := url.Parse(p.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", errInvalidProxyURL, err)
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("%s: unsupported scheme %q", errInvalidProxyURL, proxyURL.Scheme)
+	}
+
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("%s: missing host", errInvalidProxyURL)
+	}
+
+	if p.Username != nil {
+		if p.Password != nil {
+			proxyURL.User = url.UserPassword(*p.Username, *p.Password)
+		} else {
+			proxyURL.User = url.User(*p.Username)
+		}
+	}
+
+	return proxyURL, nil
+}
+
+func (p ProxyConfig) copy() *ProxyConfig {
+	proxyCopy := ProxyConfig{
+		URL: p.URL,
+	}
+
+	if p.Username != nil {
+		proxyCopy.Username = new(string)
+		*proxyCopy.Username = *p.Username
+	}
+	if p.Password != nil {
+		proxyCopy.Password = new(string)
+		*proxyCopy.Password = *p.Password
+	}
+
+	return &proxyCopy
+}
+
+// ConfigHealthCheck configures the health window watched after a remote collector config is applied.
+// If the collector is unhealthy during the window, the previous config is restored.
+type ConfigHealthCheck struct {
+	// Window is how long the collector is watched after the config is applied. Zero disables the health check.
+	Window time.Duration `yaml:"window,omitempty" mapstructure:"window,omitempty"`
+
+	// MaxExportFailureRate is the highest fraction of items exporters may fail to send during the window.
+	// Zero disables the export failure rate check.
+	MaxExportFailureRate float64 `yaml:"max_export_failure_rate,omitempty" mapstructure:"max_export_failure_rate,omitempty"`
+
+	// MetricsEndpoint is the collector's Prometheus telemetry endpoint used to measure the export failure rate
+	MetricsEndpoint string `yaml:"metrics_endpoint,omitempty" mapstructure:"metrics_endpoint,omitempty"`
+}
+
+// Enabled returns true if the health check is configured with a window
+func (h *ConfigHealthCheck) Enabled() bool {
+	return h != nil && h.Window > 0
+}
+
+func (h ConfigHealthCheck) copy() *ConfigHealthCheck {
+	hcCopy := h
+	return &hcCopy
+}
+
+// UpdateHealthCheck configures the checks the updater makes on the new collector before an update is committed.
+// A failed check rolls back the update. It's read by the updater, the collector only carries it in the config.
+type UpdateHealthCheck struct {
+	// ReconnectTimeout is how long the updater waits for the new collector to reconnect to the server
+	ReconnectTimeout time.Duration `yaml:"reconnect_timeout,omitempty" mapstructure:"reconnect_timeout,omitempty"`
+
+	// Window is how long the new collector is watched after it reconnects. Zero disables the health endpoint checks.
+	Window time.Duration `yaml:"window,omitempty" mapstructure:"window,omitempty"`
+
+	// Endpoint is the collector's health check extension endpoint polled during the window
+	Endpoint string `yaml:"endpoint,omitempty" mapstructure:"endpoint,omitempty"`
+
+	// MaxRestarts is how many times the collector may restart during the window before it's considered crash looping
+	MaxRestarts int `yaml:"max_restarts,omitempty" mapstructure:"max_restarts,omitempty"`
+}
+
+func (u UpdateHealthCheck) validate() error {
+	if u.ReconnectTimeout < 0 || u.Window < 0 || u.MaxRestarts < 0 {
+		return errors.New("update health check settings can't be negative")
+	}
+
+	if u.Endpoint != "" {
+		endpoint, err := url.Parse(u.Endpoint)
+		if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("invalid update health check endpoint %q: must be an http or https URL with a host", u.Endpoint)
+		}
+	}
+
+	return nil
+}
+
+func (u UpdateHealthCheck) copy() *UpdateHealthCheck {
+	uhCopy := u
+	return &uhCopy
+}
+
+// PackageDownloadConfig configures how agent packages are downloaded, so a fleet updating at once doesn't saturate a site's link
+type PackageDownloadConfig struct {
+	// MirrorURL is a site-local mirror or caching proxy packages are fetched from before the server's download URL.
+	// The path of the download URL is appended to it.
+	MirrorURL string `yaml:"mirror_url,omitempty" mapstructure:"mirror_url,omitempty"`
+
+	// BandwidthLimit is the highest download rate in bytes per second. Zero is unlimited.
+	BandwidthLimit int64 `yaml:"bandwidth_limit,omitempty" mapstructure:"bandwidth_limit,omitempty"`
+
+	// MaxSize is the largest package in bytes that will be downloaded. Zero uses the default limit.
+	MaxSize int64 `yaml:"max_size,omitempty" mapstructure:"max_size,omitempty"`
+
+	// MaxStartDelay is the longest random delay before a download starts, spreading out a fleet's downloads
+	MaxStartDelay time.Duration `yaml:"max_start_delay,omitempty" mapstructure:"max_start_delay,omitempty"`
+}
+
+// MirrorDownloadURL returns the URL of the download on the mirror, or an empty string if no mirror is set
+func (p PackageDownloadConfig) MirrorDownloadURL(downloadURL string) (string, error) {
+	if p.MirrorURL == "" {
+		return "", nil
+	}
+
+	mirror, err := url.Parse(p.MirrorURL)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", errInvalidMirrorURL, err)
+	}
+
+	download, err := url.Parse(downloadURL)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse download url: %w", err)
+	}
+
+	return mirror.JoinPath(download.Path).String(), nil
+}
+
+func (p PackageDownloadConfig) validate() error {
+	if p.MirrorURL != "" {
+		mirror, err := url.Parse(p.MirrorURL)
+		if err != nil {
+			return fmt.Errorf("%s: %w", errInvalidMirrorURL, err)
+		}
+
+		if (mirror.Scheme != "http" && mirror.Scheme != "https") || mirror.Host == "" {
+			return fmt.Errorf("%s: must be an http or https URL with a host", errInvalidMirrorURL)
+		}
+	}
+
+	if p.BandwidthLimit < 0 || p.MaxSize < 0 || p.MaxStartDelay < 0 {
+		return errors.New("package download limits can't be negative")
+	}
+
+	return nil
+}
+
+func (p PackageDownloadConfig) copy() *PackageDownloadConfig {
+	pdCopy := p
+	return &pdCopy
+}
+
+// TelemetryConnection is an OTLP/HTTP destination for the collector's own telemetry
+type TelemetryConnection struct {
+	// Endpoint is the full URL of the OTLP/HTTP receiver, including the path
+	Endpoint string `yaml:"endpoint" mapstructure:"endpoint"`
+
+	// Headers are sent with each export request
+	Headers map[string]string `yaml:"headers,omitempty" mapstructure:"headers,omitempty"`
+}
+
+// Equal returns true if both connections are nil or have the same endpoint and headers
+func (t *TelemetryConnection) Equal(o *TelemetryConnection) bool {
+	if t == nil || o == nil {
+		return t == o
+	}
+	return t.Endpoint == o.Endpoint && maps.Equal(t.Headers, o.Headers)
+}
+
+func (t TelemetryConnection) copy() *TelemetryConnection {
+	return &TelemetryConnection{
+		Endpoint: t.Endpoint,
+		Headers:  maps.Clone(t.Headers),
+	}
+}
+
 // ToTLS converts the config to a tls.Config
 func (c Config) ToTLS(caCertPool *x509.CertPool) (*tls.Config, error) {
 	if c.TLS == nil {
@@ -282,6 +514,30 @@ func ParseConfig(configLocation string) (*Config, error) {
 		return nil, fmt.Errorf("%s: %w", errPrefixParse, err)
 	}
 
+	if config.Proxy != nil {
+		if _, err := config.Proxy.ProxyURL(); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.PackageDownload != nil {
+		if err := config.PackageDownload.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.UpdateHealthCheck != nil {
+		if err := config.UpdateHealthCheck.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, keyFile := range config.PackageSigningKeys {
+		if _, err := os.Stat(keyFile); err != nil {
+			return nil, fmt.Errorf("%s: %w", errInvalidPackageSigningKey, err)
+		}
+	}
+
 	// Using Secure TLS check files
 	if config.TLS != nil && !config.TLS.InsecureSkipVerify {
 		// If CA file is specified
@@ -316,6 +572,7 @@ func (c Config) Copy() *Config {
 	cfgCopy := &Config{
 		Endpoint:             c.Endpoint,
 		AgentID:              c.AgentID,
+		PollingInterval:      c.PollingInterval,
 		MeasurementsInterval: c.MeasurementsInterval,
 	}
 
@@ -334,6 +591,30 @@ func (c Config) Copy() *Config {
 	if c.TLS != nil {
 		cfgCopy.TLS = c.TLS.copy()
 	}
+	if c.Proxy != nil {
+		cfgCopy.Proxy = c.Proxy.copy()
+	}
+	if c.Headers != nil {
+		cfgCopy.Headers = maps.Clone(c.Headers)
+	}
+	if c.ConfigHealthCheck != nil {
+		cfgCopy.ConfigHealthCheck = c.ConfigHealthCheck.copy()
+	}
+	if c.OwnMetrics != nil {
+		cfgCopy.OwnMetrics = c.OwnMetrics.copy()
+	}
+	if c.OwnLogs != nil {
+		cfgCopy.OwnLogs = c.OwnLogs.copy()
+	}
+	if c.PackageDownload != nil {
+		cfgCopy.PackageDownload = c.PackageDownload.copy()
+	}
+	if c.PackageSigningKeys != nil {
+		cfgCopy.PackageSigningKeys = slices.Clone(c.PackageSigningKeys)
+	}
+	if c.UpdateHealthCheck != nil {
+		cfgCopy.UpdateHealthCheck = c.UpdateHealthCheck.copy()
+	}
 	if c.ExtraMeasurementsAttributes != nil {
 		cfgCopy.ExtraMeasurementsAttributes = maps.Clone(c.ExtraMeasurementsAttributes)
 	}
@@ -0,0 +1,250 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opamp
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigHistoryDirName is the name of the directory next to the manager config that config versions are kept in
+	ConfigHistoryDirName = "config_history"
+
+	// DefaultConfigHistorySize is how many versions of each config are kept
+	DefaultConfigHistorySize = 10
+
+	// configHistoryIndexName is the name of the file listing the kept versions
+	configHistoryIndexName = "index.yaml"
+)
+
+// ErrConfigVersionNotFound is returned when reverting to a version that isn't in the history
+var ErrConfigVersionNotFound = errors.New("config version not found")
+
+// ConfigVersion is a version of a config file kept in the history
+type ConfigVersion struct {
+	// ID identifies the version. IDs increase with each recorded version.
+	ID int `yaml:"id"`
+
+	// Name is the name of the config, such as collector.yaml
+	Name string `yaml:"name"`
+
+	// Path is where the config was applied
+	Path string `yaml:"path"`
+
+	// Hash is the hex encoded SHA-256 hash of the contents
+	Hash string `yaml:"hash"`
+
+	// Timestamp is when the version was recorded
+	Timestamp time.Time `yaml:"timestamp"`
+
+	// File is the name of the file in the history directory holding the contents
+	File string `yaml:"file"`
+}
+
+// configHistoryIndex is the index of the history directory
+type configHistoryIndex struct {
+	NextID   int             `yaml:"next_id"`
+	Versions []ConfigVersion `yaml:"versions"`
+}
+
+// ConfigHistory keeps the most recent versions of config files in a directory so they can be reverted to
+type ConfigHistory struct {
+	dir  string
+	size int
+	mux  sync.Mutex
+}
+
+// NewConfigHistory creates a ConfigHistory in dir that keeps size versions of each config.
+// The directory is created when the first version is recorded.
+func NewConfigHistory(dir string, size int) *ConfigHistory {
+	if size < 1 {
+		size = DefaultConfigHistorySize
+	}
+
+	return &ConfigHistory{
+		dir:  dir,
+		size: size,
+	}
+}
+
+// Record adds the contents of the config to the history, removing the config's oldest versions past the history size.
+// Nothing is recorded if the contents match the config's latest version, in which case that version is returned.
+func (h *ConfigHistory) Record(name, configPath string, contents []byte) (*ConfigVersion, error) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	index, err := h.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := hex.EncodeToString(ComputeHash(contents))
+	for i := len(index.Versions) - 1; i >= 0; i-- {
+		if index.Versions[i].Name != name {
+			continue
+		}
+		if index.Versions[i].Hash == hash {
+			latest := index.Versions[i]
+			return &latest, nil
+		}
+		break
+	}
+
+	if err := os.MkdirAll(h.dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create config history directory: %w", err)
+	}
+
+	index.NextID++
+	version := ConfigVersion{
+		ID:        index.NextID,
+		Name:      name,
+		Path:      configPath,
+		Hash:      hash,
+		Timestamp: time.Now().UTC(),
+		File:      fmt.Sprintf("%d-%s", index.NextID, filepath.Base(name)),
+	}
+
+	if err := os.WriteFile(filepath.Join(h.dir, version.File), contents, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write config version: %w", err)
+	}
+	index.Versions = append(index.Versions, version)
+
+	// Drop the config's oldest versions
+	var kept []ConfigVersion
+	count := 0
+	for i := len(index.Versions) - 1; i >= 0; i-- {
+		v := index.Versions[i]
+		if v.Name == name {
+			count++
+			if count > h.size {
+				if err := os.Remove(filepath.Join(h.dir, v.File)); err != nil && !errors.Is(err, os.ErrNotExist) {
+					return nil, fmt.Errorf("failed to remove old config version: %w", err)
+				}
+				continue
+			}
+		}
+		kept = append(kept, v)
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].ID < kept[j].ID })
+	index.Versions = kept
+
+	if err := h.writeIndex(index); err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// Versions returns the kept versions of all configs, newest first
+func (h *ConfigHistory) Versions() ([]ConfigVersion, error) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	index, err := h.readIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]ConfigVersion, 0, len(index.Versions))
+	for i := len(index.Versions) - 1; i >= 0; i-- {
+		versions = append(versions, index.Versions[i])
+	}
+	return versions, nil
+}
+
+// Revert writes the contents of the version with the ID back to the path it was applied to.
+// The revert is recorded as a new version of the config.
+func (h *ConfigHistory) Revert(id int) (*ConfigVersion, error) {
+	versions, err := h.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	var version *ConfigVersion
+	for i := range versions {
+		if versions[i].ID == id {
+			version = &versions[i]
+			break
+		}
+	}
+	if version == nil {
+		return nil, fmt.Errorf("%w: %d", ErrConfigVersionNotFound, id)
+	}
+
+	contents, err := os.ReadFile(filepath.Join(h.dir, version.File))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config version: %w", err)
+	}
+
+	if hash := hex.EncodeToString(ComputeHash(contents)); hash != version.Hash {
+		return nil, fmt.Errorf("config version %d does not match its hash", id)
+	}
+
+	if err := os.WriteFile(filepath.Clean(version.Path), contents, 0600); err != nil {
+		return nil, fmt.Errorf("failed to write config %s: %w", version.Name, err)
+	}
+
+	if _, err := h.Record(version.Name, version.Path, contents); err != nil {
+		return nil, fmt.Errorf("failed to record reverted config: %w", err)
+	}
+
+	return version, nil
+}
+
+// readIndex reads the history index, returning an empty index if there is no history yet
+func (h *ConfigHistory) readIndex() (*configHistoryIndex, error) {
+	index := &configHistoryIndex{}
+
+	contents, err := os.ReadFile(filepath.Join(h.dir, configHistoryIndexName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return index, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read config history: %w", err)
+	}
+
+	if err := yaml.Unmarshal(contents, index); err != nil {
+		return nil, fmt.Errorf("failed to parse config history: %w", err)
+	}
+	return index, nil
+}
+
+// writeIndex replaces the history index
+func (h *ConfigHistory) writeIndex(index *configHistoryIndex) error {
+	contents, err := yaml.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config history: %w", err)
+	}
+
+	// Write then rename so an interrupted write doesn't lose the history
+	indexPath := filepath.Join(h.dir, configHistoryIndexName)
+	tmpPath := indexPath + ".tmp"
+	if err := os.WriteFile(tmpPath, contents, 0600); err != nil {
+		return fmt.Errorf("failed to write config history: %w", err)
+	}
+	if err := os.Rename(tmpPath, indexPath); err != nil {
+		return fmt.Errorf("failed to write config history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opamp
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigHistoryRecord(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), ConfigHistoryDirName)
+	history := NewConfigHistory(dir, 2)
+
+	// No history yet
+	versions, err := history.Versions()
+	require.NoError(t, err)
+	require.Empty(t, versions)
+
+	v1, err := history.Record("collector.yaml", "/etc/config.yaml", []byte("one"))
+	require.NoError(t, err)
+	require.Equal(t, 1, v1.ID)
+	require.Equal(t, "collector.yaml", v1.Name)
+	require.Equal(t, "/etc/config.yaml", v1.Path)
+	require.Equal(t, hex.EncodeToString(ComputeHash([]byte("one"))), v1.Hash)
+	require.False(t, v1.Timestamp.IsZero())
+
+	// Recording the latest contents again is a noop
+	same, err := history.Record("collector.yaml", "/etc/config.yaml", []byte("one"))
+	require.NoError(t, err)
+	require.Equal(t, v1, same)
+
+	_, err = history.Record("logging.yaml", "/etc/logging.yaml", []byte("logging"))
+	require.NoError(t, err)
+	_, err = history.Record("collector.yaml", "/etc/config.yaml", []byte("two"))
+	require.NoError(t, err)
+	v4, err := history.Record("collector.yaml", "/etc/config.yaml", []byte("three"))
+	require.NoError(t, err)
+	require.Equal(t, 4, v4.ID)
+
+	// Only the last two versions of the collector config are kept, newest first
+	versions, err = history.Versions()
+	require.NoError(t, err)
+	ids := []int{}
+	for _, v := range versions {
+		ids = append(ids, v.ID)
+	}
+	require.Equal(t, []int{4, 3, 2}, ids)
+	require.NoFileExists(t, filepath.Join(dir, v1.File))
+
+	contents, err := os.ReadFile(filepath.Join(dir, v4.File))
+	require.NoError(t, err)
+	require.Equal(t, "three", string(contents))
+
+	// IDs keep increasing after versions are removed
+	reopened := NewConfigHistory(dir, 2)
+	v5, err := reopened.Record("manager.yaml", "/etc/manager.yaml", []byte("manager"))
+	require.NoError(t, err)
+	require.Equal(t, 5, v5.ID)
+}
+
+func TestConfigHistoryRevert(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	history := NewConfigHistory(filepath.Join(tmpDir, ConfigHistoryDirName), DefaultConfigHistorySize)
+
+	good, err := history.Record("collector.yaml", configPath, []byte("good"))
+	require.NoError(t, err)
+	_, err = history.Record("collector.yaml", configPath, []byte("bad"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configPath, []byte("bad"), 0600))
+
+	reverted, err := history.Revert(good.ID)
+	require.NoError(t, err)
+	require.Equal(t, good.ID, reverted.ID)
+
+	contents, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	require.Equal(t, "good", string(contents))
+
+	// The revert is the config's latest version
+	versions, err := history.Versions()
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	require.Equal(t, good.Hash, versions[0].Hash)
+
+	t.Run("Unknown version", func(t *testing.T) {
+		_, err := history.Revert(99)
+		require.ErrorIs(t, err, ErrConfigVersionNotFound)
+	})
+
+	t.Run("Modified version", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ConfigHistoryDirName, good.File), []byte("tampered"), 0600))
+		_, err := history.Revert(good.ID)
+		require.EqualError(t, err, "config version 1 does not match its hash")
+	})
+}
@@ -24,7 +24,7 @@ import (
 //go:generate mockery --name DownloadableFileManager --filename mock_downloadable_file_manager.go --structname MockDownloadableFileManager
 type DownloadableFileManager interface {
 	// FetchAndExtractArchive fetches the archive at the specified URL.
-	// It then checks to see if it matches the expected sha256 sum of the file.
+	// It then checks to see if it matches the expected sha256 sum of the file and has a valid signature.
 	// If it matches, the archive is extracted.
 	// If the archive cannot be extracted, downloaded, or verified, then an error is returned.
 	FetchAndExtractArchive(*protobufs.DownloadableFile) error
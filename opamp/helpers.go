@@ -30,6 +30,33 @@ func StringKeyValue(key, value string) *protobufs.KeyValue {
 	}
 }
 
+// IntKeyValue converts an integer key-value pair into a protobuf.KeyValue struct
+func IntKeyValue(key string, value int64) *protobufs.KeyValue {
+	return &protobufs.KeyValue{
+		Key: key,
+		Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_IntValue{IntValue: value},
+		},
+	}
+}
+
+// StringSliceKeyValue converts a key and list of strings into a protobuf.KeyValue struct with an array value
+func StringSliceKeyValue(key string, values []string) *protobufs.KeyValue {
+	arrayValues := make([]*protobufs.AnyValue, 0, len(values))
+	for _, value := range values {
+		arrayValues = append(arrayValues, &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_StringValue{StringValue: value},
+		})
+	}
+
+	return &protobufs.KeyValue{
+		Key: key,
+		Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_ArrayValue{ArrayValue: &protobufs.ArrayValue{Values: arrayValues}},
+		},
+	}
+}
+
 // ComputeHash computes a sha256 hash of the passed in data
 func ComputeHash(data []byte) []byte {
 	hash := sha256.New()
@@ -34,6 +34,33 @@ func TestStringKeyValue(t *testing.T) {
 	require.Equal(t, expected, actual)
 }
 
+func TestIntKeyValue(t *testing.T) {
+	expected := &protobufs.KeyValue{
+		Key: "key",
+		Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_IntValue{IntValue: 8},
+		},
+	}
+
+	actual := IntKeyValue("key", 8)
+	require.Equal(t, expected, actual)
+}
+
+func TestStringSliceKeyValue(t *testing.T) {
+	expected := &protobufs.KeyValue{
+		Key: "key",
+		Value: &protobufs.AnyValue{
+			Value: &protobufs.AnyValue_ArrayValue{ArrayValue: &protobufs.ArrayValue{Values: []*protobufs.AnyValue{
+				{Value: &protobufs.AnyValue_StringValue{StringValue: "one"}},
+				{Value: &protobufs.AnyValue_StringValue{StringValue: "two"}},
+			}}},
+		},
+	}
+
+	actual := StringSliceKeyValue("key", []string{"one", "two"})
+	require.Equal(t, expected, actual)
+}
+
 func TestComputeHash(t *testing.T) {
 	expected := []byte{0xc2, 0xae, 0xcc, 0xc4, 0x2d, 0x2a, 0x57, 0x9c, 0x28, 0x1d, 0xaa, 0xe7, 0xe4, 0x64, 0xa1, 0x4d, 0x74, 0x79, 0x24, 0x15, 0x9e, 0x28, 0x61, 0x7a, 0xd0, 0x18, 0x50, 0xf0, 0xdd, 0x1b, 0xd1, 0x35}
 	actual := ComputeHash([]byte("hellow world"))
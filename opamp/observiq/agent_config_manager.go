@@ -51,6 +51,9 @@ var _ opamp.ConfigManager = (*AgentConfigManager)(nil)
 type AgentConfigManager struct {
 	configMap map[string]*opamp.ManagedConfig
 	logger    *zap.Logger
+
+	// history keeps the applied versions of configs. Nil if not kept.
+	history *opamp.ConfigHistory
 }
 
 // NewAgentConfigManager creates a new AgentConfigManager
@@ -67,6 +70,11 @@ func (a *AgentConfigManager) AddConfig(configName string, managedConfig *opamp.M
 	a.configMap[configName] = managedConfig
 }
 
+// SetConfigHistory sets the history applied configs are recorded in
+func (a *AgentConfigManager) SetConfigHistory(history *opamp.ConfigHistory) {
+	a.history = history
+}
+
 // ComposeEffectiveConfig reads in all config files and calculates the effective config
 func (a *AgentConfigManager) ComposeEffectiveConfig() (*protobufs.EffectiveConfig, error) {
 	contentMap := make(map[string]*protobufs.AgentConfigFile, len(a.configMap))
@@ -159,6 +167,10 @@ func (a *AgentConfigManager) updateExistingConfig(configName string, managedConf
 	}
 
 	a.logger.Info("Applying changes to config file", zap.String("config", configName))
+
+	// Keep the version being replaced so it can be reverted to, even if it was never applied remotely
+	a.recordHistory(configName, managedConfig.ConfigPath)
+
 	changed, err = managedConfig.Reload(newContents)
 	if err != nil {
 		err = fmt.Errorf("failed to reload config: %s: %w", configName, err)
@@ -172,11 +184,33 @@ func (a *AgentConfigManager) updateExistingConfig(configName string, managedConf
 			err = fmt.Errorf("failed hash compute for config %s: %w", configName, err)
 			return
 		}
+
+		a.recordHistory(configName, managedConfig.ConfigPath)
 	}
 
 	return
 }
 
+// recordHistory records the config on disk in the history. Failures are logged as the history isn't required to apply configs.
+func (a *AgentConfigManager) recordHistory(configName, configPath string) {
+	if a.history == nil {
+		return
+	}
+
+	contents, err := os.ReadFile(filepath.Clean(configPath))
+	if err != nil {
+		a.logger.Warn("Failed to read config for history", zap.String("config", configName), zap.Error(err))
+		return
+	}
+
+	version, err := a.history.Record(configName, configPath, contents)
+	if err != nil {
+		a.logger.Warn("Failed to record config history", zap.String("config", configName), zap.Error(err))
+		return
+	}
+	a.logger.Debug("Recorded config version", zap.String("config", configName), zap.Int("version", version.ID))
+}
+
 // verifyDiskContents verifies the contents saved on disk match the in memory hash.
 // If not overwrite them with the passed in contents
 func verifyDiskContents(configPath string, memHash, contents []byte) (changed bool, err error) {
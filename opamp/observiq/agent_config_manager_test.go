@@ -15,6 +15,7 @@
 package observiq
 
 import (
+	"encoding/hex"
 	"errors"
 	"os"
 	"path/filepath"
@@ -566,6 +567,53 @@ func TestApplyConfigChanges(t *testing.T) {
 				assert.Equal(t, expectedEffCfg, effCfg)
 			},
 		},
+		{
+			desc: "Remote config contains changes to file, history recorded",
+			testFunc: func(*testing.T) {
+				tmpDir := t.TempDir()
+				configPath := filepath.Join(tmpDir, LoggingConfigName)
+				configContents := []byte(`key: value`)
+				newFileContents := []byte(`logger: value`)
+
+				err := os.WriteFile(configPath, configContents, 0600)
+				assert.NoError(t, err)
+
+				history := opamp.NewConfigHistory(filepath.Join(tmpDir, opamp.ConfigHistoryDirName), opamp.DefaultConfigHistorySize)
+				manager := NewAgentConfigManager(zap.NewNop())
+				manager.SetConfigHistory(history)
+				mangedConfig, err := opamp.NewManagedConfig(configPath, func(data []byte) (changed bool, err error) {
+					err = os.WriteFile(configPath, data, 0600)
+					assert.NoError(t, err)
+					return true, err
+				}, true)
+				assert.NoError(t, err)
+				manager.AddConfig(LoggingConfigName, mangedConfig)
+
+				remoteConfig := &protobufs.AgentRemoteConfig{
+					Config: &protobufs.AgentConfigMap{
+						ConfigMap: map[string]*protobufs.AgentConfigFile{
+							LoggingConfigName: {
+								Body:        newFileContents,
+								ContentType: opamp.YAMLContentType,
+							},
+						},
+					},
+				}
+
+				changed, err := manager.ApplyConfigChanges(remoteConfig)
+				assert.NoError(t, err)
+				assert.True(t, changed)
+
+				// Both the replaced and applied versions are kept
+				versions, err := history.Versions()
+				assert.NoError(t, err)
+				require.Len(t, versions, 2)
+				assert.Equal(t, hex.EncodeToString(opamp.ComputeHash(newFileContents)), versions[0].Hash)
+				assert.Equal(t, hex.EncodeToString(opamp.ComputeHash(configContents)), versions[1].Hash)
+				assert.Equal(t, LoggingConfigName, versions[0].Name)
+				assert.Equal(t, configPath, versions[0].Path)
+			},
+		},
 	}
 
 	for _, tc := range testCases {
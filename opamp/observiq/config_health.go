@@ -0,0 +1,222 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/collector"
+	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultTelemetryEndpoint is the default address of the collector's Prometheus telemetry
+	defaultTelemetryEndpoint = "http://localhost:8888/metrics"
+
+	// healthCheckInterval is how often component health is checked during the health window
+	healthCheckInterval = time.Second
+
+	// exporterSentPrefix and exporterFailedPrefix prefix the collector's exporter telemetry counters
+	exporterSentPrefix   = "otelcol_exporter_sent_"
+	exporterFailedPrefix = "otelcol_exporter_send_failed_"
+)
+
+// configHealthChecker watches the collector for a window of time after a config is applied
+type configHealthChecker struct {
+	logger               *zap.Logger
+	collector            collector.Collector
+	window               time.Duration
+	interval             time.Duration
+	maxExportFailureRate float64
+	metricsEndpoint      string
+	httpClient           *http.Client
+}
+
+// newConfigHealthChecker creates a configHealthChecker from the config.
+// Returns nil if the health check is not enabled.
+func newConfigHealthChecker(logger *zap.Logger, col collector.Collector, cfg *opamp.ConfigHealthCheck) *configHealthChecker {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	metricsEndpoint := cfg.MetricsEndpoint
+	if metricsEndpoint == "" {
+		metricsEndpoint = defaultTelemetryEndpoint
+	}
+
+	return &configHealthChecker{
+		logger:               logger,
+		collector:            col,
+		window:               cfg.Window,
+		interval:             healthCheckInterval,
+		maxExportFailureRate: cfg.MaxExportFailureRate,
+		metricsEndpoint:      metricsEndpoint,
+		httpClient:           &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// exportCounts are the totals of the collector's exporter telemetry counters
+type exportCounts struct {
+	sent   float64
+	failed float64
+}
+
+// Check watches the collector until the window ends and returns an error describing why the collector is unhealthy.
+// The collector is unhealthy if it stops, a component reports a permanent or fatal error,
+// a component is still in a recoverable error state at the end of the window or
+// exporters fail to send more than the allowed fraction of items.
+// If the export failure rate is checked, the collector's telemetry must be reachable for the config to be healthy.
+func (h *configHealthChecker) Check(ctx context.Context) error {
+	var baseline *exportCounts
+	if h.maxExportFailureRate > 0 {
+		counts, err := h.scrapeExportCounts(ctx)
+		if err != nil {
+			return fmt.Errorf("export failure rate can't be checked: %w", err)
+		}
+		baseline = counts
+	}
+
+	statusChan := h.collector.Status()
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	windowTimer := time.NewTimer(h.window)
+	defer windowTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case status := <-statusChan:
+			if err := checkCollectorStatus(status); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if err := h.checkComponents(false); err != nil {
+				return err
+			}
+		case <-windowTimer.C:
+			if err := h.checkComponents(true); err != nil {
+				return err
+			}
+			if baseline != nil {
+				return h.checkExportFailureRate(ctx, baseline)
+			}
+			return nil
+		}
+	}
+}
+
+// checkCollectorStatus returns an error if the status shows the collector is no longer running
+func checkCollectorStatus(status *collector.Status) error {
+	switch {
+	case status == nil:
+		return nil
+	case status.Panicked:
+		return fmt.Errorf("collector panicked: %w", status.Err)
+	case status.Err != nil:
+		return fmt.Errorf("collector stopped: %w", status.Err)
+	case !status.Running:
+		return errors.New("collector stopped")
+	default:
+		return nil
+	}
+}
+
+// checkComponents returns an error for the first failing component found.
+// Recoverable errors are only reported at the end of the window, to give components a chance to recover.
+func (h *configHealthChecker) checkComponents(endOfWindow bool) error {
+	for key, health := range h.collector.ComponentHealth() {
+		switch health.Status {
+		case componentstatus.StatusPermanentError, componentstatus.StatusFatalError:
+			return fmt.Errorf("component %s failed: %w", key, health.LastError)
+		case componentstatus.StatusRecoverableError:
+			if endOfWindow {
+				return fmt.Errorf("component %s did not recover: %w", key, health.LastError)
+			}
+		}
+	}
+	return nil
+}
+
+// checkExportFailureRate returns an error if exporters failed to send more than the allowed fraction of items since baseline
+func (h *configHealthChecker) checkExportFailureRate(ctx context.Context, baseline *exportCounts) error {
+	counts, err := h.scrapeExportCounts(ctx)
+	if err != nil {
+		return fmt.Errorf("export failure rate can't be checked: %w", err)
+	}
+
+	sent := counts.sent - baseline.sent
+	failed := counts.failed - baseline.failed
+	if sent+failed <= 0 {
+		return nil
+	}
+
+	rate := failed / (sent + failed)
+	if rate > h.maxExportFailureRate {
+		return fmt.Errorf("export failure rate %.2f exceeds %.2f", rate, h.maxExportFailureRate)
+	}
+	return nil
+}
+
+// scrapeExportCounts sums the exporter sent and send failed counters from the collector's telemetry
+func (h *configHealthChecker) scrapeExportCounts(ctx context.Context) (*exportCounts, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.metricsEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create telemetry request: %w", err)
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scrape collector telemetry: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scrape collector telemetry: unexpected status %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse collector telemetry: %w", err)
+	}
+
+	counts := &exportCounts{}
+	for name, family := range families {
+		var total *float64
+		switch {
+		case strings.HasPrefix(name, exporterSentPrefix):
+			total = &counts.sent
+		case strings.HasPrefix(name, exporterFailedPrefix):
+			total = &counts.failed
+		default:
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			*total += metric.GetCounter().GetValue()
+		}
+	}
+
+	return counts, nil
+}
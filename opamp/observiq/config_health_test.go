@@ -0,0 +1,189 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/collector"
+	colmocks "github.com/observiq/bindplane-otel-collector/collector/mocks"
+	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.uber.org/zap"
+)
+
+func TestNewConfigHealthChecker(t *testing.T) {
+	col := colmocks.NewMockCollector(t)
+
+	require.Nil(t, newConfigHealthChecker(zap.NewNop(), col, nil))
+	require.Nil(t, newConfigHealthChecker(zap.NewNop(), col, &opamp.ConfigHealthCheck{}))
+
+	checker := newConfigHealthChecker(zap.NewNop(), col, &opamp.ConfigHealthCheck{Window: time.Minute})
+	require.Equal(t, time.Minute, checker.window)
+	require.Equal(t, defaultTelemetryEndpoint, checker.metricsEndpoint)
+
+	checker = newConfigHealthChecker(zap.NewNop(), col, &opamp.ConfigHealthCheck{Window: time.Minute, MetricsEndpoint: "http://localhost:9999/metrics"})
+	require.Equal(t, "http://localhost:9999/metrics", checker.metricsEndpoint)
+}
+
+func TestConfigHealthCheckerCheck(t *testing.T) {
+	healthy := map[string]*collector.ComponentHealth{
+		"receiver:otlp": {Status: componentstatus.StatusOK},
+	}
+
+	testCases := []struct {
+		name        string
+		statuses    []*collector.Status
+		health      []map[string]*collector.ComponentHealth
+		expectedErr string
+	}{
+		{
+			name:     "healthy",
+			statuses: []*collector.Status{{Running: true}},
+			health:   []map[string]*collector.ComponentHealth{healthy},
+		},
+		{
+			name:        "collector stopped",
+			statuses:    []*collector.Status{{Running: true}, {Running: false, Err: errors.New("exporter failed")}},
+			health:      []map[string]*collector.ComponentHealth{healthy},
+			expectedErr: "collector stopped: exporter failed",
+		},
+		{
+			name:        "collector panicked",
+			statuses:    []*collector.Status{{Panicked: true, Err: errors.New("nil pointer")}},
+			health:      []map[string]*collector.ComponentHealth{healthy},
+			expectedErr: "collector panicked: nil pointer",
+		},
+		{
+			name: "permanent error",
+			health: []map[string]*collector.ComponentHealth{
+				{"receiver:otlp": {Status: componentstatus.StatusPermanentError, LastError: errors.New("address in use")}},
+			},
+			expectedErr: "component receiver:otlp failed: address in use",
+		},
+		{
+			name: "recoverable error that recovers",
+			health: []map[string]*collector.ComponentHealth{
+				{"exporter:otlp": {Status: componentstatus.StatusRecoverableError, LastError: errors.New("connection refused")}},
+				healthy,
+			},
+		},
+		{
+			name: "recoverable error that does not recover",
+			health: []map[string]*collector.ComponentHealth{
+				{"exporter:otlp": {Status: componentstatus.StatusRecoverableError, LastError: errors.New("connection refused")}},
+			},
+			expectedErr: "component exporter:otlp did not recover: connection refused",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			statusChan := make(chan *collector.Status, len(tc.statuses))
+			for _, status := range tc.statuses {
+				statusChan <- status
+			}
+
+			// Each health check returns the next health, repeating the last one
+			var calls atomic.Int64
+			col := colmocks.NewMockCollector(t)
+			col.On("Status").Return((<-chan *collector.Status)(statusChan))
+			col.On("ComponentHealth").Return(func() map[string]*collector.ComponentHealth {
+				i := int(calls.Add(1)) - 1
+				if i >= len(tc.health) {
+					i = len(tc.health) - 1
+				}
+				return tc.health[i]
+			}).Maybe()
+
+			checker := newConfigHealthChecker(zap.NewNop(), col, &opamp.ConfigHealthCheck{Window: 200 * time.Millisecond})
+			checker.interval = 20 * time.Millisecond
+
+			err := checker.Check(context.Background())
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigHealthCheckerExportFailureRate(t *testing.T) {
+	// Each scrape returns the next set of counters
+	scrapes := []struct{ sent, failed int }{
+		{sent: 100, failed: 0},
+		{sent: 110, failed: 90},
+	}
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		scrape := scrapes[min(int(calls.Add(1))-1, len(scrapes)-1)]
+		fmt.Fprintf(w, "# TYPE otelcol_exporter_sent_log_records counter\n")
+		fmt.Fprintf(w, "otelcol_exporter_sent_log_records{exporter=\"otlp\"} %d\n", scrape.sent)
+		fmt.Fprintf(w, "# TYPE otelcol_exporter_send_failed_log_records counter\n")
+		fmt.Fprintf(w, "otelcol_exporter_send_failed_log_records{exporter=\"otlp\"} %d\n", scrape.failed)
+		fmt.Fprintf(w, "# TYPE otelcol_process_uptime counter\n")
+		fmt.Fprintf(w, "otelcol_process_uptime 1000\n")
+	}))
+	defer server.Close()
+
+	newChecker := func(endpoint string, maxRate float64) *configHealthChecker {
+		col := colmocks.NewMockCollector(t)
+		col.On("Status").Return((<-chan *collector.Status)(make(chan *collector.Status))).Maybe()
+		col.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{}).Maybe()
+
+		checker := newConfigHealthChecker(zap.NewNop(), col, &opamp.ConfigHealthCheck{
+			Window:               50 * time.Millisecond,
+			MaxExportFailureRate: maxRate,
+			MetricsEndpoint:      endpoint,
+		})
+		checker.interval = 10 * time.Millisecond
+		return checker
+	}
+
+	// 90 of the 100 items exported during the window failed
+	err := newChecker(server.URL, 0.5).Check(context.Background())
+	require.EqualError(t, err, "export failure rate 0.90 exceeds 0.50")
+
+	calls.Store(0)
+	require.NoError(t, newChecker(server.URL, 0.95).Check(context.Background()))
+
+	// The config isn't healthy when the telemetry can't be scraped
+	server.Close()
+	err = newChecker(server.URL, 0.5).Check(context.Background())
+	require.ErrorContains(t, err, "export failure rate can't be checked: scrape collector telemetry")
+}
+
+func TestConfigHealthCheckerContextCanceled(t *testing.T) {
+	col := colmocks.NewMockCollector(t)
+	col.On("Status").Return((<-chan *collector.Status)(make(chan *collector.Status)))
+	col.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{}).Maybe()
+
+	checker := newConfigHealthChecker(zap.NewNop(), col, &opamp.ConfigHealthCheck{Window: time.Minute})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, checker.Check(ctx), context.Canceled)
+	col.AssertNotCalled(t, "Restart", mock.Anything)
+}
@@ -0,0 +1,393 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/observiq/bindplane-otel-collector/collector"
+	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// secretKeyPrefix prefixes the secret key in the Authorization header
+const secretKeyPrefix = "Secret-Key "
+
+// connectionVerifyTimeout is how long to wait for the server to accept a connection with offered settings
+var connectionVerifyTimeout = 10 * time.Second
+
+// verifyOpAMPConnection checks that the server accepts a websocket connection with the header, TLS config and proxy
+var verifyOpAMPConnection = func(ctx context.Context, endpoint string, header http.Header, tlsCfg *tls.Config, proxy func(*http.Request) (*url.URL, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, connectionVerifyTimeout)
+	defer cancel()
+
+	dialer := websocket.Dialer{
+		Proxy:           proxy,
+		TLSClientConfig: tlsCfg,
+	}
+
+	conn, resp, err := dialer.DialContext(ctx, endpoint, header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("server responded with %s: %w", resp.Status, err)
+		}
+		return err
+	}
+
+	return conn.Close()
+}
+
+// onOpampConnectionSettingsHandler verifies the offered connection settings, persists them to the manager config,
+// and reconnects with them. The previous settings are restored if the reconnect fails.
+func (c *Client) onOpampConnectionSettingsHandler(ctx context.Context, settings *protobufs.OpAMPConnectionSettings) error {
+	c.logger.Info("Received OpAMP connection settings offer")
+
+	newConfig, writtenFiles, err := c.offeredConnectionConfig(settings)
+	removeWrittenFiles := func() {
+		for _, f := range writtenFiles {
+			if err := os.Remove(f); err != nil {
+				c.logger.Warn("Failed to remove offered certificate file", zap.String("file", f), zap.Error(err))
+			}
+		}
+	}
+	if err != nil {
+		removeWrittenFiles()
+		return fmt.Errorf("invalid connection settings: %w", err)
+	}
+
+	currentConfig := c.safeGetCurrentConfig()
+	if sameConnection(currentConfig, *newConfig) {
+		c.logger.Debug("Offered connection settings match the current settings")
+		return nil
+	}
+
+	// Make sure the server accepts the new settings before committing to them
+	tlsCfg, err := newConfig.ToTLS(nil)
+	if err != nil {
+		removeWrittenFiles()
+		return fmt.Errorf("failed creating TLS config: %w", err)
+	}
+	proxy, err := serverProxy(*newConfig)
+	if err != nil {
+		removeWrittenFiles()
+		return fmt.Errorf("failed configuring proxy: %w", err)
+	}
+	if err := verifyOpAMPConnection(ctx, newConfig.Endpoint, c.connectionHeader(*newConfig), tlsCfg, proxy); err != nil {
+		removeWrittenFiles()
+		return fmt.Errorf("failed to verify connection settings: %w", err)
+	}
+
+	if err := c.persistManagerConfig(*newConfig); err != nil {
+		removeWrittenFiles()
+		return err
+	}
+
+	// The client can't be stopped from within its own callback so reconnect once this returns
+	go c.reconnect(currentConfig)
+
+	return nil
+}
+
+// offeredConnectionConfig returns a copy of the current config with the offered connection settings applied.
+// Offered certificates are written next to the manager config and returned so they can be removed on failure.
+func (c *Client) offeredConnectionConfig(settings *protobufs.OpAMPConnectionSettings) (*opamp.Config, []string, error) {
+	currentConfig := c.safeGetCurrentConfig()
+	newConfig := &currentConfig
+
+	if endpoint := settings.GetDestinationEndpoint(); endpoint != "" {
+		newConfig.Endpoint = endpoint
+	}
+
+	// Offered settings are verified with a websocket connection, so offers aren't accepted while polling over HTTP
+	opampURL, err := url.Parse(newConfig.Endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opampURL.Scheme != "ws" && opampURL.Scheme != "wss" {
+		return nil, nil, ErrUnsupportedURL
+	}
+
+	for _, header := range settings.GetHeaders().GetHeaders() {
+		if strings.EqualFold(header.GetKey(), "Authorization") && strings.HasPrefix(header.GetValue(), secretKeyPrefix) {
+			secretKey := strings.TrimPrefix(header.GetValue(), secretKeyPrefix)
+			newConfig.SecretKey = &secretKey
+			continue
+		}
+		c.logger.Warn("Ignoring unsupported header in connection settings offer", zap.String("header", header.GetKey()))
+	}
+
+	var writtenFiles []string
+	if cert := settings.GetCertificate(); cert != nil {
+		certFile, keyFile, written, err := writeClientCertificate(filepath.Dir(c.managerConfigPath), cert)
+		if err != nil {
+			return nil, written, err
+		}
+		writtenFiles = written
+
+		if newConfig.TLS == nil {
+			newConfig.TLS = &opamp.TLSConfig{}
+		}
+		newConfig.TLS.CertFile = &certFile
+		newConfig.TLS.KeyFile = &keyFile
+	}
+
+	return newConfig, writtenFiles, nil
+}
+
+// writeClientCertificate writes the certificate and key to dir, named by the certificate's hash so
+// the files in use by the current config are never overwritten. Newly created files are returned.
+func writeClientCertificate(dir string, cert *protobufs.TLSCertificate) (certFile, keyFile string, written []string, err error) {
+	if _, err := tls.X509KeyPair(cert.GetCert(), cert.GetPrivateKey()); err != nil {
+		return "", "", nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+
+	hash := sha256.Sum256(cert.GetCert())
+	name := "opamp-client-" + hex.EncodeToString(hash[:])[:12]
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+
+	for path, contents := range map[string][]byte{certFile: cert.GetCert(), keyFile: cert.GetPrivateKey()} {
+		if _, statErr := os.Stat(path); statErr == nil {
+			continue
+		}
+		if err := os.WriteFile(path, contents, 0600); err != nil {
+			return "", "", written, fmt.Errorf("failed to write client certificate: %w", err)
+		}
+		written = append(written, path)
+	}
+
+	return certFile, keyFile, written, nil
+}
+
+// sameConnection returns true if both configs connect to the same endpoint with the same credentials
+func sameConnection(a, b opamp.Config) bool {
+	if a.Endpoint != b.Endpoint || a.GetSecretKey() != b.GetSecretKey() {
+		return false
+	}
+
+	var aCert, aKey, bCert, bKey *string
+	if a.TLS != nil {
+		aCert, aKey = a.TLS.CertFile, a.TLS.KeyFile
+	}
+	if b.TLS != nil {
+		bCert, bKey = b.TLS.CertFile, b.TLS.KeyFile
+	}
+	return opamp.CmpStringPtr(aCert, bCert) && opamp.CmpStringPtr(aKey, bKey)
+}
+
+// reconnect replaces the OpAMP client with one using the current config.
+// If it can't be started, the previous config is restored and reconnected with.
+func (c *Client) reconnect(previous opamp.Config) {
+	ctx := context.Background()
+	c.logger.Info("Reconnecting to server with new connection settings", zap.String("endpoint", c.safeGetCurrentConfig().Endpoint))
+
+	// Senders are restarted once the server reports its custom capabilities on the new connection
+	c.measurementsSender.Stop()
+	c.topologySender.Stop()
+
+	if err := c.safeGetOpAMPClient().Stop(ctx); err != nil {
+		c.logger.Warn("Failed to stop OpAMP client", zap.Error(err))
+	}
+
+	if err := c.startOpAMPClient(ctx); err != nil {
+		c.logger.Error("Failed to connect with new connection settings, rolling back", zap.Error(err))
+
+		if err := c.persistManagerConfig(previous); err != nil {
+			c.logger.Error("Rollback failed for manager config", zap.Error(err))
+		}
+
+		if err := c.startOpAMPClient(ctx); err != nil {
+			c.logger.Error("Failed to reconnect with previous connection settings", zap.Error(err))
+		}
+	}
+}
+
+// startOpAMPClient creates a new OpAMP client for the current config and starts it
+func (c *Client) startOpAMPClient(ctx context.Context) error {
+	cfg := c.safeGetCurrentConfig()
+	opampURL, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	opampClient, err := newOpAMPClient(c.logger, opampURL, cfg.PollingInterval)
+	if err != nil {
+		return err
+	}
+
+	c.identMux.Lock()
+	agentDesc := c.ident.ToAgentDescription()
+	c.identMux.Unlock()
+	if err := opampClient.SetAgentDescription(agentDesc); err != nil {
+		return fmt.Errorf("failed to set agent description: %w", err)
+	}
+
+	// Health must be set before starting the client as it reports health
+	c.healthMux.Lock()
+	health := c.lastHealth
+	c.healthMux.Unlock()
+	if health == nil {
+		health = collectorHealth(c.collector.ComponentHealth(), nil)
+	}
+	if err := opampClient.SetHealth(health); err != nil {
+		return fmt.Errorf("failed to set health: %w", err)
+	}
+
+	settings, err := c.startSettings(cfg)
+	if err != nil {
+		return fmt.Errorf("failed creating TLS config: %w", err)
+	}
+
+	if err := configureTransport(&settings, cfg); err != nil {
+		return fmt.Errorf("failed configuring proxy: %w", err)
+	}
+
+	c.safeSetOpAMPClient(opampClient)
+	c.measurementsSender.SetOpAMPClient(opampClient)
+	c.topologySender.SetOpAMPClient(opampClient)
+	c.diagnosticsHandler.SetOpAMPClient(opampClient)
+
+	return opampClient.Start(ctx, settings)
+}
+
+// onOwnTelemetryConnectionSettings persists the offered destinations for the collector's own telemetry
+// and restarts the collector to use them. An offer without an endpoint stops sending that signal.
+func (c *Client) onOwnTelemetryConnectionSettings(metrics, logs *protobufs.TelemetryConnectionSettings) error {
+	currentConfig := c.safeGetCurrentConfig()
+	newConfig := currentConfig.Copy()
+	if metrics != nil {
+		newConfig.OwnMetrics = c.toTelemetryConnection(metrics)
+	}
+	if logs != nil {
+		newConfig.OwnLogs = c.toTelemetryConnection(logs)
+	}
+
+	if newConfig.OwnMetrics.Equal(currentConfig.OwnMetrics) && newConfig.OwnLogs.Equal(currentConfig.OwnLogs) {
+		return nil
+	}
+
+	c.logger.Info("Own telemetry connection settings update detected")
+	rollbackCfg := &currentConfig
+	if err := c.persistManagerConfig(*newConfig); err != nil {
+		return err
+	}
+
+	// Stop collector monitoring as we are going to restart it
+	c.stopCollectorMonitoring()
+	defer c.startCollectorMonitoring(context.Background())
+
+	c.collector.SetOwnTelemetry(toTelemetryDestination(newConfig.OwnMetrics), toTelemetryDestination(newConfig.OwnLogs))
+	if err := c.collector.Restart(context.Background()); err != nil {
+		c.logger.Info("OTEL Collector restart error", zap.Error(err))
+
+		if rollbackErr := c.persistManagerConfig(*rollbackCfg); rollbackErr != nil {
+			c.logger.Error("Rollback failed for manager config", zap.Error(rollbackErr))
+		}
+
+		// Restart collector with original destinations
+		c.collector.SetOwnTelemetry(toTelemetryDestination(rollbackCfg.OwnMetrics), toTelemetryDestination(rollbackCfg.OwnLogs))
+		if rollbackErr := c.collector.Restart(context.Background()); rollbackErr != nil {
+			c.logger.Error("Collector failed for restart during rollback", zap.Error(rollbackErr))
+		}
+
+		return fmt.Errorf("collector failed to restart: %w", err)
+	}
+
+	return nil
+}
+
+// toTelemetryConnection converts an offer to the manager config's representation. Nil is returned if there is no endpoint.
+func (c *Client) toTelemetryConnection(settings *protobufs.TelemetryConnectionSettings) *opamp.TelemetryConnection {
+	if settings.GetDestinationEndpoint() == "" {
+		return nil
+	}
+
+	if settings.GetCertificate() != nil {
+		c.logger.Warn("Ignoring client certificate in own telemetry connection settings offer")
+	}
+
+	conn := &opamp.TelemetryConnection{
+		Endpoint: settings.GetDestinationEndpoint(),
+	}
+	for _, header := range settings.GetHeaders().GetHeaders() {
+		if conn.Headers == nil {
+			conn.Headers = map[string]string{}
+		}
+		conn.Headers[header.GetKey()] = header.GetValue()
+	}
+
+	return conn
+}
+
+// toTelemetryDestination converts the manager config's representation to the collector's
+func toTelemetryDestination(conn *opamp.TelemetryConnection) *collector.TelemetryDestination {
+	if conn == nil {
+		return nil
+	}
+	return &collector.TelemetryDestination{
+		Endpoint: conn.Endpoint,
+		Headers:  conn.Headers,
+	}
+}
+
+// persistManagerConfig writes the config to the manager config file and makes it the current config.
+// The file is restored if it can't be written.
+func (c *Client) persistManagerConfig(cfg opamp.Config) error {
+	rollbackFunc, cleanupFunc, err := prepRollback(c.managerConfigPath)
+	if err != nil {
+		return fmt.Errorf("failed to prep for rollback: %w", err)
+	}
+
+	defer func() {
+		// Cleanup rollback
+		if err := cleanupFunc(); err != nil {
+			c.logger.Warn("Failed to cleanup rollback file", zap.Error(err))
+		}
+	}()
+
+	contents, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to reformat manager config: %w", err)
+	}
+
+	if err := updateConfigFile(ManagerConfigName, c.managerConfigPath, contents); err != nil {
+		if rollbackErr := rollbackFunc(); rollbackErr != nil {
+			c.logger.Error("Rollback failed for manager config", zap.Error(rollbackErr))
+		}
+		return err
+	}
+
+	if c.configHistory != nil {
+		if _, err := c.configHistory.Record(ManagerConfigName, c.managerConfigPath, contents); err != nil {
+			c.logger.Warn("Failed to record config history", zap.String("config", ManagerConfigName), zap.Error(err))
+		}
+	}
+
+	c.safeSetCurrentConfig(cfg)
+	return nil
+}
@@ -0,0 +1,385 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/collector"
+	colmocks "github.com/observiq/bindplane-otel-collector/collector/mocks"
+	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/observiq/bindplane-otel-collector/opamp/mocks"
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// newConnectionSettingsTestClient returns a client with its manager config written to a temp dir
+func newConnectionSettingsTestClient(t *testing.T, opampClient client.OpAMPClient) *Client {
+	t.Helper()
+
+	secretKey := "old-secret"
+	cfg := opamp.Config{
+		Endpoint:  "ws://localhost:1234/v1/opamp",
+		SecretKey: &secretKey,
+		AgentID:   testAgentID,
+	}
+
+	managerConfigPath := filepath.Join(t.TempDir(), ManagerConfigName)
+	contents, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(managerConfigPath, contents, 0600))
+
+	return &Client{
+		logger:             zap.NewNop(),
+		ident:              &identity{agentID: testAgentID},
+		opampClient:        opampClient,
+		currentConfig:      cfg,
+		managerConfigPath:  managerConfigPath,
+		measurementsSender: newMeasurementsSender(zap.NewNop(), nil, opampClient, time.Minute, nil),
+		topologySender:     newTopologySender(zap.NewNop(), nil, opampClient),
+		diagnosticsHandler: newDiagnosticsHandler(zap.NewNop(), opampClient, nil),
+		lastHealth:         &protobufs.ComponentHealth{Healthy: true},
+	}
+}
+
+// readManagerConfig reads the client's manager config from disk
+func readManagerConfig(t *testing.T, c *Client) opamp.Config {
+	t.Helper()
+
+	contents, err := os.ReadFile(c.managerConfigPath)
+	require.NoError(t, err)
+
+	var cfg opamp.Config
+	require.NoError(t, yaml.Unmarshal(contents, &cfg))
+	return cfg
+}
+
+// setVerifyOpAMPConnection replaces the connection verifier for the test
+func setVerifyOpAMPConnection(t *testing.T, verify func(context.Context, string, http.Header, *tls.Config, func(*http.Request) (*url.URL, error)) error) {
+	original := verifyOpAMPConnection
+	verifyOpAMPConnection = verify
+	t.Cleanup(func() { verifyOpAMPConnection = original })
+}
+
+// setNewWebSocketClient replaces the OpAMP client constructor for the test, returning the clients in order
+func setNewWebSocketClient(t *testing.T, clients ...client.OpAMPClient) {
+	original := newWebSocketClient
+	var created atomic.Int64
+	newWebSocketClient = func(types.Logger) client.OpAMPClient {
+		return clients[created.Add(1)-1]
+	}
+	t.Cleanup(func() { newWebSocketClient = original })
+}
+
+func TestClient_onOpampConnectionSettingsHandler(t *testing.T) {
+	certContents, err := os.ReadFile(filepath.Join("..", "testdata", "test.crt"))
+	require.NoError(t, err)
+	keyContents, err := os.ReadFile(filepath.Join("..", "testdata", "test.key"))
+	require.NoError(t, err)
+
+	t.Run("Unsupported endpoint", func(t *testing.T) {
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			DestinationEndpoint: "http://localhost:1234/v1/opamp",
+		})
+		require.ErrorIs(t, err, ErrUnsupportedURL)
+		require.Equal(t, "ws://localhost:1234/v1/opamp", readManagerConfig(t, c).Endpoint)
+	})
+
+	t.Run("Polling over HTTP", func(t *testing.T) {
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+		c.currentConfig.Endpoint = "https://localhost:1234/v1/opamp"
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			Headers: &protobufs.Headers{Headers: []*protobufs.Header{
+				{Key: "Authorization", Value: "Secret-Key new-secret"},
+			}},
+		})
+		require.ErrorIs(t, err, ErrUnsupportedURL)
+	})
+
+	t.Run("Invalid certificate", func(t *testing.T) {
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			Certificate: &protobufs.TLSCertificate{Cert: []byte("not a cert"), PrivateKey: keyContents},
+		})
+		require.ErrorContains(t, err, "invalid client certificate")
+	})
+
+	t.Run("Unchanged settings", func(t *testing.T) {
+		setVerifyOpAMPConnection(t, func(context.Context, string, http.Header, *tls.Config, func(*http.Request) (*url.URL, error)) error {
+			t.Fatal("unexpected verification")
+			return nil
+		})
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			DestinationEndpoint: "ws://localhost:1234/v1/opamp",
+			Headers: &protobufs.Headers{Headers: []*protobufs.Header{
+				{Key: "Authorization", Value: "Secret-Key old-secret"},
+			}},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("Verification fails", func(t *testing.T) {
+		setVerifyOpAMPConnection(t, func(context.Context, string, http.Header, *tls.Config, func(*http.Request) (*url.URL, error)) error {
+			return errors.New("bad handshake")
+		})
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			DestinationEndpoint: "wss://new.example.com/v1/opamp",
+			Certificate:         &protobufs.TLSCertificate{Cert: certContents, PrivateKey: keyContents},
+		})
+		require.EqualError(t, err, "failed to verify connection settings: bad handshake")
+
+		// Nothing was persisted and the offered certificate was removed
+		require.Equal(t, "ws://localhost:1234/v1/opamp", readManagerConfig(t, c).Endpoint)
+		require.Equal(t, "ws://localhost:1234/v1/opamp", c.currentConfig.Endpoint)
+		files, err := filepath.Glob(filepath.Join(filepath.Dir(c.managerConfigPath), "opamp-client-*"))
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("Accepted and reconnected", func(t *testing.T) {
+		var verifiedHeader http.Header
+		var verifiedTLS *tls.Config
+		setVerifyOpAMPConnection(t, func(_ context.Context, endpoint string, header http.Header, tlsCfg *tls.Config, _ func(*http.Request) (*url.URL, error)) error {
+			require.Equal(t, "wss://new.example.com/v1/opamp", endpoint)
+			verifiedHeader = header
+			verifiedTLS = tlsCfg
+			return nil
+		})
+
+		started := make(chan types.StartSettings, 1)
+		newClient := mocks.NewMockOpAMPClient(t)
+		newClient.On("SetCustomCapabilities", mock.Anything).Return(nil)
+		newClient.On("SetAgentDescription", mock.Anything).Return(nil)
+		newClient.On("SetHealth", &protobufs.ComponentHealth{Healthy: true}).Return(nil)
+		newClient.On("Start", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			started <- args.Get(1).(types.StartSettings)
+		}).Return(nil)
+		setNewWebSocketClient(t, newClient)
+
+		oldClient := mocks.NewMockOpAMPClient(t)
+		oldClient.On("Stop", mock.Anything).Return(nil)
+		c := newConnectionSettingsTestClient(t, oldClient)
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			DestinationEndpoint: "wss://new.example.com/v1/opamp",
+			Headers: &protobufs.Headers{Headers: []*protobufs.Header{
+				{Key: "Authorization", Value: "Secret-Key new-secret"},
+				{Key: "X-Unsupported", Value: "ignored"},
+			}},
+			Certificate: &protobufs.TLSCertificate{Cert: certContents, PrivateKey: keyContents},
+		})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"Secret-Key new-secret"}, verifiedHeader["Authorization"])
+		require.NotContains(t, verifiedHeader, "X-Unsupported")
+		require.Len(t, verifiedTLS.Certificates, 1)
+
+		persisted := readManagerConfig(t, c)
+		require.Equal(t, "wss://new.example.com/v1/opamp", persisted.Endpoint)
+		require.Equal(t, "new-secret", persisted.GetSecretKey())
+		require.NotNil(t, persisted.TLS)
+		require.FileExists(t, *persisted.TLS.CertFile)
+		require.FileExists(t, *persisted.TLS.KeyFile)
+
+		select {
+		case settings := <-started:
+			require.Equal(t, "wss://new.example.com/v1/opamp", settings.OpAMPServerURL)
+			require.Equal(t, []string{"Secret-Key new-secret"}, settings.Header["Authorization"])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for reconnect")
+		}
+	})
+
+	t.Run("Reconnect while reporting health", func(t *testing.T) {
+		setVerifyOpAMPConnection(t, func(context.Context, string, http.Header, *tls.Config, func(*http.Request) (*url.URL, error)) error {
+			return nil
+		})
+
+		started := make(chan struct{})
+		newClient := mocks.NewMockOpAMPClient(t)
+		newClient.On("SetCustomCapabilities", mock.Anything).Return(nil)
+		newClient.On("SetAgentDescription", mock.Anything).Return(nil)
+		newClient.On("SetHealth", mock.Anything).Return(nil)
+		newClient.On("Start", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			close(started)
+		}).Return(nil)
+		setNewWebSocketClient(t, newClient)
+
+		oldClient := mocks.NewMockOpAMPClient(t)
+		oldClient.On("Stop", mock.Anything).Return(nil)
+		oldClient.On("SetHealth", mock.Anything).Return(nil).Maybe()
+		c := newConnectionSettingsTestClient(t, oldClient)
+
+		mockCollector := colmocks.NewMockCollector(t)
+		mockCollector.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{})
+		c.collector = mockCollector
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			DestinationEndpoint: "wss://new.example.com/v1/opamp",
+		})
+		require.NoError(t, err)
+
+		// The client and config are used by other handlers while the reconnect replaces them
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case <-started:
+				require.Equal(t, newClient, c.safeGetOpAMPClient())
+				require.Equal(t, "wss://new.example.com/v1/opamp", c.safeGetCurrentConfig().Endpoint)
+				return
+			case <-timeout:
+				t.Fatal("timed out waiting for reconnect")
+			default:
+				c.reportHealth(nil)
+				_ = c.safeGetCurrentConfig()
+			}
+		}
+	})
+
+	t.Run("Reconnect fails, rollback", func(t *testing.T) {
+		setVerifyOpAMPConnection(t, func(context.Context, string, http.Header, *tls.Config, func(*http.Request) (*url.URL, error)) error {
+			return nil
+		})
+
+		failedClient := mocks.NewMockOpAMPClient(t)
+		failedClient.On("SetCustomCapabilities", mock.Anything).Return(nil)
+		failedClient.On("SetAgentDescription", mock.Anything).Return(nil)
+		failedClient.On("SetHealth", mock.Anything).Return(nil)
+		failedClient.On("Start", mock.Anything, mock.Anything).Return(errors.New("oops"))
+
+		started := make(chan types.StartSettings, 1)
+		rollbackClient := mocks.NewMockOpAMPClient(t)
+		rollbackClient.On("SetCustomCapabilities", mock.Anything).Return(nil)
+		rollbackClient.On("SetAgentDescription", mock.Anything).Return(nil)
+		rollbackClient.On("SetHealth", mock.Anything).Return(nil)
+		rollbackClient.On("Start", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			started <- args.Get(1).(types.StartSettings)
+		}).Return(nil)
+		setNewWebSocketClient(t, failedClient, rollbackClient)
+
+		oldClient := mocks.NewMockOpAMPClient(t)
+		oldClient.On("Stop", mock.Anything).Return(nil)
+		c := newConnectionSettingsTestClient(t, oldClient)
+
+		err := c.onOpampConnectionSettingsHandler(context.Background(), &protobufs.OpAMPConnectionSettings{
+			DestinationEndpoint: "wss://new.example.com/v1/opamp",
+		})
+		require.NoError(t, err)
+
+		select {
+		case settings := <-started:
+			require.Equal(t, "ws://localhost:1234/v1/opamp", settings.OpAMPServerURL)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for rollback reconnect")
+		}
+		require.Equal(t, "ws://localhost:1234/v1/opamp", readManagerConfig(t, c).Endpoint)
+	})
+}
+
+func TestClient_onOwnTelemetryConnectionSettings(t *testing.T) {
+	metricsOffer := &protobufs.TelemetryConnectionSettings{
+		DestinationEndpoint: "https://telemetry.example.com/v1/metrics",
+		Headers: &protobufs.Headers{Headers: []*protobufs.Header{
+			{Key: "Authorization", Value: "Bearer abc"},
+		}},
+	}
+	expectedMetrics := &collector.TelemetryDestination{
+		Endpoint: "https://telemetry.example.com/v1/metrics",
+		Headers:  map[string]string{"Authorization": "Bearer abc"},
+	}
+
+	t.Run("Applied", func(t *testing.T) {
+		mockCollector := colmocks.NewMockCollector(t)
+		mockCollector.On("SetOwnTelemetry", expectedMetrics, (*collector.TelemetryDestination)(nil)).Once()
+		mockCollector.On("Restart", mock.Anything).Return(nil).Once()
+		mockCollector.On("Status").Return((<-chan *collector.Status)(make(chan *collector.Status)))
+
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+		c.collector = mockCollector
+		c.collectorMntrCtx, c.collectorMntrCancel = context.WithCancel(context.Background())
+		defer c.stopCollectorMonitoring()
+
+		err := c.onOwnTelemetryConnectionSettings(metricsOffer, nil)
+		require.NoError(t, err)
+
+		persisted := readManagerConfig(t, c)
+		require.Equal(t, &opamp.TelemetryConnection{
+			Endpoint: "https://telemetry.example.com/v1/metrics",
+			Headers:  map[string]string{"Authorization": "Bearer abc"},
+		}, persisted.OwnMetrics)
+		require.Nil(t, persisted.OwnLogs)
+
+		// The same offer again is a noop
+		require.NoError(t, c.onOwnTelemetryConnectionSettings(metricsOffer, nil))
+	})
+
+	t.Run("Disabled by empty endpoint", func(t *testing.T) {
+		mockCollector := colmocks.NewMockCollector(t)
+		mockCollector.On("SetOwnTelemetry", (*collector.TelemetryDestination)(nil), (*collector.TelemetryDestination)(nil)).Once()
+		mockCollector.On("Restart", mock.Anything).Return(nil).Once()
+		mockCollector.On("Status").Return((<-chan *collector.Status)(make(chan *collector.Status)))
+
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+		c.currentConfig.OwnLogs = &opamp.TelemetryConnection{Endpoint: "https://telemetry.example.com/v1/logs"}
+		c.collector = mockCollector
+		c.collectorMntrCtx, c.collectorMntrCancel = context.WithCancel(context.Background())
+		defer c.stopCollectorMonitoring()
+
+		err := c.onOwnTelemetryConnectionSettings(nil, &protobufs.TelemetryConnectionSettings{})
+		require.NoError(t, err)
+		require.Nil(t, readManagerConfig(t, c).OwnLogs)
+	})
+
+	t.Run("Restart fails, rollback", func(t *testing.T) {
+		mockCollector := colmocks.NewMockCollector(t)
+		mockCollector.On("SetOwnTelemetry", expectedMetrics, (*collector.TelemetryDestination)(nil)).Once()
+		mockCollector.On("SetOwnTelemetry", (*collector.TelemetryDestination)(nil), (*collector.TelemetryDestination)(nil)).Once()
+		mockCollector.On("Restart", mock.Anything).Return(errors.New("oops")).Once()
+		mockCollector.On("Restart", mock.Anything).Return(nil).Once()
+		mockCollector.On("Status").Return((<-chan *collector.Status)(make(chan *collector.Status)))
+
+		c := newConnectionSettingsTestClient(t, mocks.NewMockOpAMPClient(t))
+		c.collector = mockCollector
+		c.collectorMntrCtx, c.collectorMntrCancel = context.WithCancel(context.Background())
+		defer c.stopCollectorMonitoring()
+
+		err := c.onOwnTelemetryConnectionSettings(metricsOffer, nil)
+		require.EqualError(t, err, "collector failed to restart: oops")
+		require.Nil(t, readManagerConfig(t, c).OwnMetrics)
+		require.Nil(t, c.currentConfig.OwnMetrics)
+	})
+}
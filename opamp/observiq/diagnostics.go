@@ -0,0 +1,195 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/observiq/bindplane-otel-collector/internal/logging"
+	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.uber.org/zap"
+)
+
+const (
+	// diagnosticsCapability is the custom capability for on-demand agent diagnostics
+	diagnosticsCapability = "com.bindplane.diagnostics"
+
+	// goroutineProfileType requests a pprof goroutine profile
+	goroutineProfileType = "goroutineProfile"
+	// heapProfileType requests a pprof heap profile
+	heapProfileType = "heapProfile"
+	// effectiveConfigType requests the agent's current config files
+	effectiveConfigType = "effectiveConfig"
+	// recentLogsType requests the most recent agent log entries
+	recentLogsType = "recentLogs"
+
+	// defaultRecentLogLines is the number of log entries returned if the request doesn't specify one
+	defaultRecentLogLines = 100
+)
+
+// diagnosticsRequest is the optional JSON body of a diagnostics custom message from the server
+type diagnosticsRequest struct {
+	// RequestID is echoed in the response so the server can match it to the request
+	RequestID string `json:"request_id,omitempty"`
+
+	// Lines is the number of log entries to return for a recentLogs request
+	Lines int `json:"lines,omitempty"`
+}
+
+// diagnosticsResponse is the JSON body of a diagnostics custom message to the server. It is snappy-encoded when sent.
+type diagnosticsResponse struct {
+	RequestID string `json:"request_id,omitempty"`
+	Error     string `json:"error,omitempty"`
+
+	// Data is the gzipped pprof profile for profile requests and JSON for all others
+	Data []byte `json:"data,omitempty"`
+}
+
+// diagnosticsHandler answers diagnostics custom messages from the server
+type diagnosticsHandler struct {
+	logger        *zap.Logger
+	configManager opamp.ConfigManager
+
+	mux         sync.Mutex
+	opampClient client.OpAMPClient
+}
+
+func newDiagnosticsHandler(logger *zap.Logger, opampClient client.OpAMPClient, configManager opamp.ConfigManager) *diagnosticsHandler {
+	return &diagnosticsHandler{
+		logger:        logger,
+		opampClient:   opampClient,
+		configManager: configManager,
+	}
+}
+
+// SetOpAMPClient sets the client responses are sent with
+func (d *diagnosticsHandler) SetOpAMPClient(opampClient client.OpAMPClient) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	d.opampClient = opampClient
+}
+
+// Handle collects the requested diagnostics and sends them back with the same capability and type
+func (d *diagnosticsHandler) Handle(msg *protobufs.CustomMessage) {
+	var request diagnosticsRequest
+	if len(msg.GetData()) > 0 {
+		if err := json.Unmarshal(msg.GetData(), &request); err != nil {
+			d.logger.Warn("Failed to parse diagnostics request, using defaults", zap.String("type", msg.GetType()), zap.Error(err))
+		}
+	}
+
+	d.logger.Info("Collecting diagnostics", zap.String("type", msg.GetType()), zap.String("request_id", request.RequestID))
+
+	response := diagnosticsResponse{RequestID: request.RequestID}
+	data, err := d.collect(msg.GetType(), request)
+	if err != nil {
+		d.logger.Error("Failed to collect diagnostics", zap.String("type", msg.GetType()), zap.Error(err))
+		response.Error = err.Error()
+	}
+	response.Data = data
+
+	marshalled, err := json.Marshal(response)
+	if err != nil {
+		d.logger.Error("Failed to marshal diagnostics response", zap.Error(err))
+		return
+	}
+
+	d.send(&protobufs.CustomMessage{
+		Capability: diagnosticsCapability,
+		Type:       msg.GetType(),
+		Data:       snappy.Encode(nil, marshalled),
+	})
+}
+
+// collect returns the diagnostics data for the request type
+func (d *diagnosticsHandler) collect(requestType string, request diagnosticsRequest) ([]byte, error) {
+	switch requestType {
+	case goroutineProfileType:
+		return writeProfile("goroutine")
+	case heapProfileType:
+		// Collect garbage first so the profile reflects live memory
+		runtime.GC()
+		return writeProfile("heap")
+	case effectiveConfigType:
+		effectiveConfig, err := d.configManager.ComposeEffectiveConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compose effective config: %w", err)
+		}
+
+		configs := make(map[string]string, len(effectiveConfig.GetConfigMap().GetConfigMap()))
+		for name, file := range effectiveConfig.GetConfigMap().GetConfigMap() {
+			configs[name] = string(file.GetBody())
+		}
+		return json.Marshal(configs)
+	case recentLogsType:
+		lines := request.Lines
+		if lines <= 0 {
+			lines = defaultRecentLogLines
+		}
+		return json.Marshal(logging.RecentLogs(lines))
+	default:
+		return nil, fmt.Errorf("unsupported diagnostics type: %s", requestType)
+	}
+}
+
+// send sends the custom message, waiting on any pending custom message
+func (d *diagnosticsHandler) send(cm *protobufs.CustomMessage) {
+	d.mux.Lock()
+	opampClient := d.opampClient
+	d.mux.Unlock()
+
+	for i := 0; i < maxSendRetries; i++ {
+		sendingChannel, err := opampClient.SendCustomMessage(cm)
+		switch {
+		case err == nil: // OK
+		case errors.Is(err, types.ErrCustomMessagePending):
+			if i == maxSendRetries-1 {
+				d.logger.Warn("Diagnostics were blocked by other custom messages, skipping...", zap.Int("retries", maxSendRetries))
+				break
+			}
+
+			<-sendingChannel
+			continue
+		default:
+			d.logger.Error("Failed to send diagnostics", zap.Error(err))
+		}
+		break
+	}
+}
+
+// writeProfile writes the named runtime profile in the gzipped pprof format
+func writeProfile(name string) ([]byte, error) {
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		return nil, fmt.Errorf("profile %s not found", name)
+	}
+
+	var buf bytes.Buffer
+	if err := profile.WriteTo(&buf, 0); err != nil {
+		return nil, fmt.Errorf("failed to write %s profile: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,174 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/observiq/bindplane-otel-collector/opamp/mocks"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestDiagnosticsHandler(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		requestType string
+		requestData []byte
+		setupMocks  func(*mocks.MockConfigManager)
+		validate    func(*testing.T, diagnosticsResponse)
+	}{
+		{
+			desc:        "Goroutine profile",
+			requestType: goroutineProfileType,
+			requestData: []byte(`{"request_id":"abc"}`),
+			validate: func(t *testing.T, response diagnosticsResponse) {
+				require.Equal(t, "abc", response.RequestID)
+				require.Empty(t, response.Error)
+				// Profiles are gzipped
+				require.Greater(t, len(response.Data), 2)
+				require.Equal(t, []byte{0x1f, 0x8b}, response.Data[:2])
+			},
+		},
+		{
+			desc:        "Heap profile",
+			requestType: heapProfileType,
+			validate: func(t *testing.T, response diagnosticsResponse) {
+				require.Empty(t, response.Error)
+				require.Greater(t, len(response.Data), 2)
+				require.Equal(t, []byte{0x1f, 0x8b}, response.Data[:2])
+			},
+		},
+		{
+			desc:        "Effective config",
+			requestType: effectiveConfigType,
+			setupMocks: func(m *mocks.MockConfigManager) {
+				m.On("ComposeEffectiveConfig").Return(&protobufs.EffectiveConfig{
+					ConfigMap: &protobufs.AgentConfigMap{
+						ConfigMap: map[string]*protobufs.AgentConfigFile{
+							CollectorConfigName: {Body: []byte("receivers:")},
+							LoggingConfigName:   {Body: []byte("output: stdout")},
+						},
+					},
+				}, nil)
+			},
+			validate: func(t *testing.T, response diagnosticsResponse) {
+				require.Empty(t, response.Error)
+
+				var configs map[string]string
+				require.NoError(t, json.Unmarshal(response.Data, &configs))
+				require.Equal(t, map[string]string{
+					CollectorConfigName: "receivers:",
+					LoggingConfigName:   "output: stdout",
+				}, configs)
+			},
+		},
+		{
+			desc:        "Effective config error",
+			requestType: effectiveConfigType,
+			setupMocks: func(m *mocks.MockConfigManager) {
+				m.On("ComposeEffectiveConfig").Return(nil, errors.New("oops"))
+			},
+			validate: func(t *testing.T, response diagnosticsResponse) {
+				require.Equal(t, "failed to compose effective config: oops", response.Error)
+				require.Empty(t, response.Data)
+			},
+		},
+		{
+			desc:        "Recent logs",
+			requestType: recentLogsType,
+			requestData: []byte(`{"lines":10}`),
+			validate: func(t *testing.T, response diagnosticsResponse) {
+				require.Empty(t, response.Error)
+
+				var lines []string
+				require.NoError(t, json.Unmarshal(response.Data, &lines))
+				require.LessOrEqual(t, len(lines), 10)
+			},
+		},
+		{
+			desc:        "Unsupported type",
+			requestType: "threadDump",
+			validate: func(t *testing.T, response diagnosticsResponse) {
+				require.Equal(t, "unsupported diagnostics type: threadDump", response.Error)
+			},
+		},
+		{
+			desc:        "Malformed request uses defaults",
+			requestType: recentLogsType,
+			requestData: []byte(`{`),
+			validate: func(t *testing.T, response diagnosticsResponse) {
+				require.Empty(t, response.Error)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mockManager := mocks.NewMockConfigManager(t)
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockManager)
+			}
+
+			var sent *protobufs.CustomMessage
+			mockClient := mocks.NewMockOpAMPClient(t)
+			mockClient.On("SendCustomMessage", mock.Anything).Run(func(args mock.Arguments) {
+				sent = args.Get(0).(*protobufs.CustomMessage)
+			}).Return(make(chan struct{}), nil).Once()
+
+			handler := newDiagnosticsHandler(zap.NewNop(), mockClient, mockManager)
+			handler.Handle(&protobufs.CustomMessage{
+				Capability: diagnosticsCapability,
+				Type:       tc.requestType,
+				Data:       tc.requestData,
+			})
+
+			require.NotNil(t, sent)
+			require.Equal(t, diagnosticsCapability, sent.Capability)
+			require.Equal(t, tc.requestType, sent.Type)
+
+			decoded, err := snappy.Decode(nil, sent.Data)
+			require.NoError(t, err)
+
+			var response diagnosticsResponse
+			require.NoError(t, json.Unmarshal(decoded, &response))
+			tc.validate(t, response)
+		})
+	}
+}
+
+func TestDiagnosticsHandlerPendingMessage(t *testing.T) {
+	// The pending message has already been sent
+	pending := make(chan struct{})
+	close(pending)
+
+	mockClient := mocks.NewMockOpAMPClient(t)
+	mockClient.On("SendCustomMessage", mock.Anything).Return(pending, types.ErrCustomMessagePending).Once()
+	mockClient.On("SendCustomMessage", mock.Anything).Return(make(chan struct{}), nil).Once()
+
+	handler := newDiagnosticsHandler(zap.NewNop(), mockClient, mocks.NewMockConfigManager(t))
+	handler.Handle(&protobufs.CustomMessage{
+		Capability: diagnosticsCapability,
+		Type:       recentLogsType,
+	})
+
+	mockClient.AssertNumberOfCalls(t, "SendCustomMessage", 2)
+}
@@ -0,0 +1,182 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/collector"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// healthReportInterval is how often the collector's component health is checked for changes to report
+var healthReportInterval = 10 * time.Second
+
+// errCollectorNotRunning is reported as the agent's last error when the collector stopped without an error
+var errCollectorNotRunning = errors.New("collector is not running")
+
+// statusSeverity orders reported statuses from healthy to failed, so the worst status of a group can be reported
+var statusSeverity = map[string]int{
+	componentstatus.StatusOK.String():               0,
+	componentstatus.StatusStarting.String():         1,
+	componentstatus.StatusStopping.String():         1,
+	componentstatus.StatusStopped.String():          1,
+	componentstatus.StatusRecoverableError.String(): 2,
+	componentstatus.StatusPermanentError.String():   3,
+	componentstatus.StatusFatalError.String():       4,
+}
+
+// collectorHealth returns the agent's health built from the health of the collector's components.
+// Components are grouped by pipeline under keys such as "pipeline:traces" and by kind and ID within a pipeline,
+// such as "receiver:otlp". Extensions are keyed directly under the agent. A non-nil stoppedErr reports the collector as stopped.
+func collectorHealth(components map[string]*collector.ComponentHealth, stoppedErr error) *protobufs.ComponentHealth {
+	if stoppedErr != nil {
+		return &protobufs.ComponentHealth{
+			Healthy:            false,
+			LastError:          stoppedErr.Error(),
+			Status:             componentstatus.StatusStopped.String(),
+			StatusTimeUnixNano: uint64(time.Now().UnixNano()),
+		}
+	}
+
+	pipelines := map[string]map[string]*collector.ComponentHealth{}
+	extensions := map[string]*collector.ComponentHealth{}
+	for key, health := range components {
+		if len(health.Pipelines) == 0 {
+			extensions[key] = health
+			continue
+		}
+		for _, p := range health.Pipelines {
+			pipelineKey := "pipeline:" + p
+			if pipelines[pipelineKey] == nil {
+				pipelines[pipelineKey] = map[string]*collector.ComponentHealth{}
+			}
+			pipelines[pipelineKey][key] = health
+		}
+	}
+
+	healthMap := make(map[string]*protobufs.ComponentHealth, len(pipelines)+len(extensions))
+	for key, pipelineComponents := range pipelines {
+		componentMap := make(map[string]*protobufs.ComponentHealth, len(pipelineComponents))
+		for componentKey, health := range pipelineComponents {
+			componentMap[componentKey] = toProtoComponentHealth(health)
+		}
+		healthMap[key] = groupHealth(componentMap)
+	}
+	for key, health := range extensions {
+		healthMap[key] = toProtoComponentHealth(health)
+	}
+
+	return groupHealth(healthMap)
+}
+
+// toProtoComponentHealth converts a component's health to its OpAMP representation
+func toProtoComponentHealth(health *collector.ComponentHealth) *protobufs.ComponentHealth {
+	protoHealth := &protobufs.ComponentHealth{
+		Healthy:            health.Healthy(),
+		Status:             health.Status.String(),
+		StatusTimeUnixNano: unixNano(health.StatusTime),
+	}
+
+	// A stopped component isn't up, so it has no start time
+	if health.Status != componentstatus.StatusStopped {
+		protoHealth.StartTimeUnixNano = unixNano(health.StartTime)
+	}
+	if health.LastError != nil {
+		protoHealth.LastError = health.LastError.Error()
+	}
+
+	return protoHealth
+}
+
+// groupHealth returns the health of a group of components. The group is healthy if every component is,
+// has the worst status and earliest start time of its components, and reports the errors of unhealthy components.
+func groupHealth(healthMap map[string]*protobufs.ComponentHealth) *protobufs.ComponentHealth {
+	group := &protobufs.ComponentHealth{
+		Healthy:            true,
+		Status:             componentstatus.StatusOK.String(),
+		ComponentHealthMap: healthMap,
+	}
+
+	worst := 0
+	keys := make([]string, 0, len(healthMap))
+	for key := range healthMap {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var lastErrors []string
+	for _, key := range keys {
+		health := healthMap[key]
+		if !health.GetHealthy() {
+			group.Healthy = false
+			if health.GetLastError() != "" {
+				lastErrors = append(lastErrors, fmt.Sprintf("%s: %s", key, health.GetLastError()))
+			}
+		}
+
+		if severity := statusSeverity[health.GetStatus()]; severity > worst {
+			worst = severity
+			group.Status = health.GetStatus()
+		}
+
+		if start := health.GetStartTimeUnixNano(); start != 0 && (group.StartTimeUnixNano == 0 || start < group.StartTimeUnixNano) {
+			group.StartTimeUnixNano = start
+		}
+		if statusTime := health.GetStatusTimeUnixNano(); statusTime > group.StatusTimeUnixNano {
+			group.StatusTimeUnixNano = statusTime
+		}
+	}
+
+	if len(lastErrors) > 0 {
+		group.LastError = strings.Join(lastErrors, "; ")
+	}
+
+	return group
+}
+
+// unixNano returns the time in nanoseconds since the epoch, or zero for the zero time
+func unixNano(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.UnixNano())
+}
+
+// reportHealth sends the collector's health to the server if it changed since it was last reported.
+// A non-nil stoppedErr reports the collector as stopped.
+func (c *Client) reportHealth(stoppedErr error) {
+	health := collectorHealth(c.collector.ComponentHealth(), stoppedErr)
+
+	c.healthMux.Lock()
+	defer c.healthMux.Unlock()
+
+	if c.lastHealth != nil && proto.Equal(c.lastHealth, health) {
+		return
+	}
+
+	if err := c.safeGetOpAMPClient().SetHealth(health); err != nil {
+		c.logger.Error("Failed to report health", zap.Error(err))
+		return
+	}
+	c.lastHealth = health
+}
@@ -0,0 +1,219 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/collector"
+	colmocks "github.com/observiq/bindplane-otel-collector/collector/mocks"
+	"github.com/observiq/bindplane-otel-collector/opamp/mocks"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.uber.org/zap"
+)
+
+func TestCollectorHealth(t *testing.T) {
+	start := time.Unix(100, 0)
+	later := time.Unix(200, 0)
+
+	components := map[string]*collector.ComponentHealth{
+		"receiver:otlp": {
+			ID:         component.MustNewID("otlp"),
+			Kind:       component.KindReceiver,
+			Pipelines:  []string{"logs", "traces"},
+			Status:     componentstatus.StatusOK,
+			StartTime:  start,
+			StatusTime: start,
+		},
+		"exporter:otlp": {
+			ID:         component.MustNewID("otlp"),
+			Kind:       component.KindExporter,
+			Pipelines:  []string{"traces"},
+			Status:     componentstatus.StatusRecoverableError,
+			LastError:  errors.New("connection refused"),
+			StartTime:  later,
+			StatusTime: later,
+		},
+		"exporter:logging": {
+			ID:         component.MustNewID("logging"),
+			Kind:       component.KindExporter,
+			Pipelines:  []string{"logs"},
+			Status:     componentstatus.StatusOK,
+			LastError:  errors.New("recovered"),
+			StartTime:  later,
+			StatusTime: later,
+		},
+		"extension:file_storage": {
+			ID:         component.MustNewID("file_storage"),
+			Kind:       component.KindExtension,
+			Status:     componentstatus.StatusStopped,
+			StartTime:  start,
+			StatusTime: later,
+		},
+	}
+
+	otlpReceiver := &protobufs.ComponentHealth{
+		Healthy:            true,
+		Status:             "StatusOK",
+		StartTimeUnixNano:  uint64(start.UnixNano()),
+		StatusTimeUnixNano: uint64(start.UnixNano()),
+	}
+
+	expected := &protobufs.ComponentHealth{
+		Healthy:            false,
+		Status:             "StatusRecoverableError",
+		LastError:          "pipeline:traces: exporter:otlp: connection refused",
+		StartTimeUnixNano:  uint64(start.UnixNano()),
+		StatusTimeUnixNano: uint64(later.UnixNano()),
+		ComponentHealthMap: map[string]*protobufs.ComponentHealth{
+			"pipeline:logs": {
+				Healthy:            true,
+				Status:             "StatusOK",
+				StartTimeUnixNano:  uint64(start.UnixNano()),
+				StatusTimeUnixNano: uint64(later.UnixNano()),
+				ComponentHealthMap: map[string]*protobufs.ComponentHealth{
+					"receiver:otlp": otlpReceiver,
+					"exporter:logging": {
+						Healthy:            true,
+						Status:             "StatusOK",
+						LastError:          "recovered",
+						StartTimeUnixNano:  uint64(later.UnixNano()),
+						StatusTimeUnixNano: uint64(later.UnixNano()),
+					},
+				},
+			},
+			"pipeline:traces": {
+				Healthy:            false,
+				Status:             "StatusRecoverableError",
+				LastError:          "exporter:otlp: connection refused",
+				StartTimeUnixNano:  uint64(start.UnixNano()),
+				StatusTimeUnixNano: uint64(later.UnixNano()),
+				ComponentHealthMap: map[string]*protobufs.ComponentHealth{
+					"receiver:otlp": otlpReceiver,
+					"exporter:otlp": {
+						Healthy:            false,
+						Status:             "StatusRecoverableError",
+						LastError:          "connection refused",
+						StartTimeUnixNano:  uint64(later.UnixNano()),
+						StatusTimeUnixNano: uint64(later.UnixNano()),
+					},
+				},
+			},
+			"extension:file_storage": {
+				Healthy:            true,
+				Status:             "StatusStopped",
+				StatusTimeUnixNano: uint64(later.UnixNano()),
+			},
+		},
+	}
+
+	require.Equal(t, expected, collectorHealth(components, nil))
+}
+
+func TestCollectorHealthStopped(t *testing.T) {
+	health := collectorHealth(map[string]*collector.ComponentHealth{}, errors.New("oops"))
+	require.False(t, health.Healthy)
+	require.Equal(t, "oops", health.LastError)
+	require.Equal(t, "StatusStopped", health.Status)
+	require.Zero(t, health.StartTimeUnixNano)
+	require.NotZero(t, health.StatusTimeUnixNano)
+}
+
+func TestClient_reportHealth(t *testing.T) {
+	mockCollector := colmocks.NewMockCollector(t)
+	mockCollector.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{})
+
+	mockOpAmpClient := mocks.NewMockOpAMPClient(t)
+	mockOpAmpClient.On("SetHealth", mock.Anything).Return(nil).Twice()
+
+	c := &Client{
+		logger:      zap.NewNop(),
+		collector:   mockCollector,
+		opampClient: mockOpAmpClient,
+	}
+
+	c.reportHealth(nil)
+	require.True(t, c.lastHealth.Healthy)
+
+	// Unchanged health isn't sent again
+	c.reportHealth(nil)
+
+	c.reportHealth(errCollectorNotRunning)
+	require.False(t, c.lastHealth.Healthy)
+	require.Equal(t, errCollectorNotRunning.Error(), c.lastHealth.LastError)
+}
+
+func TestClient_monitorCollectorStatusReportsHealth(t *testing.T) {
+	original := healthReportInterval
+	healthReportInterval = 10 * time.Millisecond
+	defer func() { healthReportInterval = original }()
+
+	statusChannel := make(chan *collector.Status, 2)
+	mockCollector := colmocks.NewMockCollector(t)
+	mockCollector.On("Status").Return((<-chan *collector.Status)(statusChannel))
+	mockCollector.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{
+		"exporter:otlp": {
+			ID:        component.MustNewID("otlp"),
+			Kind:      component.KindExporter,
+			Pipelines: []string{"traces"},
+			Status:    componentstatus.StatusPermanentError,
+			LastError: errors.New("unauthorized"),
+		},
+	})
+
+	reported := make(chan *protobufs.ComponentHealth, 10)
+	mockOpAmpClient := mocks.NewMockOpAMPClient(t)
+	mockOpAmpClient.On("SetHealth", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		reported <- args.Get(0).(*protobufs.ComponentHealth)
+	})
+
+	c := &Client{
+		logger:      zap.NewNop(),
+		collector:   mockCollector,
+		opampClient: mockOpAmpClient,
+	}
+	c.startCollectorMonitoring(context.Background())
+	defer c.stopCollectorMonitoring()
+
+	// A running status keeps monitoring
+	statusChannel <- &collector.Status{Running: true}
+
+	select {
+	case health := <-reported:
+		require.False(t, health.Healthy)
+		require.Equal(t, "StatusPermanentError", health.Status)
+		require.Equal(t, "pipeline:traces: exporter:otlp: unauthorized", health.LastError)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for health report")
+	}
+
+	statusChannel <- &collector.Status{Running: false, Err: errors.New("crashed")}
+
+	require.Eventually(t, func() bool {
+		select {
+		case health := <-reported:
+			return health.Status == "StatusStopped" && health.LastError == "crashed"
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
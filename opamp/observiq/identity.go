@@ -15,15 +15,44 @@
 package observiq
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"runtime"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/observiq/bindplane-otel-collector/factories"
 	ios "github.com/observiq/bindplane-otel-collector/internal/os"
 	"github.com/observiq/bindplane-otel-collector/opamp"
 	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/collector/otelcol"
 	"go.uber.org/zap"
 )
 
+// agentDescriptionRefreshInterval is how often the host inventory is checked for changes to report
+var agentDescriptionRefreshInterval = 5 * time.Minute
+
+// cloudDetectTimeout is how long to wait on the cloud metadata endpoints
+var cloudDetectTimeout = 2 * time.Second
+
+// detectCloud finds the metadata of the cloud instance the collector is running on.
+// Tests replace it with a stand-in so they don't query the metadata endpoints.
+var detectCloud = func(ctx context.Context) (*ios.CloudInfo, error) {
+	return ios.DetectCloud(ctx, ios.DefaultCloudEndpoints)
+}
+
+// loadedComponents lists the components of the collector's factories, they don't change while running
+var loadedComponents = sync.OnceValue(func() []string {
+	f, err := factories.DefaultFactories()
+	if err != nil {
+		return nil
+	}
+	return componentNames(f)
+})
+
 // identity contains identifying information about the Collector
 type identity struct {
 	agentID     opamp.AgentID
@@ -36,6 +65,18 @@ type identity struct {
 	oSFamily    string
 	hostname    string
 	mac         string
+	host        hostInfo
+}
+
+// hostInfo is the inventory of the host and environment the collector is running on
+type hostInfo struct {
+	cpuCount    int
+	memoryTotal uint64
+	ipAddresses []string
+	timezone    string
+	container   ios.ContainerInfo
+	cloud       *ios.CloudInfo
+	components  []string
 }
 
 // newIdentity constructs a new identity for this collector
@@ -62,7 +103,119 @@ func newIdentity(logger *zap.Logger, config opamp.Config, version string) *ident
 		oSFamily:    runtime.GOOS,
 		hostname:    hostname,
 		mac:         ios.MACAddress(),
+		host:        newHostInfo(logger),
+	}
+}
+
+// newHostInfo gathers the current host inventory
+func newHostInfo(logger *zap.Logger) hostInfo {
+	memoryTotal, err := ios.TotalMemory()
+	if err != nil {
+		logger.Warn("Failed to retrieve total memory for collector", zap.Error(err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cloudDetectTimeout)
+	defer cancel()
+
+	cloud, err := detectCloud(ctx)
+	switch {
+	case errors.Is(err, ios.ErrNoCloud):
+		logger.Debug("No cloud metadata found")
+	case err != nil:
+		logger.Debug("Failed to retrieve cloud metadata", zap.Error(err))
+	}
+
+	return hostInfo{
+		cpuCount:    ios.CPUCount(),
+		memoryTotal: memoryTotal,
+		ipAddresses: ios.IPAddresses(),
+		timezone:    ios.Timezone(),
+		container:   ios.Container(),
+		cloud:       cloud,
+		components:  loadedComponents(),
+	}
+}
+
+// componentNames lists the components of the factories as <kind>:<type>, sorted
+func componentNames(f otelcol.Factories) []string {
+	names := []string{}
+	for t := range f.Receivers {
+		names = append(names, fmt.Sprintf("receiver:%s", t))
+	}
+	for t := range f.Processors {
+		names = append(names, fmt.Sprintf("processor:%s", t))
+	}
+	for t := range f.Exporters {
+		names = append(names, fmt.Sprintf("exporter:%s", t))
+	}
+	for t := range f.Connectors {
+		names = append(names, fmt.Sprintf("connector:%s", t))
 	}
+	for t := range f.Extensions {
+		names = append(names, fmt.Sprintf("extension:%s", t))
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// copy creates a deep copy of the host inventory
+func (h hostInfo) copy() hostInfo {
+	hostCpy := h
+	if h.ipAddresses != nil {
+		hostCpy.ipAddresses = append([]string{}, h.ipAddresses...)
+	}
+	if h.components != nil {
+		hostCpy.components = append([]string{}, h.components...)
+	}
+	if h.cloud != nil {
+		cloudCpy := *h.cloud
+		hostCpy.cloud = &cloudCpy
+	}
+	return hostCpy
+}
+
+// attributes returns the non-identifying attributes of the host inventory, skipping unknown values
+func (h hostInfo) attributes() []*protobufs.KeyValue {
+	attributes := []*protobufs.KeyValue{}
+
+	if h.cpuCount > 0 {
+		attributes = append(attributes, opamp.IntKeyValue("host.cpu.count", int64(h.cpuCount)))
+	}
+	if h.memoryTotal > 0 {
+		attributes = append(attributes, opamp.IntKeyValue("host.memory.total", int64(h.memoryTotal)))
+	}
+	if len(h.ipAddresses) > 0 {
+		attributes = append(attributes, opamp.StringSliceKeyValue("host.ip", h.ipAddresses))
+	}
+	if h.timezone != "" {
+		attributes = append(attributes, opamp.StringKeyValue("host.timezone", h.timezone))
+	}
+
+	if h.container.Runtime != "" {
+		attributes = append(attributes, opamp.StringKeyValue("container.runtime", h.container.Runtime))
+	}
+	if h.container.KubernetesNamespace != "" {
+		attributes = append(attributes, opamp.StringKeyValue("k8s.namespace.name", h.container.KubernetesNamespace))
+	}
+	if h.container.KubernetesPod != "" {
+		attributes = append(attributes, opamp.StringKeyValue("k8s.pod.name", h.container.KubernetesPod))
+	}
+
+	if h.cloud != nil {
+		attributes = append(attributes,
+			opamp.StringKeyValue("cloud.provider", h.cloud.Provider),
+			opamp.StringKeyValue("cloud.region", h.cloud.Region),
+			opamp.StringKeyValue("cloud.availability_zone", h.cloud.AvailabilityZone),
+			opamp.StringKeyValue("host.id", h.cloud.InstanceID),
+		)
+	}
+
+	if len(h.components) > 0 {
+		attributes = append(attributes, opamp.StringSliceKeyValue("service.components", h.components))
+	}
+
+	return attributes
 }
 
 // Copy creates a deep copy of this identity
@@ -76,6 +229,7 @@ func (i identity) Copy() *identity {
 		oSFamily:    i.oSFamily,
 		hostname:    i.hostname,
 		mac:         i.mac,
+		host:        i.host.copy(),
 	}
 
 	if i.agentName != nil {
@@ -111,6 +265,7 @@ func (i *identity) ToAgentDescription() *protobufs.AgentDescription {
 		opamp.StringKeyValue("host.name", i.hostname),
 		opamp.StringKeyValue("host.mac_address", i.mac),
 	}
+	nonIdentifyingAttributes = append(nonIdentifyingAttributes, i.host.attributes()...)
 
 	if i.labels != nil {
 		nonIdentifyingAttributes = append(nonIdentifyingAttributes, opamp.StringKeyValue("service.labels", *i.labels))
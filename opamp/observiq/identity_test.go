@@ -15,14 +15,21 @@
 package observiq
 
 import (
+	"context"
 	"os"
 	"runtime"
 	"testing"
 
+	ios "github.com/observiq/bindplane-otel-collector/internal/os"
 	"github.com/observiq/bindplane-otel-collector/opamp"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 )
 
@@ -50,6 +57,15 @@ func Test_newIdentity(t *testing.T) {
 	}
 
 	expectedVersion := "0.0.0"
+	expectedCloud := &ios.CloudInfo{
+		Provider:         ios.CloudProviderAWS,
+		Region:           "us-east-2",
+		AvailabilityZone: "us-east-2b",
+		InstanceID:       "i-0123456789",
+	}
+	setDetectCloud(t, func(context.Context) (*ios.CloudInfo, error) {
+		return expectedCloud, nil
+	})
 
 	got := newIdentity(zap.NewNop(), cfg, expectedVersion)
 
@@ -68,6 +84,54 @@ func Test_newIdentity(t *testing.T) {
 	require.Equal(t, got.version, expectedVersion)
 	require.Equal(t, got.oSArch, runtime.GOARCH)
 	require.Equal(t, got.oSFamily, runtime.GOOS)
+
+	// Check host inventory
+	require.Equal(t, runtime.NumCPU(), got.host.cpuCount)
+	require.Positive(t, got.host.memoryTotal)
+	require.NotEmpty(t, got.host.timezone)
+	require.Equal(t, expectedCloud, got.host.cloud)
+	require.Contains(t, got.host.components, "receiver:otlp")
+	require.Contains(t, got.host.components, "extension:file_storage")
+}
+
+// setDetectCloud replaces the cloud metadata detection with a stand-in for the test
+func setDetectCloud(t *testing.T, detect func(context.Context) (*ios.CloudInfo, error)) {
+	t.Helper()
+	original := detectCloud
+	detectCloud = detect
+	t.Cleanup(func() { detectCloud = original })
+}
+
+func Test_newHostInfoNoCloud(t *testing.T) {
+	setDetectCloud(t, func(context.Context) (*ios.CloudInfo, error) {
+		return nil, ios.ErrNoCloud
+	})
+
+	got := newHostInfo(zap.NewNop())
+	require.Nil(t, got.cloud)
+	require.Equal(t, runtime.NumCPU(), got.cpuCount)
+}
+
+func Test_componentNames(t *testing.T) {
+	f := otelcol.Factories{
+		Receivers: map[component.Type]receiver.Factory{
+			component.MustNewType("otlp"):        nil,
+			component.MustNewType("hostmetrics"): nil,
+		},
+		Exporters: map[component.Type]exporter.Factory{
+			component.MustNewType("debug"): nil,
+		},
+		Extensions: map[component.Type]extension.Factory{
+			component.MustNewType("file_storage"): nil,
+		},
+	}
+
+	require.Equal(t, []string{
+		"exporter:debug",
+		"extension:file_storage",
+		"receiver:hostmetrics",
+		"receiver:otlp",
+	}, componentNames(f))
 }
 
 func TestToAgentDescription(t *testing.T) {
@@ -141,6 +205,66 @@ func TestToAgentDescription(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "With host inventory",
+			ident: &identity{
+				agentID:     testAgentID,
+				serviceName: "com.observiq.collector",
+				version:     "v1.2.3",
+				oSArch:      "amd64",
+				oSDetails:   "os details",
+				oSFamily:    "linux",
+				hostname:    "my-linux-box",
+				mac:         "68-C7-B4-EB-A8-D2",
+				host: hostInfo{
+					cpuCount:    4,
+					memoryTotal: 8589934592,
+					ipAddresses: []string{"10.0.0.5", "2001:db8::5"},
+					timezone:    "America/Chicago",
+					container: ios.ContainerInfo{
+						Runtime:             "containerd",
+						Kubernetes:          true,
+						KubernetesNamespace: "observability",
+						KubernetesPod:       "agent-0",
+					},
+					cloud: &ios.CloudInfo{
+						Provider:         ios.CloudProviderGCP,
+						Region:           "us-central1",
+						AvailabilityZone: "us-central1-a",
+						InstanceID:       "4520031799277581759",
+					},
+					components: []string{"exporter:otlp", "receiver:otlp"},
+				},
+			},
+			expected: &protobufs.AgentDescription{
+				IdentifyingAttributes: []*protobufs.KeyValue{
+					opamp.StringKeyValue("service.instance.id", testAgentID.String()),
+					opamp.StringKeyValue("service.name", "com.observiq.collector"),
+					opamp.StringKeyValue("service.version", "v1.2.3"),
+					opamp.StringKeyValue("service.instance.name", "my-linux-box"),
+				},
+				NonIdentifyingAttributes: []*protobufs.KeyValue{
+					opamp.StringKeyValue("os.arch", "amd64"),
+					opamp.StringKeyValue("os.details", "os details"),
+					opamp.StringKeyValue("os.family", "linux"),
+					opamp.StringKeyValue("host.name", "my-linux-box"),
+					opamp.StringKeyValue("host.mac_address", "68-C7-B4-EB-A8-D2"),
+					opamp.IntKeyValue("host.cpu.count", 4),
+					opamp.IntKeyValue("host.memory.total", 8589934592),
+					opamp.StringSliceKeyValue("host.ip", []string{"10.0.0.5", "2001:db8::5"}),
+					opamp.StringKeyValue("host.timezone", "America/Chicago"),
+					opamp.StringKeyValue("container.runtime", "containerd"),
+					opamp.StringKeyValue("k8s.namespace.name", "observability"),
+					opamp.StringKeyValue("k8s.pod.name", "agent-0"),
+					opamp.StringKeyValue("cloud.provider", "gcp"),
+					opamp.StringKeyValue("cloud.region", "us-central1"),
+					opamp.StringKeyValue("cloud.availability_zone", "us-central1-a"),
+					opamp.StringKeyValue("host.id", "4520031799277581759"),
+					opamp.StringSliceKeyValue("service.components", []string{"exporter:otlp", "receiver:otlp"}),
+					opamp.StringKeyValue("service.key", "test-key"),
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -166,9 +290,21 @@ func Test_identityCopy(t *testing.T) {
 		oSFamily:    "linux",
 		hostname:    "my-linux-box",
 		mac:         "68-C7-B4-EB-A8-D2",
+		host: hostInfo{
+			cpuCount:    4,
+			ipAddresses: []string{"10.0.0.5"},
+			cloud:       &ios.CloudInfo{Provider: ios.CloudProviderAWS},
+			components:  []string{"receiver:otlp"},
+		},
 	}
 
 	copyIdent := ident.Copy()
 
 	require.Equal(t, ident, copyIdent)
+
+	// The host inventory must not be shared with the copy
+	copyIdent.host.ipAddresses[0] = "10.0.0.6"
+	copyIdent.host.cloud.Provider = ios.CloudProviderGCP
+	require.Equal(t, "10.0.0.5", ident.host.ipAddresses[0])
+	require.Equal(t, ios.CloudProviderAWS, ident.host.cloud.Provider)
 }
@@ -114,9 +114,19 @@ func (m *measurementsSender) Stop() {
 	close(m.done)
 	m.wg.Wait()
 
+	// Replace the closed channel so the sender can be started again
+	m.done = make(chan struct{})
 	m.isRunning = false
 }
 
+// SetOpAMPClient sets the client messages are sent with. It takes effect the next time the sender is started.
+func (m *measurementsSender) SetOpAMPClient(opampClient client.OpAMPClient) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.opampClient = opampClient
+}
+
 func (m *measurementsSender) loop() {
 	t := newTicker()
 	t.SetInterval(m.interval)
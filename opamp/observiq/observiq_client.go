@@ -22,8 +22,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/observiq/bindplane-otel-collector/collector"
 	"github.com/observiq/bindplane-otel-collector/internal/measurements"
@@ -36,6 +39,7 @@ import (
 	"github.com/open-telemetry/opamp-go/client/types"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 )
 
 var (
@@ -48,7 +52,17 @@ const capabilities = protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus
 	protobufs.AgentCapabilities_AgentCapabilities_ReportsPackageStatuses |
 	protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig |
 	protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig |
-	protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig
+	protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig |
+	protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand |
+	protobufs.AgentCapabilities_AgentCapabilities_AcceptsOpAMPConnectionSettings |
+	protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics |
+	protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnLogs |
+	protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth
+
+// newWebSocketClient creates the OpAMP client for ws and wss endpoints
+var newWebSocketClient = func(logger types.Logger) client.OpAMPClient {
+	return client.NewWebSocket(logger)
+}
 
 // Ensure interface is satisfied
 var _ opamp.Client = (*Client)(nil)
@@ -68,6 +82,14 @@ type Client struct {
 	reportManager           *report.Manager
 	measurementsSender      *measurementsSender
 	topologySender          *topologySender
+	diagnosticsHandler      *diagnosticsHandler
+
+	// configHealthChecker watches the collector after a remote config is applied. Nil if disabled.
+	configHealthChecker *configHealthChecker
+
+	// shutdownCtx is canceled when disconnecting to interrupt a running config health check
+	shutdownCtx    context.Context
+	shutdownCancel context.CancelFunc
 
 	// To signal if we are disconnecting already and not take any actions on connection failures
 	disconnecting bool
@@ -77,7 +99,26 @@ type Client struct {
 	collectorMntrCancel context.CancelFunc
 	collectorMntrWg     sync.WaitGroup
 
-	currentConfig opamp.Config
+	// lastHealth is the health last reported to the server
+	healthMux  sync.Mutex
+	lastHealth *protobufs.ComponentHealth
+
+	// identMux guards the identity while the host inventory is refreshed
+	identMux sync.Mutex
+
+	// connMux guards the OpAMP client and current config, which are replaced
+	// when reconnecting with offered connection settings
+	connMux sync.Mutex
+
+	// Used to refresh the agent description when the host inventory changes
+	descRefreshCancel context.CancelFunc
+	descRefreshWg     sync.WaitGroup
+
+	currentConfig     opamp.Config
+	managerConfigPath string
+
+	// configHistory keeps the applied versions of configs. Nil if not kept.
+	configHistory *opamp.ConfigHistory
 }
 
 // NewClientArgs arguments passed when creating a new client
@@ -100,6 +141,8 @@ func NewClient(args *NewClientArgs) (opamp.Client, error) {
 	clientLogger := args.DefaultLogger.Named("opamp")
 
 	configManager := NewAgentConfigManager(args.DefaultLogger)
+	configHistory := opamp.NewConfigHistory(filepath.Join(filepath.Dir(args.ManagerConfigPath), opamp.ConfigHistoryDirName), opamp.DefaultConfigHistorySize)
+	configManager.SetConfigHistory(configHistory)
 	updaterManger, err := newUpdaterManager(clientLogger, args.TmpPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create updaterManager: %w", err)
@@ -121,13 +164,17 @@ func NewClient(args *NewClientArgs) (opamp.Client, error) {
 		logger:                  clientLogger,
 		ident:                   newIdentity(clientLogger, args.Config, args.Version),
 		configManager:           configManager,
-		downloadableFileManager: newDownloadableFileManager(clientLogger, args.TmpPath),
+		downloadableFileManager: newDownloadableFileManager(clientLogger, args.TmpPath, args.Config.PackageSigningKeys, args.Config.PackageDownload),
 		collector:               args.Collector,
 		currentConfig:           args.Config,
 		packagesStateProvider:   newPackagesStateProvider(clientLogger, packagestate.DefaultFileName),
 		updaterManager:          updaterManger,
 		reportManager:           reportManager,
+		configHealthChecker:     newConfigHealthChecker(clientLogger, args.Collector, args.Config.ConfigHealthCheck),
+		managerConfigPath:       args.ManagerConfigPath,
+		configHistory:           configHistory,
 	}
+	observiqClient.shutdownCtx, observiqClient.shutdownCancel = context.WithCancel(context.Background())
 
 	// Parse URL to determin scheme
 	opampURL, err := url.Parse(args.Config.Endpoint)
@@ -141,22 +188,9 @@ func NewClient(args *NewClientArgs) (opamp.Client, error) {
 	}
 
 	// Create collect client based on URL scheme
-	switch opampURL.Scheme {
-	case "ws", "wss":
-		logger := newZapOpAMPLoggerAdapter(clientLogger)
-		observiqClient.opampClient = client.NewWebSocket(logger)
-	default:
-		return nil, ErrUnsupportedURL
-	}
-
-	err = observiqClient.opampClient.SetCustomCapabilities(&protobufs.CustomCapabilities{
-		Capabilities: []string{
-			measurements.ReportMeasurementsV1Capability,
-			topology.ReportTopologyCapability,
-		},
-	})
+	observiqClient.opampClient, err = newOpAMPClient(clientLogger, opampURL, args.Config.PollingInterval)
 	if err != nil {
-		return nil, fmt.Errorf("error setting custom capabilities: %w", err)
+		return nil, err
 	}
 
 	// Create measurements sender
@@ -175,9 +209,47 @@ func NewClient(args *NewClientArgs) (opamp.Client, error) {
 		observiqClient.opampClient,
 	)
 
+	// Create diagnostics handler
+	observiqClient.diagnosticsHandler = newDiagnosticsHandler(
+		clientLogger,
+		observiqClient.opampClient,
+		configManager,
+	)
+
 	return observiqClient, nil
 }
 
+// newOpAMPClient creates an OpAMP client for the URL's scheme with the agent's custom capabilities.
+// The http and https schemes poll the server at the polling interval, or the client's default if zero.
+func newOpAMPClient(logger *zap.Logger, opampURL *url.URL, pollingInterval time.Duration) (client.OpAMPClient, error) {
+	var opampClient client.OpAMPClient
+	switch opampURL.Scheme {
+	case "ws", "wss":
+		opampClient = newWebSocketClient(newZapOpAMPLoggerAdapter(logger))
+	case "http", "https":
+		httpClient := client.NewHTTP(newZapOpAMPLoggerAdapter(logger))
+		if pollingInterval > 0 {
+			httpClient.SetPollingInterval(pollingInterval)
+		}
+		opampClient = httpClient
+	default:
+		return nil, ErrUnsupportedURL
+	}
+
+	err := opampClient.SetCustomCapabilities(&protobufs.CustomCapabilities{
+		Capabilities: []string{
+			measurements.ReportMeasurementsV1Capability,
+			topology.ReportTopologyCapability,
+			diagnosticsCapability,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error setting custom capabilities: %w", err)
+	}
+
+	return opampClient, nil
+}
+
 func (c *Client) addManagedConfigs(args *NewClientArgs) error {
 	// Add configs to config manager
 	managerManagedConfig, err := opamp.NewManagedConfig(args.ManagerConfigPath, managerReload(c, args.ManagerConfigPath), true)
@@ -209,8 +281,10 @@ func (c *Client) addManagedConfigs(args *NewClientArgs) error {
 
 // Connect initiates a connection to the OpAmp server
 func (c *Client) Connect(ctx context.Context) error {
+	cfg := c.safeGetCurrentConfig()
+
 	// Compose and set the agent description
-	if err := c.opampClient.SetAgentDescription(c.ident.ToAgentDescription()); err != nil {
+	if err := c.safeGetOpAMPClient().SetAgentDescription(c.ident.ToAgentDescription()); err != nil {
 		c.logger.Error("Error while setting agent description", zap.Error(err))
 
 		// Set package status file for error (for Updater to pick up), but do not force send to Server
@@ -219,7 +293,7 @@ func (c *Client) Connect(ctx context.Context) error {
 		return err
 	}
 
-	tlsCfg, err := c.currentConfig.ToTLS(nil)
+	settings, err := c.startSettings(cfg)
 	if err != nil {
 		// Set package status file for error (for Updater to pick up), but do not force send to Server
 		c.tryToFailPackageInstall(fmt.Sprintf("Failed creating TLS config: %s", err.Error()), false)
@@ -227,33 +301,16 @@ func (c *Client) Connect(ctx context.Context) error {
 		return fmt.Errorf("failed creating TLS config: %w", err)
 	}
 
-	settings := types.StartSettings{
-		OpAMPServerURL: c.currentConfig.Endpoint,
-		Header: http.Header{
-			"Authorization":               []string{fmt.Sprintf("Secret-Key %s", c.currentConfig.GetSecretKey())},
-			"User-Agent":                  []string{fmt.Sprintf("observiq-otel-collector/%s", version.Version())},
-			"OpAMP-Version":               []string{opamp.Version()},
-			"Agent-ID":                    []string{c.ident.agentID.String()},
-			"Agent-Version":               []string{version.Version()},
-			"Agent-Hostname":              []string{c.ident.hostname},
-			"X-Bindplane-Agent-Id-Format": []string{c.ident.agentID.Type()},
-		},
-		TLSConfig:   tlsCfg,
-		InstanceUid: c.ident.agentID.OpAMPInstanceUID(),
-		Callbacks: types.CallbacksStruct{
-			OnConnectFunc:          c.onConnectHandler,
-			OnConnectFailedFunc:    c.onConnectFailedHandler,
-			OnErrorFunc:            c.onErrorHandler,
-			OnMessageFunc:          c.onMessageFuncHandler,
-			GetEffectiveConfigFunc: c.onGetEffectiveConfigHandler,
-			// Unimplemented handlers
-			// OnOpampConnectionSettingsFunc
-			// OnOpampConnectionSettingsAcceptedFunc
-			// OnCommandFunc
-			// SaveRemoteConfigStatusFunc
-		},
-		PackagesStateProvider: c.packagesStateProvider,
-		Capabilities:          capabilities,
+	if err := configureTransport(&settings, cfg); err != nil {
+		// Set package status file for error (for Updater to pick up), but do not force send to Server
+		c.tryToFailPackageInstall(fmt.Sprintf("Failed configuring proxy: %s", err.Error()), false)
+
+		return fmt.Errorf("failed configuring proxy: %w", err)
+	}
+
+	// Send the collector's own telemetry where the server last offered
+	if cfg.OwnMetrics != nil || cfg.OwnLogs != nil {
+		c.collector.SetOwnTelemetry(toTelemetryDestination(cfg.OwnMetrics), toTelemetryDestination(cfg.OwnLogs))
 	}
 
 	// Start the embedded collector
@@ -269,23 +326,95 @@ func (c *Client) Connect(ctx context.Context) error {
 	// Now that collector has successfully started kick off monitoring
 	c.startCollectorMonitoring(ctx)
 
-	err = c.opampClient.Start(ctx, settings)
+	// Health must be set before starting the client as it reports health
+	c.reportHealth(nil)
+
+	err = c.safeGetOpAMPClient().Start(ctx, settings)
 	if err != nil {
 		// Set package status file for error (for Updater to pick up), but do not force send to Server
 		c.tryToFailPackageInstall(fmt.Sprintf("OpAMP client failed to start: %s", err.Error()), false)
+		return err
+	}
+
+	c.startAgentDescriptionRefresh(ctx)
+
+	return nil
+}
+
+// startSettings returns the settings used to start the OpAMP client with the config.
+// An error is only returned if the TLS config is invalid.
+func (c *Client) startSettings(cfg opamp.Config) (types.StartSettings, error) {
+	tlsCfg, err := cfg.ToTLS(nil)
+	if err != nil {
+		return types.StartSettings{}, err
 	}
 
-	return err
+	return types.StartSettings{
+		OpAMPServerURL: cfg.Endpoint,
+		Header:         c.connectionHeader(cfg),
+		TLSConfig:      tlsCfg,
+		InstanceUid:    c.ident.agentID.OpAMPInstanceUID(),
+		Callbacks: types.CallbacksStruct{
+			OnConnectFunc:                 c.onConnectHandler,
+			OnConnectFailedFunc:           c.onConnectFailedHandler,
+			OnErrorFunc:                   c.onErrorHandler,
+			OnMessageFunc:                 c.onMessageFuncHandler,
+			GetEffectiveConfigFunc:        c.onGetEffectiveConfigHandler,
+			OnCommandFunc:                 c.onCommandHandler,
+			OnOpampConnectionSettingsFunc: c.onOpampConnectionSettingsHandler,
+			// Unimplemented handlers
+			// OnOpampConnectionSettingsAcceptedFunc
+			// SaveRemoteConfigStatusFunc
+		},
+		PackagesStateProvider: c.packagesStateProvider,
+		Capabilities:          capabilities,
+	}, nil
+}
+
+// connectionHeader returns the headers sent when connecting to the server with the config.
+// The config's additional headers can't replace the agent's own headers.
+func (c *Client) connectionHeader(cfg opamp.Config) http.Header {
+	header := http.Header{
+		"Authorization":               []string{fmt.Sprintf("Secret-Key %s", cfg.GetSecretKey())},
+		"User-Agent":                  []string{fmt.Sprintf("observiq-otel-collector/%s", version.Version())},
+		"OpAMP-Version":               []string{opamp.Version()},
+		"Agent-ID":                    []string{c.ident.agentID.String()},
+		"Agent-Version":               []string{version.Version()},
+		"Agent-Hostname":              []string{c.ident.hostname},
+		"X-Bindplane-Agent-Id-Format": []string{c.ident.agentID.Type()},
+	}
+
+	agentHeaders := make([]string, 0, len(header))
+	for key := range header {
+		agentHeaders = append(agentHeaders, key)
+	}
+
+	for key, value := range cfg.Headers {
+		reserved := slices.ContainsFunc(agentHeaders, func(k string) bool {
+			return strings.EqualFold(k, key)
+		})
+		if reserved {
+			c.logger.Warn("Ignoring configured header that replaces an agent header", zap.String("header", key))
+			continue
+		}
+		header.Set(key, value)
+	}
+
+	return header
 }
 
 // Disconnect disconnects from the server
 func (c *Client) Disconnect(ctx context.Context) error {
 	// Ensure we're no longer monitoring the collector as we shutdown to avoid error messages due to shutdown
 	c.stopCollectorMonitoring()
+	c.stopAgentDescriptionRefresh()
+	if c.shutdownCancel != nil {
+		c.shutdownCancel()
+	}
 
 	c.safeSetDisconnecting(true)
 	c.collector.Stop(ctx)
-	return c.opampClient.Stop(ctx)
+	return c.safeGetOpAMPClient().Stop(ctx)
 }
 
 // client callbacks
@@ -369,6 +498,38 @@ func (c *Client) onMessageFuncHandler(ctx context.Context, msg *types.MessageDat
 			c.topologySender.Stop()
 		}
 	}
+	if msg.OwnMetricsConnSettings != nil || msg.OwnLogsConnSettings != nil {
+		if err := c.onOwnTelemetryConnectionSettings(msg.OwnMetricsConnSettings, msg.OwnLogsConnSettings); err != nil {
+			c.logger.Error("Error while processing own telemetry connection settings", zap.Error(err))
+		}
+	}
+	if msg.CustomMessage != nil && msg.CustomMessage.GetCapability() == diagnosticsCapability {
+		// Collecting profiles can take a moment so don't block other messages
+		go c.diagnosticsHandler.Handle(msg.CustomMessage)
+	}
+}
+
+func (c *Client) onCommandHandler(_ context.Context, command *protobufs.ServerToAgentCommand) error {
+	switch command.GetType() {
+	case protobufs.CommandType_CommandType_Restart:
+		c.logger.Info("Received restart command from server")
+
+		// Stop collector monitoring as we are going to restart it
+		c.stopCollectorMonitoring()
+		defer c.startCollectorMonitoring(context.Background())
+
+		// Use the background context as the collector runs past this callback
+		if err := c.collector.Restart(context.Background()); err != nil {
+			c.logger.Error("Collector failed to restart", zap.Error(err))
+			return fmt.Errorf("collector failed to restart: %w", err)
+		}
+		c.logger.Info("Collector restarted")
+
+		return nil
+	default:
+		c.logger.Warn("Unsupported command received", zap.String("type", command.GetType().String()))
+		return fmt.Errorf("unsupported command type: %s", command.GetType())
+	}
 }
 
 func (c *Client) onRemoteConfigHandler(ctx context.Context, remoteConfig *protobufs.AgentRemoteConfig) error {
@@ -390,14 +551,14 @@ func (c *Client) onRemoteConfigHandler(ctx context.Context, remoteConfig *protob
 
 	c.logger.Info("Setting remote config status", zap.String("status", remoteCfgStatus.Status.String()))
 	// Set the remote config status
-	if err := c.opampClient.SetRemoteConfigStatus(remoteCfgStatus); err != nil {
+	if err := c.safeGetOpAMPClient().SetRemoteConfigStatus(remoteCfgStatus); err != nil {
 		return fmt.Errorf("failed to set remote config status: %w", err)
 	}
 
 	// If we changed the config call UpdateEffectiveConfig
 	if changed {
 		c.logger.Info("Updating effective config")
-		if err := c.opampClient.UpdateEffectiveConfig(ctx); err != nil {
+		if err := c.safeGetOpAMPClient().UpdateEffectiveConfig(ctx); err != nil {
 			return fmt.Errorf("failed to update effective config: %w", err)
 		}
 	}
@@ -423,7 +584,7 @@ func (c *Client) onPackagesAvailableHandler(availablePkgs *protobufs.PackagesAva
 
 		curPkgStatuses.ErrorMessage = "Already installing new packages"
 		// Dont' actually set the on file package statuses because we want to ignore this
-		if err := c.opampClient.SetPackageStatuses(curPkgStatuses); err != nil {
+		if err := c.safeGetOpAMPClient().SetPackageStatuses(curPkgStatuses); err != nil {
 			c.logger.Error("OpAMP client failed to set already installing package statuses", zap.Error(err))
 		}
 		return errors.New("failed because already installing packages")
@@ -453,7 +614,7 @@ func (c *Client) onPackagesAvailableHandler(availablePkgs *protobufs.PackagesAva
 		return fmt.Errorf("failed to save last reported package statuses: %w", err)
 	}
 
-	if err = c.opampClient.SetPackageStatuses(curPkgStatuses); err != nil {
+	if err = c.safeGetOpAMPClient().SetPackageStatuses(curPkgStatuses); err != nil {
 		return fmt.Errorf("opamp client failed to set package statuses: %w", err)
 	}
 
@@ -636,7 +797,7 @@ func (c *Client) failPackageInstall(pkgStatuses *protobufs.PackageStatuses, errM
 
 	// Only send status to Server if this is set. Otherwise it will happen after collector is restarted
 	if sendStatusNow {
-		if err := c.opampClient.SetPackageStatuses(pkgStatuses); err != nil {
+		if err := c.safeGetOpAMPClient().SetPackageStatuses(pkgStatuses); err != nil {
 			c.logger.Error("OpAMP client failed to set failed install package statuses", zap.Error(err))
 		}
 	}
@@ -668,7 +829,7 @@ func (c *Client) finishPackageInstall(pkgStatuses *protobufs.PackageStatuses) {
 		c.logger.Error("Failed to set last reported package statuses", zap.Error(err))
 	}
 
-	if err := c.opampClient.SetPackageStatuses(pkgStatuses); err != nil {
+	if err := c.safeGetOpAMPClient().SetPackageStatuses(pkgStatuses); err != nil {
 		c.logger.Error("OpAMP client failed to set package statuses", zap.Error(err))
 	}
 }
@@ -702,26 +863,92 @@ func (c *Client) startCollectorMonitoring(ctx context.Context) {
 	go c.monitorCollectorStatus()
 }
 
-// monitorCollectorStatus monitors the status of the collector after startup
+// monitorCollectorStatus monitors the status of the collector after startup and reports its health when it changes
 func (c *Client) monitorCollectorStatus() {
 	defer c.collectorMntrWg.Done()
 	statusChan := c.collector.Status()
-	select {
-	case status := <-statusChan:
-		switch {
-		case status.Panicked:
-			// Currently we can't recover from this so we should log a message and exit with an error code.
-			// No need to cleanup on shutdown as if no state is left over that would prevent a new process from starting.
-			c.logger.Fatal("Collector encountered unrecoverable error", zap.Error(status.Err))
-		case status.Err != nil:
-			c.logger.Error("Collector unexpectedly stopped running", zap.Error(status.Err))
-		case !status.Running:
-			c.logger.Error("Collector unexpectedly stopped running")
+
+	ticker := time.NewTicker(healthReportInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case status := <-statusChan:
+			switch {
+			case status.Panicked:
+				// Currently we can't recover from this so we should log a message and exit with an error code.
+				// No need to cleanup on shutdown as if no state is left over that would prevent a new process from starting.
+				c.logger.Fatal("Collector encountered unrecoverable error", zap.Error(status.Err))
+			case status.Err != nil:
+				c.logger.Error("Collector unexpectedly stopped running", zap.Error(status.Err))
+				c.reportHealth(status.Err)
+				return
+			case !status.Running:
+				c.logger.Error("Collector unexpectedly stopped running")
+				c.reportHealth(errCollectorNotRunning)
+				return
+			}
+		case <-ticker.C:
+			c.reportHealth(nil)
+		case <-c.collectorMntrCtx.Done():
+			c.logger.Debug("collector monitor context closed")
+			return
+		}
+	}
+}
+
+// startAgentDescriptionRefresh starts a separate goroutine to periodically refresh the host inventory
+func (c *Client) startAgentDescriptionRefresh(ctx context.Context) {
+	refreshCtx, cancel := context.WithCancel(ctx)
+	c.descRefreshCancel = cancel
+	c.descRefreshWg.Add(1)
+
+	ticker := time.NewTicker(agentDescriptionRefreshInterval)
+	go func() {
+		defer c.descRefreshWg.Done()
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.refreshAgentDescription()
+			case <-refreshCtx.Done():
+				return
+			}
 		}
-	case <-c.collectorMntrCtx.Done():
-		c.logger.Debug("collector monitor context closed")
+	}()
+}
+
+// stopAgentDescriptionRefresh stops refreshing the host inventory
+func (c *Client) stopAgentDescriptionRefresh() {
+	if c.descRefreshCancel == nil {
+		return
+	}
+	c.descRefreshCancel()
+	c.descRefreshWg.Wait()
+}
+
+// refreshAgentDescription gathers the host inventory and sends the agent description to the server if it changed
+func (c *Client) refreshAgentDescription() {
+	host := newHostInfo(c.logger)
+
+	c.identMux.Lock()
+	defer c.identMux.Unlock()
+
+	updated := c.ident.Copy()
+	updated.host = host
+	agentDesc := updated.ToAgentDescription()
+	if proto.Equal(agentDesc, c.ident.ToAgentDescription()) {
 		return
 	}
+
+	if err := c.safeGetOpAMPClient().SetAgentDescription(agentDesc); err != nil {
+		c.logger.Warn("Failed to update agent description", zap.Error(err))
+		return
+	}
+
+	c.ident.host = host
+	c.logger.Info("Host inventory changed, updated agent description")
 }
 
 func (c *Client) safeSetUpdatingPackage(value bool) {
@@ -747,3 +974,28 @@ func (c *Client) safeGetDisconnecting() bool {
 	defer c.mutex.Unlock()
 	return c.disconnecting
 }
+
+func (c *Client) safeSetOpAMPClient(opampClient client.OpAMPClient) {
+	c.connMux.Lock()
+	defer c.connMux.Unlock()
+	c.opampClient = opampClient
+}
+
+func (c *Client) safeGetOpAMPClient() client.OpAMPClient {
+	c.connMux.Lock()
+	defer c.connMux.Unlock()
+	return c.opampClient
+}
+
+func (c *Client) safeSetCurrentConfig(cfg opamp.Config) {
+	c.connMux.Lock()
+	defer c.connMux.Unlock()
+	c.currentConfig = cfg
+}
+
+// safeGetCurrentConfig returns a copy of the current config that is safe to modify
+func (c *Client) safeGetCurrentConfig() opamp.Config {
+	c.connMux.Lock()
+	defer c.connMux.Unlock()
+	return *c.currentConfig.Copy()
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/observiq/bindplane-otel-collector/collector"
 	colmocks "github.com/observiq/bindplane-otel-collector/collector/mocks"
+	ios "github.com/observiq/bindplane-otel-collector/internal/os"
 	"github.com/observiq/bindplane-otel-collector/internal/report"
 	"github.com/observiq/bindplane-otel-collector/internal/version"
 	"github.com/observiq/bindplane-otel-collector/opamp"
@@ -51,7 +52,7 @@ func TestNewClient(t *testing.T) {
 		{
 			desc: "Bad URL Scheme",
 			config: opamp.Config{
-				Endpoint: "http://localhost:1234",
+				Endpoint: "tcp://localhost:1234",
 				AgentID:  testAgentID,
 			},
 			expectedErr: ErrUnsupportedURL,
@@ -84,6 +85,16 @@ func TestNewClient(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		{
+			desc: "Valid HTTP Polling Config",
+			config: opamp.Config{
+				Endpoint:        "https://localhost:1234/v1/opamp",
+				AgentID:         testAgentID,
+				SecretKey:       &secretKey,
+				PollingInterval: 10 * time.Second,
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -330,6 +341,7 @@ func TestClientConnect(t *testing.T) {
 				mockOpAmpClient := mocks.NewMockOpAMPClient(t)
 				mockOpAmpClient.On("SetAgentDescription", mock.Anything).Return(nil)
 				mockOpAmpClient.On("Start", mock.Anything, mock.Anything).Return(expectedErr)
+				mockOpAmpClient.On("SetHealth", mock.Anything).Return(nil)
 				mockStateProvider := new(mocks.MockPackagesStateProvider)
 				mockStateProvider.On("LastReportedStatuses").Return(packageStatuses, nil)
 				mockStateProvider.On("SetLastReportedStatuses", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
@@ -342,6 +354,7 @@ func TestClientConnect(t *testing.T) {
 				mockCollector := colmocks.NewMockCollector(t)
 				mockCollector.On("Run", mock.Anything).Return(nil)
 				mockCollector.On("Status").Return((<-chan *collector.Status)(statusChannel))
+				mockCollector.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{})
 
 				c := &Client{
 					opampClient:   mockOpAmpClient,
@@ -372,11 +385,17 @@ func TestClientConnect(t *testing.T) {
 			testFunc: func(*testing.T) {
 				mockOpAmpClient := mocks.NewMockOpAMPClient(t)
 				mockOpAmpClient.On("SetAgentDescription", mock.Anything).Return(nil)
+				mockOpAmpClient.On("SetHealth", &protobufs.ComponentHealth{
+					Healthy:            true,
+					Status:             "StatusOK",
+					ComponentHealthMap: map[string]*protobufs.ComponentHealth{},
+				}).Return(nil)
 
 				statusChannel := make(chan *collector.Status)
 				mockCollector := colmocks.NewMockCollector(t)
 				mockCollector.On("Run", mock.Anything).Return(nil)
 				mockCollector.On("Status").Return((<-chan *collector.Status)(statusChannel))
+				mockCollector.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{})
 
 				mockPackagesStateProvider := mocks.NewMockPackagesStateProvider(t)
 
@@ -862,6 +881,64 @@ func TestClient_onGetEffectiveConfigHandler(t *testing.T) {
 	mockManager.AssertExpectations(t)
 }
 
+func TestClient_onCommandHandler(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		command     *protobufs.ServerToAgentCommand
+		setupMocks  func(*colmocks.MockCollector)
+		expectedErr string
+	}{
+		{
+			desc:    "Restart",
+			command: &protobufs.ServerToAgentCommand{Type: protobufs.CommandType_CommandType_Restart},
+			setupMocks: func(m *colmocks.MockCollector) {
+				m.On("Restart", mock.Anything).Return(nil).Once()
+				m.On("Status").Return((<-chan *collector.Status)(make(chan *collector.Status)))
+			},
+		},
+		{
+			desc:    "Restart fails",
+			command: &protobufs.ServerToAgentCommand{Type: protobufs.CommandType_CommandType_Restart},
+			setupMocks: func(m *colmocks.MockCollector) {
+				m.On("Restart", mock.Anything).Return(errors.New("oops")).Once()
+				m.On("Status").Return((<-chan *collector.Status)(make(chan *collector.Status)))
+			},
+			expectedErr: "collector failed to restart: oops",
+		},
+		{
+			desc:        "Unsupported command",
+			command:     &protobufs.ServerToAgentCommand{Type: protobufs.CommandType(99)},
+			expectedErr: "unsupported command type: 99",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mockCollector := colmocks.NewMockCollector(t)
+			if tc.setupMocks != nil {
+				tc.setupMocks(mockCollector)
+			}
+
+			c := &Client{
+				logger:    zap.NewNop(),
+				collector: mockCollector,
+			}
+
+			// Setup Context to mock out already running collector monitor
+			c.collectorMntrCtx, c.collectorMntrCancel = context.WithCancel(context.Background())
+
+			err := c.onCommandHandler(context.Background(), tc.command)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			c.stopCollectorMonitoring()
+		})
+	}
+}
+
 func TestClient_onRemoteConfigHandler(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -1546,3 +1623,109 @@ func TestClient_onPackagesAvailableHandler(t *testing.T) {
 		t.Run(tc.desc, tc.testFunc)
 	}
 }
+
+func TestClient_refreshAgentDescription(t *testing.T) {
+	cloud := &ios.CloudInfo{
+		Provider:         ios.CloudProviderAzure,
+		Region:           "westus",
+		AvailabilityZone: "1",
+		InstanceID:       "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+	}
+	setDetectCloud(t, func(context.Context) (*ios.CloudInfo, error) {
+		return cloud, nil
+	})
+
+	testCases := []struct {
+		desc     string
+		testFunc func(*testing.T)
+	}{
+		{
+			desc: "Host inventory unchanged",
+			testFunc: func(t *testing.T) {
+				mockOpAmpClient := mocks.NewMockOpAMPClient(t)
+				c := &Client{
+					opampClient: mockOpAmpClient,
+					logger:      zap.NewNop(),
+					ident:       &identity{agentID: testAgentID, host: newHostInfo(zap.NewNop())},
+				}
+
+				c.refreshAgentDescription()
+				mockOpAmpClient.AssertNotCalled(t, "SetAgentDescription", mock.Anything)
+			},
+		},
+		{
+			desc: "Host inventory changed",
+			testFunc: func(t *testing.T) {
+				mockOpAmpClient := mocks.NewMockOpAMPClient(t)
+				c := &Client{
+					opampClient: mockOpAmpClient,
+					logger:      zap.NewNop(),
+					ident:       &identity{agentID: testAgentID},
+				}
+
+				var sent *protobufs.AgentDescription
+				mockOpAmpClient.On("SetAgentDescription", mock.Anything).Run(func(args mock.Arguments) {
+					sent = args.Get(0).(*protobufs.AgentDescription)
+				}).Return(nil)
+
+				c.refreshAgentDescription()
+				require.Equal(t, cloud, c.ident.host.cloud)
+				var provider string
+				for _, kv := range sent.NonIdentifyingAttributes {
+					if kv.GetKey() == "cloud.provider" {
+						provider = kv.GetValue().GetStringValue()
+					}
+				}
+				require.Equal(t, ios.CloudProviderAzure, provider)
+			},
+		},
+		{
+			desc: "Failed to set agent description",
+			testFunc: func(t *testing.T) {
+				mockOpAmpClient := mocks.NewMockOpAMPClient(t)
+				c := &Client{
+					opampClient: mockOpAmpClient,
+					logger:      zap.NewNop(),
+					ident:       &identity{agentID: testAgentID},
+				}
+
+				mockOpAmpClient.On("SetAgentDescription", mock.Anything).Return(errors.New("oops"))
+
+				c.refreshAgentDescription()
+				require.Equal(t, hostInfo{}, c.ident.host)
+			},
+		},
+		{
+			desc: "Refreshes periodically until stopped",
+			testFunc: func(t *testing.T) {
+				original := agentDescriptionRefreshInterval
+				agentDescriptionRefreshInterval = 10 * time.Millisecond
+				t.Cleanup(func() { agentDescriptionRefreshInterval = original })
+
+				mockOpAmpClient := mocks.NewMockOpAMPClient(t)
+				c := &Client{
+					opampClient: mockOpAmpClient,
+					logger:      zap.NewNop(),
+					ident:       &identity{agentID: testAgentID},
+				}
+
+				refreshed := make(chan struct{})
+				mockOpAmpClient.On("SetAgentDescription", mock.Anything).Run(func(mock.Arguments) {
+					close(refreshed)
+				}).Return(nil).Once()
+
+				c.startAgentDescriptionRefresh(context.Background())
+				select {
+				case <-refreshed:
+				case <-time.After(5 * time.Second):
+					t.Fatal("agent description was not refreshed")
+				}
+				c.stopAgentDescriptionRefresh()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, tc.testFunc)
+	}
+}
@@ -18,45 +18,91 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"context"
 	"crypto/sha256"
 	"crypto/subtle"
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/observiq/bindplane-otel-collector/signature"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 const extractFolder = "latest"
 const maxArchiveObjectByteSize = 1000000000
 
+// defaultMaxDownloadSize is the largest package downloaded when no max size is configured
+const defaultMaxDownloadSize = 2000000000
+
+// maxDownloadAttempts is how many times a download is tried before giving up
+const maxDownloadAttempts = 5
+
+// maxRateLimitBurst is the most bytes read at once when the download is rate limited
+const maxRateLimitBurst = 256 * 1024
+
+// downloadRetryDelay is multiplied by the attempt number to get the wait before resuming a download
+var downloadRetryDelay = time.Second
+
+// downloadResponseHeaderTimeout is how long to wait for the response headers of a download request
+var downloadResponseHeaderTimeout = 30 * time.Second
+
+// downloadIdleTimeout is how long a download may go without receiving any data before it is interrupted
+var downloadIdleTimeout = 30 * time.Second
+
 // Ensure interface is satisfied
 var _ opamp.DownloadableFileManager = (*DownloadableFileManager)(nil)
 
 // DownloadableFileManager handles DownloadableFile's from a PackagesAvailable message
 type DownloadableFileManager struct {
-	tmpPath string
-	logger  *zap.Logger
+	tmpPath     string
+	signingKeys []string
+	downloadCfg opamp.PackageDownloadConfig
+	client      *http.Client
+	logger      *zap.Logger
 }
 
-// newDownloadableFileManager creates a new OpAmp DownloadableFileManager
-func newDownloadableFileManager(logger *zap.Logger, tmpPath string) *DownloadableFileManager {
-	return &DownloadableFileManager{
-		tmpPath: filepath.Clean(tmpPath),
-		logger:  logger,
+// newDownloadableFileManager creates a new OpAmp DownloadableFileManager.
+// Archives must be signed by the release key or one of the keys at signingKeys.
+// Downloads use the defaults if downloadCfg is nil.
+func newDownloadableFileManager(logger *zap.Logger, tmpPath string, signingKeys []string, downloadCfg *opamp.PackageDownloadConfig) *DownloadableFileManager {
+	m := &DownloadableFileManager{
+		tmpPath:     filepath.Clean(tmpPath),
+		signingKeys: signingKeys,
+		client:      newDownloadClient(),
+		logger:      logger,
+	}
+	if downloadCfg != nil {
+		m.downloadCfg = *downloadCfg
 	}
+	return m
+}
+
+// newDownloadClient returns the client packages are downloaded with.
+// The client has no overall timeout as packages can be large, so a stalled transfer is caught by the
+// response header timeout and the idle timeout on the body instead.
+func newDownloadClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = downloadResponseHeaderTimeout
+	return &http.Client{Transport: transport}
 }
 
 // FetchAndExtractArchive fetches the archive at the specified URL, placing it into dir.
-// It then checks to see if it matches the "expectedHash", a hex-encoded string representing the expected sha256 sum of the file.
-// If it matches, the archive is extracted into the $dir/latest directory.
+// It then checks to see if it matches the "expectedHash", a hex-encoded string representing the expected sha256 sum of the file,
+// and that its detached signature was made by a trusted key.
+// If both match, the archive is extracted into the $dir/latest directory.
 // If the archive cannot be extracted, downloaded, or verified, then an error is returned.
 func (m DownloadableFileManager) FetchAndExtractArchive(file *protobufs.DownloadableFile) error {
 	archiveFilePath, err := getOutputFilePath(m.tmpPath, file.GetDownloadUrl())
@@ -64,6 +110,8 @@ func (m DownloadableFileManager) FetchAndExtractArchive(file *protobufs.Download
 		return fmt.Errorf("failed to determine archive download path: %w", err)
 	}
 
+	m.waitForStartDelay()
+
 	if err := m.downloadFile(file.GetDownloadUrl(), archiveFilePath); err != nil {
 		return fmt.Errorf("failed to download file: %w", err)
 	}
@@ -74,6 +122,10 @@ func (m DownloadableFileManager) FetchAndExtractArchive(file *protobufs.Download
 		return fmt.Errorf("content hash could not be verified: %w", err)
 	}
 
+	if err := m.verifySignature(archiveFilePath, file.GetSignature()); err != nil {
+		return fmt.Errorf("signature could not be verified: %w", err)
+	}
+
 	// Clean the "latest" dir before extraction
 	if err := os.RemoveAll(extractPath); err != nil {
 		return fmt.Errorf("error cleaning archive extraction target path: %w", err)
@@ -86,23 +138,28 @@ func (m DownloadableFileManager) FetchAndExtractArchive(file *protobufs.Download
 	return nil
 }
 
-// Downloads the file into the outPath, truncating the file if it already exists
+// downloadFile downloads the file into the outPath, truncating the file if it already exists.
+// If a site-local mirror is configured the file is fetched from it first, falling back to downloadURL.
 func (m DownloadableFileManager) downloadFile(downloadURL string, outPath string) error {
-	//#nosec G107 HTTP request must be dynamic based on input
-	resp, err := http.Get(downloadURL)
+	mirrorURL, err := m.downloadCfg.MirrorDownloadURL(downloadURL)
 	if err != nil {
-		return fmt.Errorf("could not GET url: %w", err)
+		return err
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			m.logger.Warn("Failed to close response body while downloading file", zap.String("URL", downloadURL), zap.Error(err))
-		}
-	}()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("got non-200 status code (%d)", resp.StatusCode)
+	if mirrorURL != "" {
+		err := m.downloadFileFrom(mirrorURL, outPath)
+		if err == nil {
+			return nil
+		}
+		m.logger.Warn("Failed to download file from mirror, falling back to download URL", zap.String("URL", mirrorURL), zap.Error(err))
 	}
 
+	return m.downloadFileFrom(downloadURL, outPath)
+}
+
+// downloadFileFrom downloads the file into the outPath.
+// Interrupted transfers are resumed with range requests, up to maxDownloadAttempts times.
+func (m DownloadableFileManager) downloadFileFrom(downloadURL string, outPath string) error {
 	outPathClean := filepath.Clean(outPath)
 	f, err := os.OpenFile(outPathClean, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0600)
 	if err != nil {
@@ -115,11 +172,211 @@ func (m DownloadableFileManager) downloadFile(downloadURL string, outPath string
 		}
 	}()
 
-	if _, err = io.Copy(f, resp.Body); err != nil {
-		return fmt.Errorf("failed to copy request body to file: %w", err)
+	var offset int64
+	var retry bool
+	for attempt := 1; ; attempt++ {
+		offset, retry, err = m.downloadRange(downloadURL, f, offset)
+		if err == nil {
+			return nil
+		}
+
+		if !retry || attempt >= maxDownloadAttempts {
+			return err
+		}
+
+		m.logger.Warn("Download interrupted, resuming", zap.String("URL", downloadURL), zap.Int64("offset", offset), zap.Error(err))
+		time.Sleep(downloadRetryDelay * time.Duration(attempt))
 	}
+}
 
-	return nil
+// downloadRange downloads the file from offset onward and writes it into f, returning the size of the file written so far.
+// retry is true if the download failed in a way that can be resumed.
+func (m DownloadableFileManager) downloadRange(downloadURL string, f *os.File, offset int64) (size int64, retry bool, err error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return offset, false, fmt.Errorf("could not GET url: %w", err)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	// Transport errors such as a dropped connection or a timeout may succeed on another attempt
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return offset, true, fmt.Errorf("could not GET url: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			m.logger.Warn("Failed to close response body while downloading file", zap.String("URL", downloadURL), zap.Error(err))
+		}
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		// Resuming, keep what was already written as long as the range starts where it left off
+		contentRange := resp.Header.Get("Content-Range")
+		if start, ok := contentRangeStart(contentRange); !ok || start != offset {
+			if err := f.Truncate(0); err != nil {
+				return offset, false, fmt.Errorf("failed to truncate file: %w", err)
+			}
+			return 0, true, fmt.Errorf("partial content range %q does not start at offset %d", contentRange, offset)
+		}
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		// The server sent the whole file, start over
+		if offset > 0 {
+			m.logger.Debug("Server does not support range requests, restarting download", zap.String("URL", downloadURL))
+		}
+		if err := f.Truncate(0); err != nil {
+			return offset, false, fmt.Errorf("failed to truncate file: %w", err)
+		}
+		offset = 0
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return offset, true, fmt.Errorf("got non-200 status code (%d)", resp.StatusCode)
+	default:
+		return offset, false, fmt.Errorf("got non-200 status code (%d)", resp.StatusCode)
+	}
+
+	maxSize := m.maxDownloadSize()
+	if resp.ContentLength > 0 && offset+resp.ContentLength > maxSize {
+		return offset, false, fmt.Errorf("file size %d exceeds the max download size of %d bytes", offset+resp.ContentLength, maxSize)
+	}
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset, false, fmt.Errorf("failed to seek file: %w", err)
+	}
+
+	// Read one byte past the max size so an oversized file without a content length is caught
+	idleBody := newIdleTimeoutReader(resp.Body, downloadIdleTimeout, cancel)
+	defer idleBody.stop()
+
+	var body io.Reader = io.LimitReader(idleBody, maxSize-offset+1)
+	if m.downloadCfg.BandwidthLimit > 0 {
+		body = newRateLimitedReader(body, m.downloadCfg.BandwidthLimit)
+	}
+
+	n, err := io.Copy(f, body)
+	if err != nil {
+		if idleBody.timedOut() {
+			err = fmt.Errorf("no data received for %s: %w", downloadIdleTimeout, err)
+		}
+		return offset + n, true, fmt.Errorf("failed to copy request body to file: %w", err)
+	}
+
+	if offset+n > maxSize {
+		return offset + n, false, fmt.Errorf("file exceeds the max download size of %d bytes", maxSize)
+	}
+
+	return offset + n, false, nil
+}
+
+// contentRangeStart returns the first byte position of a Content-Range header, such as "bytes 5-10/11"
+func contentRangeStart(contentRange string) (int64, bool) {
+	byteRange, ok := strings.CutPrefix(contentRange, "bytes ")
+	if !ok {
+		return 0, false
+	}
+
+	first, _, ok := strings.Cut(byteRange, "-")
+	if !ok {
+		return 0, false
+	}
+
+	start, err := strconv.ParseInt(strings.TrimSpace(first), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return start, true
+}
+
+// maxDownloadSize returns the largest file that may be downloaded
+func (m DownloadableFileManager) maxDownloadSize() int64 {
+	if m.downloadCfg.MaxSize > 0 {
+		return m.downloadCfg.MaxSize
+	}
+	return defaultMaxDownloadSize
+}
+
+// waitForStartDelay waits a random time up to the max start delay, so a fleet doesn't download at the same time
+func (m DownloadableFileManager) waitForStartDelay() {
+	if m.downloadCfg.MaxStartDelay <= 0 {
+		return
+	}
+
+	delay := rand.N(m.downloadCfg.MaxStartDelay)
+	m.logger.Info("Delaying package download", zap.Duration("delay", delay))
+	time.Sleep(delay)
+}
+
+// rateLimitedReader limits the rate bytes are read from the underlying reader
+type rateLimitedReader struct {
+	r       io.Reader
+	limiter *rate.Limiter
+}
+
+func newRateLimitedReader(r io.Reader, bytesPerSecond int64) *rateLimitedReader {
+	// Allow up to a second worth of bytes at once so reads aren't split too finely
+	burst := int(min(bytesPerSecond, maxRateLimitBurst))
+	return &rateLimitedReader{
+		r:       r,
+		limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), burst),
+	}
+}
+
+// Read reads at most a burst of bytes, waiting until the limiter allows them
+func (r *rateLimitedReader) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+
+	n, err := r.r.Read(p)
+	if n > 0 {
+		if waitErr := r.limiter.WaitN(context.Background(), n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+	return n, err
+}
+
+// idleTimeoutReader calls cancel if no read from the underlying reader returns within the timeout
+type idleTimeoutReader struct {
+	r       io.Reader
+	timeout time.Duration
+	timer   *time.Timer
+	expired atomic.Bool
+}
+
+func newIdleTimeoutReader(r io.Reader, timeout time.Duration, cancel context.CancelFunc) *idleTimeoutReader {
+	reader := &idleTimeoutReader{
+		r:       r,
+		timeout: timeout,
+	}
+	reader.timer = time.AfterFunc(timeout, func() {
+		reader.expired.Store(true)
+		cancel()
+	})
+	return reader
+}
+
+// Read reads from the underlying reader, restarting the timeout once data is received
+func (r *idleTimeoutReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if n > 0 && r.timer.Stop() {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+// timedOut returns true if the timeout expired
+func (r *idleTimeoutReader) timedOut() bool {
+	return r.expired.Load()
+}
+
+// stop stops the timeout
+func (r *idleTimeoutReader) stop() {
+	r.timer.Stop()
 }
 
 // getOutputFilePath gets the output path relative to the base dir for the archive from the given URL.
@@ -169,6 +426,37 @@ func (m DownloadableFileManager) verifyContentHash(contentPath string, expectedF
 	return nil
 }
 
+// verifySignature checks the signature of the archive against the trusted keys.
+// The signature is then saved next to the archive so the updater can verify it again before installing.
+func (m DownloadableFileManager) verifySignature(archivePath string, sig []byte) error {
+	verifier, err := signature.NewVerifier(m.signingKeys...)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	if err := verifier.VerifyFile(archivePath, sig); err != nil {
+		return err
+	}
+
+	// Remove signatures of previous downloads so the updater only finds this one
+	oldSigPaths, err := filepath.Glob(filepath.Join(m.tmpPath, "*"+signature.FileExtension))
+	if err != nil {
+		return fmt.Errorf("failed to find previous signatures: %w", err)
+	}
+	for _, oldSigPath := range oldSigPaths {
+		if err := os.Remove(oldSigPath); err != nil {
+			return fmt.Errorf("failed to remove previous signature: %w", err)
+		}
+	}
+
+	sigPath := filepath.Clean(archivePath + signature.FileExtension)
+	if err := os.WriteFile(sigPath, sig, 0600); err != nil {
+		return fmt.Errorf("failed to save signature: %w", err)
+	}
+
+	return nil
+}
+
 // CleanupArtifacts removes previous installation artifacts by removing the temporary directory.
 func (m DownloadableFileManager) CleanupArtifacts() {
 	if err := os.RemoveAll(m.tmpPath); err != nil {
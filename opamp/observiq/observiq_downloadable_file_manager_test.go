@@ -16,15 +16,27 @@ package observiq
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/observiq/bindplane-otel-collector/signature"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -32,8 +44,12 @@ import (
 )
 
 func TestDownloadFile(t *testing.T) {
+	originalDelay := downloadRetryDelay
+	downloadRetryDelay = 0
+	t.Cleanup(func() { downloadRetryDelay = originalDelay })
+
 	tmpDir := t.TempDir()
-	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir)
+	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, nil, nil)
 	t.Run("Downloads File Over HTTP", func(t *testing.T) {
 
 		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -96,6 +112,291 @@ func TestDownloadFile(t *testing.T) {
 	})
 }
 
+func TestDownloadFileResume(t *testing.T) {
+	originalDelay := downloadRetryDelay
+	downloadRetryDelay = 0
+	t.Cleanup(func() { downloadRetryDelay = originalDelay })
+
+	content := []byte("Hello World")
+
+	testCases := []struct {
+		desc          string
+		supportsRange bool
+	}{
+		{
+			desc:          "Resumes interrupted download with range request",
+			supportsRange: true,
+		},
+		{
+			desc:          "Restarts download when server ignores range request",
+			supportsRange: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			requests := 0
+			var rangeHeader string
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if requests == 1 {
+					// Send part of the file then drop the connection
+					w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+					w.Write(content[:5])
+					w.(http.Flusher).Flush()
+					panic(http.ErrAbortHandler)
+				}
+
+				rangeHeader = r.Header.Get("Range")
+				if tc.supportsRange {
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes 5-%d/%d", len(content)-1, len(content)))
+					w.WriteHeader(http.StatusPartialContent)
+					w.Write(content[5:])
+					return
+				}
+				w.Write(content)
+			}))
+			defer s.Close()
+
+			outPath := filepath.Join(t.TempDir(), "out.txt")
+			downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, nil)
+			err := downloadableFileManager.downloadFile(s.URL, outPath)
+			require.NoError(t, err)
+
+			require.Equal(t, 2, requests)
+			require.Equal(t, "bytes=5-", rangeHeader)
+
+			b, err := os.ReadFile(outPath)
+			require.NoError(t, err)
+			require.Equal(t, content, b)
+		})
+	}
+
+	t.Run("Retries when the connection is dropped before a response", func(t *testing.T) {
+		requests := 0
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+			if requests == 1 {
+				conn, _, err := w.(http.Hijacker).Hijack()
+				require.NoError(t, err)
+				conn.Close()
+				return
+			}
+			w.Write(content)
+		}))
+		defer s.Close()
+
+		outPath := filepath.Join(t.TempDir(), "out.txt")
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, nil)
+		err := downloadableFileManager.downloadFile(s.URL, outPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, requests)
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Equal(t, content, b)
+	})
+
+	t.Run("Restarts download when range does not start at offset", func(t *testing.T) {
+		var rangeHeaders []string
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rangeHeaders = append(rangeHeaders, r.Header.Get("Range"))
+			switch len(rangeHeaders) {
+			case 1:
+				w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+				w.Write(content[:5])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			case 2:
+				// Responds with a different range than requested
+				w.Header().Set("Content-Range", fmt.Sprintf("bytes 3-%d/%d", len(content)-1, len(content)))
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write(content[3:])
+			default:
+				w.Write(content)
+			}
+		}))
+		defer s.Close()
+
+		outPath := filepath.Join(t.TempDir(), "out.txt")
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, nil)
+		err := downloadableFileManager.downloadFile(s.URL, outPath)
+		require.NoError(t, err)
+		require.Equal(t, []string{"", "bytes=5-", ""}, rangeHeaders)
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Equal(t, content, b)
+	})
+
+	t.Run("Retries when the transfer stalls", func(t *testing.T) {
+		originalTimeout := downloadIdleTimeout
+		downloadIdleTimeout = 100 * time.Millisecond
+		t.Cleanup(func() { downloadIdleTimeout = originalTimeout })
+
+		var mux sync.Mutex
+		var rangeHeaders []string
+		stalled := make(chan struct{})
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mux.Lock()
+			rangeHeaders = append(rangeHeaders, r.Header.Get("Range"))
+			requests := len(rangeHeaders)
+			mux.Unlock()
+
+			if requests == 1 {
+				// Send part of the file then stop sending without closing the connection
+				w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+				w.Write(content[:5])
+				w.(http.Flusher).Flush()
+				<-stalled
+				return
+			}
+
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes 5-%d/%d", len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(content[5:])
+		}))
+		defer s.Close()
+		defer close(stalled)
+
+		outPath := filepath.Join(t.TempDir(), "out.txt")
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, nil)
+		err := downloadableFileManager.downloadFile(s.URL, outPath)
+		require.NoError(t, err)
+
+		mux.Lock()
+		defer mux.Unlock()
+		require.Equal(t, []string{"", "bytes=5-"}, rangeHeaders)
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Equal(t, content, b)
+	})
+
+	t.Run("Retries when the response headers are not sent", func(t *testing.T) {
+		originalTimeout := downloadResponseHeaderTimeout
+		downloadResponseHeaderTimeout = 100 * time.Millisecond
+		t.Cleanup(func() { downloadResponseHeaderTimeout = originalTimeout })
+
+		var requests atomic.Int64
+		stalled := make(chan struct{})
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) == 1 {
+				<-stalled
+				return
+			}
+			w.Write(content)
+		}))
+		defer s.Close()
+		defer close(stalled)
+
+		outPath := filepath.Join(t.TempDir(), "out.txt")
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, nil)
+		err := downloadableFileManager.downloadFile(s.URL, outPath)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), requests.Load())
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Equal(t, content, b)
+	})
+
+	t.Run("Gives up after max attempts", func(t *testing.T) {
+		requests := 0
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			requests++
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer s.Close()
+
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, nil)
+		err := downloadableFileManager.downloadFile(s.URL, filepath.Join(t.TempDir(), "out.txt"))
+		require.ErrorContains(t, err, "got non-200 status code (503)")
+		require.Equal(t, maxDownloadAttempts, requests)
+	})
+}
+
+func TestDownloadFileLimits(t *testing.T) {
+	content := bytes.Repeat([]byte("a"), 1500)
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("chunked") == "true" {
+			// Flushing before writing everything hides the content length
+			w.(http.Flusher).Flush()
+		}
+		w.Write(content)
+	}))
+	defer s.Close()
+
+	t.Run("Max size exceeded by content length", func(t *testing.T) {
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, &opamp.PackageDownloadConfig{MaxSize: 1000})
+		err := downloadableFileManager.downloadFile(s.URL, filepath.Join(t.TempDir(), "out.txt"))
+		require.ErrorContains(t, err, "file size 1500 exceeds the max download size of 1000 bytes")
+	})
+
+	t.Run("Max size exceeded without content length", func(t *testing.T) {
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, &opamp.PackageDownloadConfig{MaxSize: 1000})
+		err := downloadableFileManager.downloadFile(s.URL+"?chunked=true", filepath.Join(t.TempDir(), "out.txt"))
+		require.ErrorContains(t, err, "file exceeds the max download size of 1000 bytes")
+	})
+
+	t.Run("Bandwidth limited", func(t *testing.T) {
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, &opamp.PackageDownloadConfig{BandwidthLimit: 1000})
+		outPath := filepath.Join(t.TempDir(), "out.txt")
+
+		// The first 1000 bytes are allowed at once, the remaining 500 take half a second
+		start := time.Now()
+		err := downloadableFileManager.downloadFile(s.URL, outPath)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Equal(t, content, b)
+	})
+}
+
+func TestDownloadFileMirror(t *testing.T) {
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("origin"))
+	}))
+	defer origin.Close()
+
+	var mirrorPath string
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorPath = r.URL.Path
+		if r.URL.Path == "/cache/missing/observiq-otel-collector.tar.gz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("mirror"))
+	}))
+	defer mirror.Close()
+
+	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), t.TempDir(), nil, &opamp.PackageDownloadConfig{MirrorURL: mirror.URL + "/cache"})
+
+	t.Run("Downloads from mirror", func(t *testing.T) {
+		outPath := filepath.Join(t.TempDir(), "out.txt")
+		err := downloadableFileManager.downloadFile(origin.URL+"/v1.2.3/observiq-otel-collector.tar.gz", outPath)
+		require.NoError(t, err)
+		require.Equal(t, "/cache/v1.2.3/observiq-otel-collector.tar.gz", mirrorPath)
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Equal(t, []byte("mirror"), b)
+	})
+
+	t.Run("Falls back to download URL", func(t *testing.T) {
+		outPath := filepath.Join(t.TempDir(), "out.txt")
+		err := downloadableFileManager.downloadFile(origin.URL+"/missing/observiq-otel-collector.tar.gz", outPath)
+		require.NoError(t, err)
+
+		b, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		require.Equal(t, []byte("origin"), b)
+	})
+}
+
 func TestGetOutputFilePath(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -145,7 +446,7 @@ func TestGetOutputFilePath(t *testing.T) {
 
 func TestVerifyContentHash(t *testing.T) {
 	tmpDir := t.TempDir()
-	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir)
+	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, nil, nil)
 
 	hash1, _ := hex.DecodeString("c87e2ca771bab6024c269b933389d2a92d4941c848c52f155b9b84e1f109fe35")
 	hash2, _ := hex.DecodeString("7e4ead2053637d9fcb7f3316e748becb8af163c6f851446eeef878a994ae5c4b")
@@ -204,6 +505,55 @@ func TestVerifyContentHash(t *testing.T) {
 	}
 }
 
+// newTestSigningKey generates a signing key, writing the PEM encoded public key into dir
+func newTestSigningKey(t *testing.T, dir string) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(dir, "signing_key.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	return priv, keyPath
+}
+
+// signArchive creates a base64 encoded signature of the archive, as written by cosign
+func signArchive(t *testing.T, priv *ecdsa.PrivateKey, archive []byte) []byte {
+	t.Helper()
+
+	digest := sha256.Sum256(archive)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	require.NoError(t, err)
+	return []byte(base64.StdEncoding.EncodeToString(sig))
+}
+
+func TestVerifySignature(t *testing.T) {
+	tmpDir := t.TempDir()
+	priv, keyPath := newTestSigningKey(t, t.TempDir())
+
+	archivePath := filepath.Join(tmpDir, "new.tar.gz")
+	require.NoError(t, os.WriteFile(archivePath, []byte("archive"), 0600))
+	oldSigPath := filepath.Join(tmpDir, "old.tar.gz"+signature.FileExtension)
+	require.NoError(t, os.WriteFile(oldSigPath, []byte("old"), 0600))
+
+	t.Run("Invalid signing key", func(t *testing.T) {
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, []string{filepath.Join(tmpDir, "missing.pub")}, nil)
+		err := downloadableFileManager.verifySignature(archivePath, signArchive(t, priv, []byte("archive")))
+		require.ErrorContains(t, err, "failed to load signing keys")
+	})
+
+	t.Run("Replaces previous signatures", func(t *testing.T) {
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, []string{keyPath}, nil)
+		err := downloadableFileManager.verifySignature(archivePath, signArchive(t, priv, []byte("archive")))
+		require.NoError(t, err)
+
+		require.NoFileExists(t, oldSigPath)
+		require.FileExists(t, archivePath+signature.FileExtension)
+	})
+}
+
 func TestDownloadAndVerifyExtraction(t *testing.T) {
 	hash1, _ := hex.DecodeString("d3bf2375be7372b34eae9bc16296ce9e40e53f5b79b329e23056c4aaf77eb47c")
 	hash2, _ := hex.DecodeString("5594349d022f7f374fa3ee777ded15f4f06a47aa08eec300bd06cdb0d2688fac")
@@ -212,6 +562,8 @@ func TestDownloadAndVerifyExtraction(t *testing.T) {
 		name         string
 		archivePath  string
 		expectedHash []byte
+		unsigned     bool
+		untrusted    bool
 		expectedErr  string
 	}{
 		{
@@ -236,25 +588,38 @@ func TestDownloadAndVerifyExtraction(t *testing.T) {
 			expectedHash: hash3,
 			expectedErr:  "content hash could not be verified",
 		},
+		{
+			name:         "Missing signature",
+			archivePath:  filepath.Join("testdata", "test.tar.gz"),
+			expectedHash: hash1,
+			unsigned:     true,
+			expectedErr:  "signature could not be verified: missing signature",
+		},
+		{
+			name:         "Signature from untrusted key",
+			archivePath:  filepath.Join("testdata", "test.tar.gz"),
+			expectedHash: hash1,
+			untrusted:    true,
+			expectedErr:  "signature could not be verified: signature does not match any trusted key",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			tmpDir := t.TempDir()
+			priv, keyPath := newTestSigningKey(t, t.TempDir())
 
-			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
-				archiveBytes, err := os.ReadFile(tc.archivePath)
-				if err != nil {
-					t.Errorf("Failed to open archive for sending over http: %s", err)
-				}
+			archiveBytes, err := os.ReadFile(tc.archivePath)
+			require.NoError(t, err)
 
-				if filepath.Base(tc.archivePath) == "not-actually-tar.tar.gz" {
-					// This file is a text file, and git actually detects that and replaces line endings on windows
-					// Replace \r\n with \n so tests pass on windows systems
-					archiveBytes = bytes.ReplaceAll(archiveBytes, []byte("\r\n"), []byte("\n"))
-				}
+			if filepath.Base(tc.archivePath) == "not-actually-tar.tar.gz" {
+				// This file is a text file, and git actually detects that and replaces line endings on windows
+				// Replace \r\n with \n so tests pass on windows systems
+				archiveBytes = bytes.ReplaceAll(archiveBytes, []byte("\r\n"), []byte("\n"))
+			}
 
-				_, err = w.Write(archiveBytes)
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, err := w.Write(archiveBytes)
 				if err != nil {
 					t.Errorf("Failed to copy archive for sending over http: %s", err)
 				}
@@ -266,11 +631,25 @@ func TestDownloadAndVerifyExtraction(t *testing.T) {
 				ContentHash: []byte(tc.expectedHash),
 			}
 
-			downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir)
-			err := downloadableFileManager.FetchAndExtractArchive(file)
+			switch {
+			case tc.unsigned:
+			case tc.untrusted:
+				untrustedPriv, _ := newTestSigningKey(t, t.TempDir())
+				file.Signature = signArchive(t, untrustedPriv, archiveBytes)
+			default:
+				file.Signature = signArchive(t, priv, archiveBytes)
+			}
+
+			downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, []string{keyPath}, nil)
+			err = downloadableFileManager.FetchAndExtractArchive(file)
 			if tc.expectedErr == "" {
 				require.NoError(t, err)
 
+				// Make sure the signature was saved next to the archive for the updater
+				savedSig, err := os.ReadFile(filepath.Join(tmpDir, filepath.Base(tc.archivePath)+signature.FileExtension))
+				require.NoError(t, err)
+				require.Equal(t, file.Signature, savedSig)
+
 				// Make sure test.txt exists in the output dir
 				expectedBytes, err := os.ReadFile(filepath.Join("testdata", "test.txt"))
 				require.NoError(t, err)
@@ -302,7 +681,7 @@ func TestDownloadAndVerifyHTTPFailure(t *testing.T) {
 		ContentHash: []byte{},
 	}
 
-	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir)
+	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, nil, nil)
 	err := downloadableFileManager.FetchAndExtractArchive(file)
 	require.ErrorContains(t, err, "failed to download file:")
 }
@@ -315,7 +694,7 @@ func TestDownloadAndVerifyInvalidURL(t *testing.T) {
 		ContentHash: []byte{},
 	}
 
-	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir)
+	downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, nil, nil)
 	err := downloadableFileManager.FetchAndExtractArchive(file)
 	require.ErrorContains(t, err, "failed to determine archive download path:")
 }
@@ -325,7 +704,7 @@ func TestCleanupArtifacts(t *testing.T) {
 		tmpDir := filepath.Join(t.TempDir(), "tmp")
 
 		// Try to download -- this should create tmpDir, but fail to download
-		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir)
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, nil, nil)
 		err := downloadableFileManager.FetchAndExtractArchive(&protobufs.DownloadableFile{
 			DownloadUrl: "http://invalid-host:0/some-file.zip",
 		})
@@ -339,7 +718,7 @@ func TestCleanupArtifacts(t *testing.T) {
 
 	t.Run("Does nothing if tmp dir does not exist", func(t *testing.T) {
 		tmpDir := filepath.Join(t.TempDir(), "tmp")
-		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir)
+		downloadableFileManager := newDownloadableFileManager(zap.NewNop(), tmpDir, nil, nil)
 
 		require.NoDirExists(t, tmpDir)
 
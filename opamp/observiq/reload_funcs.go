@@ -38,24 +38,29 @@ func managerReload(client *Client, managerConfigPath string) opamp.ReloadFunc {
 
 		// Check if the updatable fields are equal
 		// If so then exit
-		if client.currentConfig.CmpUpdatableFields(newConfig) {
+		currentConfig := client.safeGetCurrentConfig()
+		if currentConfig.CmpUpdatableFields(newConfig) {
 			return false, nil
 		}
 
 		updatedKeys := []string{}
-		if !opamp.CmpStringPtr(client.currentConfig.AgentName, newConfig.AgentName) {
+		if !opamp.CmpStringPtr(currentConfig.AgentName, newConfig.AgentName) {
 			updatedKeys = append(updatedKeys, "agent_name")
 		}
 
-		if client.currentConfig.MeasurementsInterval != newConfig.MeasurementsInterval {
+		if currentConfig.MeasurementsInterval != newConfig.MeasurementsInterval {
 			updatedKeys = append(updatedKeys, "measurements_interval")
 		}
 
-		if !maps.Equal(client.currentConfig.ExtraMeasurementsAttributes, newConfig.ExtraMeasurementsAttributes) {
+		if !maps.Equal(currentConfig.ExtraMeasurementsAttributes, newConfig.ExtraMeasurementsAttributes) {
 			updatedKeys = append(updatedKeys, "extra_measurements_attributes")
 		}
 
 		client.logger.Info("Manager config update detected", zap.Strings("updated_keys", updatedKeys))
+		// The identity is also updated by the host inventory refresh
+		client.identMux.Lock()
+		defer client.identMux.Unlock()
+
 		// Going to do an update prep a rollback
 		rollbackFunc, cleanupFunc, err := prepRollback(managerConfigPath)
 		if err != nil {
@@ -69,15 +74,15 @@ func managerReload(client *Client, managerConfigPath string) opamp.ReloadFunc {
 			}
 		}()
 
-		//create a copies for rollback
-		rollBackCfg := client.currentConfig.Copy()
+		// Create a copy of the identity for rollback. The current config is only replaced once the update succeeds.
 		rollbackIdent := client.ident.Copy()
 
 		// Updatable config fields
-		client.currentConfig.AgentName = newConfig.AgentName
-		client.currentConfig.Labels = newConfig.Labels
-		client.currentConfig.MeasurementsInterval = newConfig.MeasurementsInterval
-		client.currentConfig.ExtraMeasurementsAttributes = newConfig.ExtraMeasurementsAttributes
+		updatedConfig := currentConfig
+		updatedConfig.AgentName = newConfig.AgentName
+		updatedConfig.Labels = newConfig.Labels
+		updatedConfig.MeasurementsInterval = newConfig.MeasurementsInterval
+		updatedConfig.ExtraMeasurementsAttributes = newConfig.ExtraMeasurementsAttributes
 
 		// Update identity
 		client.ident.agentName = newConfig.AgentName
@@ -85,14 +90,13 @@ func managerReload(client *Client, managerConfigPath string) opamp.ReloadFunc {
 
 		// Write out new config file
 		// Marshal back into bytes
-		newContents, err := yaml.Marshal(client.currentConfig)
+		newContents, err := yaml.Marshal(updatedConfig)
 		if err != nil {
 			// Rollback file
 			if rollbackErr := rollbackFunc(); rollbackErr != nil {
 				client.logger.Error("Rollback failed for manager config", zap.Error(rollbackErr))
 			}
 			client.ident = rollbackIdent
-			client.currentConfig = *rollBackCfg
 			return false, fmt.Errorf("failed to reformat manager config: %w", err)
 		}
 
@@ -103,24 +107,24 @@ func managerReload(client *Client, managerConfigPath string) opamp.ReloadFunc {
 				client.logger.Error("Rollback failed for collector config", zap.Error(rollbackErr))
 			}
 			client.ident = rollbackIdent
-			client.currentConfig = *rollBackCfg
 			return false, err
 		}
 
 		// Set the agent description
-		if err := client.opampClient.SetAgentDescription(client.ident.ToAgentDescription()); err != nil {
+		if err := client.safeGetOpAMPClient().SetAgentDescription(client.ident.ToAgentDescription()); err != nil {
 			// Rollback file
 			if rollbackErr := rollbackFunc(); rollbackErr != nil {
 				client.logger.Error("Rollback failed for collector config", zap.Error(rollbackErr))
 			}
 			client.ident = rollbackIdent
-			client.currentConfig = *rollBackCfg
 			return false, fmt.Errorf("failed to set agent description: %w ", err)
 		}
 
+		client.safeSetCurrentConfig(updatedConfig)
+
 		// Set new measurements interval and attributes
-		client.measurementsSender.SetInterval(client.currentConfig.MeasurementsInterval)
-		client.measurementsSender.SetExtraAttributes(client.currentConfig.ExtraMeasurementsAttributes)
+		client.measurementsSender.SetInterval(updatedConfig.MeasurementsInterval)
+		client.measurementsSender.SetExtraAttributes(updatedConfig.ExtraMeasurementsAttributes)
 
 		return true, nil
 	}
@@ -128,6 +132,12 @@ func managerReload(client *Client, managerConfigPath string) opamp.ReloadFunc {
 
 func collectorReload(client *Client, collectorConfigPath string) opamp.ReloadFunc {
 	return func(contents []byte) (bool, error) {
+		// Validate the config in-process first so an invalid config never interrupts the running collector
+		if err := client.collector.ValidateConfig(context.Background(), contents); err != nil {
+			client.logger.Error("Rejected invalid collector config", zap.Error(err))
+			return false, fmt.Errorf("invalid collector config: %w", err)
+		}
+
 		rollbackFunc, cleanupFunc, err := prepRollback(collectorConfigPath)
 		client.logger.Info("Rollback prepped", zap.String("collectorConfigPath", collectorConfigPath))
 		if err != nil {
@@ -170,6 +180,36 @@ func collectorReload(client *Client, collectorConfigPath string) opamp.ReloadFun
 			return false, fmt.Errorf("collector failed to restart: %w", err)
 		}
 		client.logger.Info("OTEL Collector restarted")
+
+		// Watch the collector before reporting the config as applied, as a config can start but then fail.
+		// This blocks the OpAMP client's message handling, so the server gets no RemoteConfigStatus
+		// until the window ends. Disconnecting interrupts the check.
+		if client.configHealthChecker != nil {
+			if err := client.configHealthChecker.Check(client.shutdownCtx); err != nil {
+				if client.shutdownCtx.Err() != nil {
+					// Shutting down, so leave the previous config on disk without restarting the collector
+					if rollbackErr := rollbackFunc(); rollbackErr != nil {
+						client.logger.Error("Rollback failed for collector config", zap.Error(rollbackErr))
+					}
+					return false, fmt.Errorf("collector health check interrupted: %w", err)
+				}
+
+				client.logger.Error("Collector unhealthy after config update, rolling back", zap.Error(err))
+
+				// Rollback file
+				if rollbackErr := rollbackFunc(); rollbackErr != nil {
+					client.logger.Error("Rollback failed for collector config", zap.Error(rollbackErr))
+				}
+
+				// Restart collector with original file
+				if rollbackErr := client.collector.Restart(context.Background()); rollbackErr != nil {
+					client.logger.Error("Collector failed for restart during rollback", zap.Error(rollbackErr))
+				}
+
+				return false, fmt.Errorf("collector failed health check: %w", err)
+			}
+			client.logger.Info("Collector passed health check")
+		}
 		// Reset Snapshot Reporter
 		report.GetSnapshotReporter().Reset()
 		client.logger.Info("Snapshot reporter reset")
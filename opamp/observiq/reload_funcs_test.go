@@ -28,6 +28,7 @@ import (
 	"github.com/observiq/bindplane-otel-collector/opamp/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
@@ -205,6 +206,41 @@ func Test_collectorReload(t *testing.T) {
 		desc     string
 		testFunc func(*testing.T)
 	}{
+		{
+			desc: "Invalid config, collector untouched",
+			testFunc: func(t *testing.T) {
+				tmpDir := t.TempDir()
+
+				collectorFilePath := filepath.Join(tmpDir, CollectorConfigName)
+
+				mockCollector := colmocks.NewMockCollector(t)
+				mockCollector.On("ValidateConfig", mock.Anything, []byte("invalid: config")).Return(errors.New("invalid configuration: receivers::otlp: must specify at least one protocol"))
+
+				currContents := []byte("current: config")
+
+				// Write Config file so we can verify it remained the same
+				err := os.WriteFile(collectorFilePath, currContents, 0600)
+				assert.NoError(t, err)
+
+				client := &Client{
+					logger:    zap.NewNop(),
+					collector: mockCollector,
+				}
+
+				reloadFunc := collectorReload(client, collectorFilePath)
+
+				changed, err := reloadFunc([]byte("invalid: config"))
+				assert.EqualError(t, err, "invalid collector config: invalid configuration: receivers::otlp: must specify at least one protocol")
+				assert.False(t, changed)
+
+				// Verify config was never written
+				data, err := os.ReadFile(collectorFilePath)
+				assert.NoError(t, err)
+				assert.Equal(t, currContents, data)
+
+				mockCollector.AssertNotCalled(t, "Restart", mock.Anything)
+			},
+		},
 		{
 			desc: "Collector failed to restart, rollback required",
 			testFunc: func(t *testing.T) {
@@ -215,6 +251,7 @@ func Test_collectorReload(t *testing.T) {
 				expectedErr := errors.New("oops")
 				statusChannel := make(chan *collector.Status)
 				mockCollector := colmocks.NewMockCollector(t)
+				mockCollector.On("ValidateConfig", mock.Anything, []byte("valid: config")).Return(nil)
 				mockCollector.On("Status").Return((<-chan *collector.Status)(statusChannel))
 				mockCollector.On("Restart", mock.Anything).Return(expectedErr).Once()
 				mockCollector.On("Restart", mock.Anything).Return(nil).Once()
@@ -251,6 +288,129 @@ func Test_collectorReload(t *testing.T) {
 				}, 2*time.Second, 100*time.Millisecond)
 			},
 		},
+		{
+			desc: "Collector unhealthy after restart, rollback required",
+			testFunc: func(t *testing.T) {
+				tmpDir := t.TempDir()
+
+				collectorFilePath := filepath.Join(tmpDir, CollectorConfigName)
+
+				statusChannel := make(chan *collector.Status)
+				mockCollector := colmocks.NewMockCollector(t)
+				mockCollector.On("ValidateConfig", mock.Anything, []byte("valid: config")).Return(nil)
+				mockCollector.On("Status").Return((<-chan *collector.Status)(statusChannel))
+				mockCollector.On("Restart", mock.Anything).Return(nil).Twice()
+				mockCollector.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{
+					"exporter:otlp": {Status: componentstatus.StatusPermanentError, LastError: errors.New("invalid endpoint")},
+				})
+
+				currContents := []byte("current: config")
+
+				// Write Config file so we can verify it remained the same
+				err := os.WriteFile(collectorFilePath, currContents, 0600)
+				assert.NoError(t, err)
+
+				client := &Client{
+					logger:    zap.NewNop(),
+					collector: mockCollector,
+				}
+				client.configHealthChecker = newConfigHealthChecker(client.logger, mockCollector, &opamp.ConfigHealthCheck{Window: time.Second})
+				client.configHealthChecker.interval = 10 * time.Millisecond
+				client.shutdownCtx, client.shutdownCancel = context.WithCancel(context.Background())
+
+				// Setup Context to mock out already running collector monitor
+				client.collectorMntrCtx, client.collectorMntrCancel = context.WithCancel(context.Background())
+
+				reloadFunc := collectorReload(client, collectorFilePath)
+
+				changed, err := reloadFunc([]byte("valid: config"))
+				assert.EqualError(t, err, "collector failed health check: component exporter:otlp failed: invalid endpoint")
+				assert.False(t, changed)
+
+				// Verify config rolledback
+				data, err := os.ReadFile(collectorFilePath)
+				assert.NoError(t, err)
+				assert.Equal(t, currContents, data)
+
+				// Cleanup
+				assert.Eventually(t, func() bool {
+					client.stopCollectorMonitoring()
+					return true
+				}, 2*time.Second, 100*time.Millisecond)
+			},
+		},
+		{
+			desc: "Disconnect interrupts health check",
+			testFunc: func(t *testing.T) {
+				tmpDir := t.TempDir()
+
+				collectorFilePath := filepath.Join(tmpDir, CollectorConfigName)
+
+				statusChannel := make(chan *collector.Status)
+				mockCollector := colmocks.NewMockCollector(t)
+				mockCollector.On("ValidateConfig", mock.Anything, []byte("valid: config")).Return(nil)
+				mockCollector.On("Status").Return((<-chan *collector.Status)(statusChannel))
+				mockCollector.On("Restart", mock.Anything).Return(nil).Once()
+				mockCollector.On("ComponentHealth").Return(map[string]*collector.ComponentHealth{}).Maybe()
+				mockCollector.On("Stop", mock.Anything).Return()
+
+				mockOpAmpClient := new(mocks.MockOpAMPClient)
+				mockOpAmpClient.On("Stop", mock.Anything).Return(nil)
+
+				currContents := []byte("current: config")
+
+				// Write Config file so we can verify it remained the same
+				err := os.WriteFile(collectorFilePath, currContents, 0600)
+				assert.NoError(t, err)
+
+				client := &Client{
+					logger:      zap.NewNop(),
+					collector:   mockCollector,
+					opampClient: mockOpAmpClient,
+				}
+				client.configHealthChecker = newConfigHealthChecker(client.logger, mockCollector, &opamp.ConfigHealthCheck{Window: time.Hour})
+				client.configHealthChecker.interval = 10 * time.Millisecond
+				client.shutdownCtx, client.shutdownCancel = context.WithCancel(context.Background())
+
+				// Setup Context to mock out already running collector monitor
+				client.collectorMntrCtx, client.collectorMntrCancel = context.WithCancel(context.Background())
+
+				reloadFunc := collectorReload(client, collectorFilePath)
+
+				errChan := make(chan error, 1)
+				go func() {
+					_, err := reloadFunc([]byte("valid: config"))
+					errChan <- err
+				}()
+
+				// Wait for the health check to start watching the collector
+				assert.Eventually(t, func() bool {
+					data, err := os.ReadFile(collectorFilePath)
+					return err == nil && string(data) == "valid: config"
+				}, 2*time.Second, 10*time.Millisecond)
+
+				assert.NoError(t, client.Disconnect(context.Background()))
+
+				select {
+				case err := <-errChan:
+					assert.ErrorIs(t, err, context.Canceled)
+				case <-time.After(2 * time.Second):
+					t.Fatal("health check was not interrupted")
+				}
+
+				// Verify config rolledback without restarting the collector
+				data, err := os.ReadFile(collectorFilePath)
+				assert.NoError(t, err)
+				assert.Equal(t, currContents, data)
+				mockCollector.AssertNumberOfCalls(t, "Restart", 1)
+
+				// Cleanup
+				assert.Eventually(t, func() bool {
+					client.stopCollectorMonitoring()
+					return true
+				}, 2*time.Second, 100*time.Millisecond)
+			},
+		},
 		{
 			desc: "Successful update",
 			testFunc: func(t *testing.T) {
@@ -259,6 +419,7 @@ func Test_collectorReload(t *testing.T) {
 				collectorFilePath := filepath.Join(tmpDir, CollectorConfigName)
 
 				mockCollector := colmocks.NewMockCollector(t)
+				mockCollector.On("ValidateConfig", mock.Anything, []byte("valid: config")).Return(nil)
 				statusChannel := make(chan *collector.Status)
 				mockCollector.On("Status").Return((<-chan *collector.Status)(statusChannel))
 				mockCollector.On("Restart", mock.Anything).Return(nil)
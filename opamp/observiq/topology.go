@@ -88,9 +88,19 @@ func (ts *topologySender) Stop() {
 	close(ts.done)
 	ts.wg.Wait()
 
+	// Replace the closed channel so the sender can be started again
+	ts.done = make(chan struct{})
 	ts.isRunning = false
 }
 
+// SetOpAMPClient sets the client messages are sent with. It takes effect the next time the sender is started.
+func (ts *topologySender) SetOpAMPClient(opampClient client.OpAMPClient) {
+	ts.mux.Lock()
+	defer ts.mux.Unlock()
+
+	ts.opampClient = opampClient
+}
+
 func (ts *topologySender) loop() {
 	t := newTicker()
 	defer t.Stop()
@@ -0,0 +1,142 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package observiq
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/observiq/bindplane-otel-collector/opamp"
+	"github.com/open-telemetry/opamp-go/client/types"
+)
+
+// The OpAMP clients in opamp-go v0.17.0 don't accept a transport or proxy. The websocket client dials with
+// a copy of websocket.DefaultDialer and the HTTP client sends with http.DefaultClient, so both are scoped to
+// the server's host and pointed at the configured proxy before the client is started. Requests to other hosts
+// keep using the proxy and transport that were set before.
+// Later opamp-go releases add proxy settings to types.StartSettings, which should replace this once upgraded.
+var (
+	transportMux sync.Mutex
+
+	// dialerProxyFallback is the websocket dialer proxy replaced by configureTransport, used for hosts other than the server
+	dialerProxyFallback func(*http.Request) (*url.URL, error)
+)
+
+// serverProxy returns the proxy used to connect to the server. The proxy environment variables are used if
+// no proxy is configured.
+func serverProxy(cfg opamp.Config) (func(*http.Request) (*url.URL, error), error) {
+	if cfg.Proxy == nil {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxyURL, err := cfg.Proxy.ProxyURL()
+	if err != nil {
+		return nil, err
+	}
+
+	return http.ProxyURL(proxyURL), nil
+}
+
+// configureTransport points the transport used by the OpAMP client for the config's endpoint at the configured proxy.
+// For plain HTTP the TLS config is moved from the settings to the transport, otherwise the client replaces the transport.
+func configureTransport(settings *types.StartSettings, cfg opamp.Config) error {
+	serverURL, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return err
+	}
+
+	proxy, err := serverProxy(cfg)
+	if err != nil {
+		return err
+	}
+
+	transportMux.Lock()
+	defer transportMux.Unlock()
+
+	switch serverURL.Scheme {
+	case "ws", "wss":
+		if !isHostProxy(websocket.DefaultDialer.Proxy) {
+			dialerProxyFallback = websocket.DefaultDialer.Proxy
+		}
+		websocket.DefaultDialer.Proxy = hostProxy(serverURL.Host, proxy, dialerProxyFallback)
+	case "http", "https":
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = proxy
+		transport.TLSClientConfig = settings.TLSConfig
+		settings.TLSConfig = nil
+
+		http.DefaultClient.Transport = &hostRoundTripper{
+			host:     serverURL.Host,
+			server:   transport,
+			fallback: previousTransport(),
+		}
+	default:
+		return ErrUnsupportedURL
+	}
+
+	return nil
+}
+
+// previousTransport returns the transport http.DefaultClient used before configureTransport replaced it
+func previousTransport() http.RoundTripper {
+	switch transport := http.DefaultClient.Transport.(type) {
+	case nil:
+		return http.DefaultTransport
+	case *hostRoundTripper:
+		return transport.fallback
+	default:
+		return transport
+	}
+}
+
+// hostProxy returns a proxy func that uses proxy for requests to host and fallback for all others
+func hostProxy(host string, proxy, fallback func(*http.Request) (*url.URL, error)) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		if req.URL.Host == host {
+			return proxy(req)
+		}
+		if fallback == nil {
+			return nil, nil
+		}
+		return fallback(req)
+	}
+}
+
+// isHostProxy returns true if the proxy func was created by hostProxy.
+// Funcs can't be compared, but every func returned by hostProxy shares its code pointer.
+func isHostProxy(proxy func(*http.Request) (*url.URL, error)) bool {
+	if proxy == nil {
+		return false
+	}
+	return reflect.ValueOf(proxy).Pointer() == reflect.ValueOf(hostProxy("", nil, nil)).Pointer()
+}
+
+// hostRoundTripper sends requests to host with the server transport and all others with the fallback
+type hostRoundTripper struct {
+	host     string
+	server   http.RoundTripper
+	fallback http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper
+func (h *hostRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == h.host {
+		return h.server.RoundTrip(req)
+	}
+	return h.fallback.RoundTrip(req)
+}
@@ -5,6 +5,8 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 
+	packagestate "github.com/observiq/bindplane-otel-collector/packagestate"
+
 	protobufs "github.com/open-telemetry/opamp-go/protobufs"
 )
 
@@ -13,6 +15,36 @@ type MockStateManager struct {
 	mock.Mock
 }
 
+// LoadPlan provides a mock function with no fields
+func (_m *MockStateManager) LoadPlan() (*packagestate.UpdatePlan, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LoadPlan")
+	}
+
+	var r0 *packagestate.UpdatePlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*packagestate.UpdatePlan, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *packagestate.UpdatePlan); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*packagestate.UpdatePlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadStatuses provides a mock function with no fields
 func (_m *MockStateManager) LoadStatuses() (*protobufs.PackageStatuses, error) {
 	ret := _m.Called()
@@ -43,6 +75,24 @@ func (_m *MockStateManager) LoadStatuses() (*protobufs.PackageStatuses, error) {
 	return r0, r1
 }
 
+// SavePlan provides a mock function with given fields: plan
+func (_m *MockStateManager) SavePlan(plan *packagestate.UpdatePlan) error {
+	ret := _m.Called(plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*packagestate.UpdatePlan) error); ok {
+		r0 = rf(plan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveStatuses provides a mock function with given fields: statuses
 func (_m *MockStateManager) SaveStatuses(statuses *protobufs.PackageStatuses) error {
 	ret := _m.Called(statuses)
@@ -39,8 +39,17 @@ type StateManager interface {
 	// If none were saved returns error
 	LoadStatuses() (*protobufs.PackageStatuses, error)
 
-	// SaveStatuses saves the given PackageStatuses
+	// SaveStatuses saves the given PackageStatuses.
+	// Any saved UpdatePlan is discarded, since it was made for the previous statuses.
 	SaveStatuses(statuses *protobufs.PackageStatuses) error
+
+	// LoadPlan retrieves the previously saved UpdatePlan.
+	// If the statuses were saved without a plan it returns nil.
+	LoadPlan() (*UpdatePlan, error)
+
+	// SavePlan saves the UpdatePlan alongside the previously saved PackageStatuses.
+	// If no statuses were saved returns error
+	SavePlan(plan *UpdatePlan) error
 }
 
 // FileStateManager manages state on disk via a JSON file
@@ -62,6 +71,7 @@ type packageStates struct {
 	AllPackagesHash []byte                   `json:"all_packages_hash"`
 	AllErrorMessage string                   `json:"all_error_message"`
 	PackageStates   map[string]*packageState `json:"package_states"`
+	UpdatePlan      *UpdatePlan              `json:"update_plan,omitempty"`
 }
 
 // NewFileStateManager creates a new PackagesStateManager
@@ -76,17 +86,12 @@ func NewFileStateManager(logger *zap.Logger, jsonPath string) StateManager {
 func (p *FileStateManager) LoadStatuses() (*protobufs.PackageStatuses, error) {
 	p.logger.Debug("Loading package statuses")
 
-	statusesBytes, err := os.ReadFile(p.jsonPath)
+	states, err := p.loadStates()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read package statuses json: %w", err)
-	}
-
-	var packageStates packageStates
-	if err := json.Unmarshal(statusesBytes, &packageStates); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal package statuses: %w", err)
+		return nil, err
 	}
 
-	return packageStatesToStatuses(packageStates), nil
+	return packageStatesToStatuses(*states), nil
 }
 
 // SaveStatuses saves the given PackageStatuses into a json file
@@ -114,6 +119,55 @@ func (p *FileStateManager) SaveStatuses(statuses *protobufs.PackageStatuses) err
 	return nil
 }
 
+// LoadPlan retrieves the UpdatePlan from a saved json file
+func (p *FileStateManager) LoadPlan() (*UpdatePlan, error) {
+	p.logger.Debug("Loading update plan")
+
+	states, err := p.loadStates()
+	if err != nil {
+		return nil, err
+	}
+
+	return states.UpdatePlan, nil
+}
+
+// SavePlan saves the UpdatePlan into the json file holding the package statuses
+func (p *FileStateManager) SavePlan(plan *UpdatePlan) error {
+	p.logger.Debug("Saving update plan")
+
+	states, err := p.loadStates()
+	if err != nil {
+		return err
+	}
+	states.UpdatePlan = plan
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		return fmt.Errorf("failed to marshal package statuses: %w", err)
+	}
+
+	if err := os.WriteFile(p.jsonPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write package statuses json: %w", err)
+	}
+
+	return nil
+}
+
+// loadStates reads the package states from the json file
+func (p *FileStateManager) loadStates() (*packageStates, error) {
+	statusesBytes, err := os.ReadFile(p.jsonPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package statuses json: %w", err)
+	}
+
+	var states packageStates
+	if err := json.Unmarshal(statusesBytes, &states); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal package statuses: %w", err)
+	}
+
+	return &states, nil
+}
+
 func packageStatusesToStates(statuses *protobufs.PackageStatuses) *packageStates {
 	states := &packageStates{
 		AllPackagesHash: statuses.GetServerProvidedAllPackagesHash(),
@@ -246,3 +246,87 @@ func TestSaveStatuses(t *testing.T) {
 		t.Run(tc.desc, tc.testFunc)
 	}
 }
+
+func TestSaveAndLoadPlan(t *testing.T) {
+	plan := &UpdatePlan{
+		Files: []FileChange{
+			{Path: "/opt/observiq-otel-collector/observiq-otel-collector", Action: FileActionReplace},
+			{Path: "/opt/observiq-otel-collector/plugins/new_plugin.yaml", Action: FileActionCreate},
+		},
+		ServiceChanged: true,
+	}
+
+	testCases := []struct {
+		desc     string
+		testFunc func(*testing.T)
+	}{
+		{
+			desc: "File doesn't exist",
+			testFunc: func(t *testing.T) {
+				tmpDir := t.TempDir()
+				p := NewFileStateManager(zap.NewNop(), filepath.Join(tmpDir, "test.json"))
+
+				err := p.SavePlan(plan)
+				assert.ErrorContains(t, err, "failed to read package statuses json")
+
+				loaded, err := p.LoadPlan()
+				assert.ErrorContains(t, err, "failed to read package statuses json")
+				assert.Nil(t, loaded)
+			},
+		},
+		{
+			desc: "No plan saved",
+			testFunc: func(t *testing.T) {
+				p := NewFileStateManager(zap.NewNop(), filepath.Join("testdata", "package_statuses_good.json"))
+
+				loaded, err := p.LoadPlan()
+				assert.NoError(t, err)
+				assert.Nil(t, loaded)
+			},
+		},
+		{
+			desc: "Plan saved with statuses",
+			testFunc: func(t *testing.T) {
+				tmpDir := t.TempDir()
+				testJSON := filepath.Join(tmpDir, "test.json")
+				good, err := os.ReadFile(filepath.Join("testdata", "package_statuses_good.json"))
+				assert.NoError(t, err)
+				assert.NoError(t, os.WriteFile(testJSON, good, 0600))
+
+				p := NewFileStateManager(zap.NewNop(), testJSON)
+				expectedStatuses, err := p.LoadStatuses()
+				assert.NoError(t, err)
+
+				assert.NoError(t, p.SavePlan(plan))
+
+				loaded, err := p.LoadPlan()
+				assert.NoError(t, err)
+				assert.Equal(t, plan, loaded)
+
+				statuses, err := p.LoadStatuses()
+				assert.NoError(t, err)
+				assert.Equal(t, expectedStatuses, statuses)
+			},
+		},
+		{
+			desc: "Saving statuses discards plan",
+			testFunc: func(t *testing.T) {
+				tmpDir := t.TempDir()
+				testJSON := filepath.Join(tmpDir, "test.json")
+				p := NewFileStateManager(zap.NewNop(), testJSON)
+
+				assert.NoError(t, p.SaveStatuses(&protobufs.PackageStatuses{}))
+				assert.NoError(t, p.SavePlan(plan))
+				assert.NoError(t, p.SaveStatuses(&protobufs.PackageStatuses{}))
+
+				loaded, err := p.LoadPlan()
+				assert.NoError(t, err)
+				assert.Nil(t, loaded)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, tc.testFunc)
+	}
+}
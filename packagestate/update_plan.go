@@ -0,0 +1,51 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packagestate
+
+// FileAction is what an update would do to a file
+type FileAction string
+
+const (
+	// FileActionCreate is a file that doesn't exist yet
+	FileActionCreate FileAction = "create"
+
+	// FileActionReplace is an existing file whose contents would change
+	FileActionReplace FileAction = "replace"
+
+	// FileActionUnchanged is an existing file that would be rewritten with the same contents
+	FileActionUnchanged FileAction = "unchanged"
+)
+
+// FileChange is a file an update would write
+type FileChange struct {
+	// Path is the full path of the file that would be written
+	Path string `json:"path"`
+
+	// Action is what the update would do to the file
+	Action FileAction `json:"action"`
+}
+
+// UpdatePlan previews the changes an update would make, without making them.
+// It's written by a dry run of the updater.
+type UpdatePlan struct {
+	// Files are the files the update would write
+	Files []FileChange `json:"files"`
+
+	// ServiceChanged is true if the update would change the service configuration
+	ServiceChanged bool `json:"service_changed"`
+
+	// Error is why the update would fail, if it would
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,24 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plugins contains the plugins shipped with the collector
+package plugins
+
+import "embed"
+
+// Catalog contains every plugin in this directory.
+// It is used by the plugin receiver to resolve plugins by name when they are not found in a search path.
+//
+//go:embed *.yaml
+var Catalog embed.FS
@@ -56,6 +56,30 @@ type Config struct {
 
 	// ID of the storage extension to use for storing progress
 	StorageID *component.ID `mapstructure:"storage"`
+
+	// Concurrency is the maximum number of objects downloaded and consumed at the same time.
+	// Default value of 1
+	Concurrency int `mapstructure:"concurrency"`
+
+	// Format is the encoding of the telemetry in each object.
+	// Default value of auto detects the format from the object extension.
+	Format rehydration.Format `mapstructure:"format"`
+
+	// Compression is the compression applied to each object.
+	// Default value of auto detects the compression from the object extension.
+	Compression rehydration.Compression `mapstructure:"compression"`
+
+	// Condition is an OTTL condition evaluated against each rehydrated record.
+	// Only records matching the condition are emitted. Default value of "" emits all records.
+	Condition string `mapstructure:"condition"`
+
+	// PrefixTemplate is appended to the s3_prefix when listing objects.
+	// Placeholders in the form {attribute} are replaced with the value from ResourceAttributes
+	// so objects outside of that partition are never listed.
+	PrefixTemplate string `mapstructure:"prefix_template"`
+
+	// ResourceAttributes are the values used to render PrefixTemplate
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
 }
 
 // Validate the configuration
@@ -91,6 +115,22 @@ func (c *Config) Validate() error {
 		return errors.New("poll_timeout must be at least one second")
 	}
 
+	if c.Concurrency < 1 {
+		return errors.New("concurrency must be at least one")
+	}
+
+	if err := c.Format.Validate(); err != nil {
+		return fmt.Errorf("format is invalid: %w", err)
+	}
+
+	if err := c.Compression.Validate(); err != nil {
+		return fmt.Errorf("compression is invalid: %w", err)
+	}
+
+	if _, err := rehydration.RenderPrefix(c.S3Prefix, c.PrefixTemplate, c.ResourceAttributes); err != nil {
+		return fmt.Errorf("prefix_template is invalid: %w", err)
+	}
+
 	return nil
 }
 
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
 	"github.com/observiq/bindplane-otel-collector/receiver/awss3rehydrationreceiver/internal/metadata"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -45,6 +46,9 @@ func createDefaultConfig() component.Config {
 		DeleteOnRead: false,
 		PollInterval: time.Minute,
 		PollTimeout:  time.Second * 30,
+		Concurrency:  1,
+		Format:       rehydration.FormatAuto,
+		Compression:  rehydration.CompressionAuto,
 	}
 }
 
@@ -55,7 +59,7 @@ func createMetricsReceiver(_ context.Context, params receiver.Settings, conf com
 		return nil, errImproperCfgType
 	}
 
-	return newMetricsReceiver(params.ID, params.Logger, cfg, con)
+	return newMetricsReceiver(params, cfg, con)
 }
 
 // createLogsReceiver creates a logs receiver
@@ -65,7 +69,7 @@ func createLogsReceiver(_ context.Context, params receiver.Settings, conf compon
 		return nil, errImproperCfgType
 	}
 
-	return newLogsReceiver(params.ID, params.Logger, cfg, con)
+	return newLogsReceiver(params, cfg, con)
 }
 
 // createTracesReceiver creates a traces receiver
@@ -75,5 +79,5 @@ func createTracesReceiver(_ context.Context, params receiver.Settings, conf comp
 		return nil, errImproperCfgType
 	}
 
-	return newTracesReceiver(params.ID, params.Logger, cfg, con)
+	return newTracesReceiver(params, cfg, con)
 }
@@ -30,6 +30,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/zap"
 )
 
@@ -45,7 +47,7 @@ func Test_newMetricsReceiver(t *testing.T) {
 		EndingTime:   "2023-10-02T17:01",
 	}
 	co := consumertest.NewNop()
-	r, err := newMetricsReceiver(id, testLogger, cfg, co)
+	r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, co)
 	require.NoError(t, err)
 
 	require.Equal(t, testLogger, r.logger)
@@ -67,7 +69,7 @@ func Test_newLogsReceiver(t *testing.T) {
 		EndingTime:   "2023-10-02T17:01",
 	}
 	co := consumertest.NewNop()
-	r, err := newLogsReceiver(id, testLogger, cfg, co)
+	r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, co)
 	require.NoError(t, err)
 
 	require.Equal(t, testLogger, r.logger)
@@ -89,7 +91,7 @@ func Test_newTracesReceiver(t *testing.T) {
 		EndingTime:   "2023-10-02T17:01",
 	}
 	co := consumertest.NewNop()
-	r, err := newTracesReceiver(id, testLogger, cfg, co)
+	r, err := newTracesReceiver(newTestSettings(id, testLogger), cfg, co)
 	require.NoError(t, err)
 
 	require.Equal(t, testLogger, r.logger)
@@ -99,6 +101,27 @@ func Test_newTracesReceiver(t *testing.T) {
 	require.IsType(t, &rehydration.TracesConsumer{}, r.consumer)
 }
 
+func Test_newReceiverFilter(t *testing.T) {
+	setNewAWSClient(t)
+	id := component.MustNewID("test")
+	cfg := &Config{
+		StartingTime:       "2023-10-02T17:00",
+		EndingTime:         "2023-10-02T17:01",
+		S3Prefix:           "root",
+		Condition:          `resource.attributes["host.name"] == "web-1"`,
+		PrefixTemplate:     "host.name={host.name}/",
+		ResourceAttributes: map[string]string{"host.name": "web-1"},
+	}
+
+	r, err := newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, "root/host.name=web-1/", r.prefix)
+
+	cfg.Condition = "not a condition"
+	_, err = newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.ErrorContains(t, err, "invalid condition")
+}
+
 func Test_fullRehydration(t *testing.T) {
 	testType, err := component.NewType("test")
 	require.NoError(t, err)
@@ -125,7 +148,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.MetricsSink{}
-		r, err := newMetricsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -165,7 +188,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.MetricsSink{}
-		r, err := newMetricsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -206,7 +229,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.TracesSink{}
-		r, err := newTracesReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newTracesReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -247,7 +270,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -289,7 +312,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -336,7 +359,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -383,7 +406,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -392,6 +415,55 @@ func Test_fullRehydration(t *testing.T) {
 
 		runRehydrationValidateTest(t, r, checkFunc)
 	})
+
+	t.Run("concurrent objects", func(t *testing.T) {
+		// Test data
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+		expectedBuffSize := int64(len(jsonBytes))
+
+		returnedBlobInfo := []*aws.ObjectInfo{
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=05/bloblogs_1.json",
+				Size: expectedBuffSize,
+			},
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=10/bloblogs_2.json",
+				Size: expectedBuffSize,
+			},
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=15/bloblogs_3.json",
+				Size: expectedBuffSize,
+			},
+		}
+
+		concurrentCfg := *cfg
+		concurrentCfg.Concurrency = 3
+
+		// Setup mocks
+		mockClient := setNewAWSClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.S3Bucket, (*string)(nil), (*string)(nil)).Return(returnedBlobInfo, nil, nil)
+		mockClient.EXPECT().DownloadObject(mock.Anything, cfg.S3Bucket, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+			copy(buf, jsonBytes)
+			return expectedBuffSize, nil
+		}).Times(3)
+
+		// Create new receiver
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), &concurrentCfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.LogRecordCount() == 3*logs.LogRecordCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+
+		snapshot := r.progress.Snapshot()
+		require.Equal(t, int64(3), snapshot.ObjectsTotal)
+		require.Equal(t, int64(3), snapshot.ObjectsDone)
+		require.Equal(t, 3*expectedBuffSize, snapshot.Bytes)
+		require.Equal(t, time.Date(2023, 10, 2, 17, 15, 0, 0, time.UTC), snapshot.TimeCursor)
+	})
 }
 
 // runRehydrationValidateTest runs the rehydration tests with the passed in checkFunc
@@ -408,6 +480,14 @@ func runRehydrationValidateTest(t *testing.T, r *rehydrationReceiver, checkFunc
 	require.NoError(t, err)
 }
 
+// newTestSettings returns receiver settings with the given id and logger
+func newTestSettings(id component.ID, logger *zap.Logger) receiver.Settings {
+	params := receivertest.NewNopSettings()
+	params.ID = id
+	params.Logger = logger
+	return params
+}
+
 // setNewAWSClient helper function used to set the newAWSS3Client
 // function with a mock and return the mock.
 func setNewAWSClient(t *testing.T) *mocks.MockS3Client {
@@ -18,7 +18,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"time"
 
 	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
@@ -26,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 )
 
@@ -41,6 +41,10 @@ type rehydrationReceiver struct {
 	supportedTelemetry pipeline.Signal
 	consumer           rehydration.Consumer
 	checkpointStore    rehydration.CheckpointStorer
+	progress           *rehydration.Progress
+
+	// prefix is the rendered prefix used when listing objects
+	prefix string
 
 	startingTime time.Time
 	endingTime   time.Time
@@ -52,8 +56,13 @@ type rehydrationReceiver struct {
 }
 
 // newMetricsReceiver creates a new metrics specific receiver.
-func newMetricsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsumer consumer.Metrics) (*rehydrationReceiver, error) {
-	r, err := newRehydrationReceiver(id, logger, cfg)
+func newMetricsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Metrics) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithMetricsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
 	if err != nil {
 		return nil, err
 	}
@@ -65,8 +74,13 @@ func newMetricsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextCo
 }
 
 // newLogsReceiver creates a new logs specific receiver.
-func newLogsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsumer consumer.Logs) (*rehydrationReceiver, error) {
-	r, err := newRehydrationReceiver(id, logger, cfg)
+func newLogsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Logs) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithLogsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
 	if err != nil {
 		return nil, err
 	}
@@ -78,8 +92,13 @@ func newLogsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsu
 }
 
 // newTracesReceiver creates a new traces specific receiver.
-func newTracesReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) (*rehydrationReceiver, error) {
-	r, err := newRehydrationReceiver(id, logger, cfg)
+func newTracesReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Traces) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithTracesCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +110,7 @@ func newTracesReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextCon
 }
 
 // newRehydrationReceiver creates a new rehydration receiver
-func newRehydrationReceiver(id component.ID, logger *zap.Logger, cfg *Config) (*rehydrationReceiver, error) {
+func newRehydrationReceiver(params receiver.Settings, cfg *Config) (*rehydrationReceiver, error) {
 	awsClient, err := newAWSS3Client(cfg.Region, cfg.RoleArn)
 	if err != nil {
 		return nil, fmt.Errorf("new aws s3 client: %w", err)
@@ -109,15 +128,27 @@ func newRehydrationReceiver(id component.ID, logger *zap.Logger, cfg *Config) (*
 		return nil, fmt.Errorf("invalid ending_time timestamp: %w", err)
 	}
 
+	prefix, err := rehydration.RenderPrefix(cfg.S3Prefix, cfg.PrefixTemplate, cfg.ResourceAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix_template: %w", err)
+	}
+
+	progress, err := rehydration.NewProgress(params.TelemetrySettings.MeterProvider, params.ID, startingTime, endingTime)
+	if err != nil {
+		return nil, fmt.Errorf("new progress: %w", err)
+	}
+
 	ctx, cancel := context.WithCancelCause(context.Background())
 
 	return &rehydrationReceiver{
-		logger:          logger,
-		id:              id,
+		logger:          params.Logger,
+		id:              params.ID,
 		cfg:             cfg,
 		awsClient:       awsClient,
 		doneChan:        make(chan struct{}),
 		checkpointStore: rehydration.NewNopStorage(),
+		progress:        progress,
+		prefix:          prefix,
 		startingTime:    startingTime,
 		endingTime:      endingTime,
 		ctx:             ctx,
@@ -156,7 +187,7 @@ func (r *rehydrationReceiver) Shutdown(ctx context.Context) error {
 		}
 	}
 
-	err = errors.Join(err, r.checkpointStore.Close(ctx))
+	err = errors.Join(err, r.checkpointStore.Close(ctx), r.progress.Close())
 
 	return err
 }
@@ -206,8 +237,8 @@ func (r *rehydrationReceiver) rehydrate(checkpoint *rehydration.CheckPoint, mark
 	defer cancel()
 
 	var prefix *string
-	if r.cfg.S3Prefix != "" {
-		prefix = &r.cfg.S3Prefix
+	if r.prefix != "" {
+		prefix = &r.prefix
 	}
 
 	objects, nextMarker, err := r.awsClient.ListObjects(rehydrateCtx, r.cfg.S3Bucket, prefix, marker)
@@ -218,8 +249,7 @@ func (r *rehydrationReceiver) rehydrate(checkpoint *rehydration.CheckPoint, mark
 
 	marker = nextMarker
 
-	processedObjectNames := make([]string, 0, len(objects))
-
+	entities := make([]rehydration.Entity, 0, len(objects))
 	for _, object := range objects {
 		r.logger.Debug("Object", zap.String("name", object.Name))
 		objectTime, telemetryType, err := rehydration.ParseEntityPath(object.Name)
@@ -231,24 +261,39 @@ func (r *rehydrationReceiver) rehydrate(checkpoint *rehydration.CheckPoint, mark
 		case checkpoint.ShouldParse(*objectTime, object.Name):
 			// if the object is not in the specified time range or not of the telemetry type supported by this receiver
 			// then skip consuming it.
-			if !rehydration.IsInTimeRange(*objectTime, r.startingTime, r.endingTime) || telemetryType != r.supportedTelemetry {
+			if !rehydration.IsInTimeRange(*objectTime, r.startingTime, r.endingTime) || !rehydration.SupportsTelemetry(telemetryType, r.supportedTelemetry, r.cfg.Format) {
 				continue
 			}
 
-			// Process and consume the object at the given path
-			if err := r.processObject(object); err != nil {
-				r.logger.Error("Error consuming object", zap.String("object", object.Name), zap.Error(err))
-				continue
-			}
+			entities = append(entities, rehydration.Entity{Name: object.Name, Time: *objectTime, Size: object.Size})
+		}
+	}
 
-			checkpoint.UpdateCheckpoint(*objectTime, object.Name)
-			if err := r.checkpointStore.SaveCheckpoint(r.ctx, r.checkpointKey(), checkpoint); err != nil {
-				r.logger.Error("Error while saving checkpoint", zap.Error(err))
-			}
+	r.progress.AddTotal(len(entities))
+
+	processedObjectNames := make([]string, 0, len(entities))
+
+	// Objects are downloaded and consumed concurrently but committed in listing order
+	// so the checkpoint never moves past an object that hasn't been rehydrated.
+	rehydration.ProcessEntities(r.ctx, entities, r.cfg.Concurrency, r.processObject, func(entity rehydration.Entity, err error) {
+		if err != nil {
+			r.logger.Error("Error consuming object", zap.String("object", entity.Name), zap.Error(err))
+			return
+		}
 
-			// keep track of object names for number processed and deleting
-			processedObjectNames = append(processedObjectNames, object.Name)
+		r.progress.Done(entity)
+
+		checkpoint.UpdateCheckpoint(entity.Time, entity.Name)
+		if err := r.checkpointStore.SaveCheckpoint(r.ctx, r.checkpointKey(), checkpoint); err != nil {
+			r.logger.Error("Error while saving checkpoint", zap.Error(err))
 		}
+
+		// keep track of object names for number processed and deleting
+		processedObjectNames = append(processedObjectNames, entity.Name)
+	})
+
+	if len(entities) > 0 {
+		r.progress.Log(r.logger)
 	}
 
 	numEntitiesRehydrated = len(processedObjectNames)
@@ -265,30 +310,27 @@ func (r *rehydrationReceiver) rehydrate(checkpoint *rehydration.CheckPoint, mark
 
 // processObject does the following:
 // 1. Downloads the object
-// 2. Decompresses the object if applicable
+// 2. Decompresses the object if applicable and detects its format
 // 3. Pass the object to the consumer
-func (r *rehydrationReceiver) processObject(object *aws.ObjectInfo) error {
+func (r *rehydrationReceiver) processObject(ctx context.Context, object rehydration.Entity) error {
 	objectBuffer := make([]byte, object.Size)
 
-	size, err := r.awsClient.DownloadObject(r.ctx, r.cfg.S3Bucket, object.Name, objectBuffer)
+	size, err := r.awsClient.DownloadObject(ctx, r.cfg.S3Bucket, object.Name, objectBuffer)
 	if err != nil {
 		return fmt.Errorf("download object: %w", err)
 	}
 
-	ext := filepath.Ext(object.Name)
-	switch {
-	case ext == ".gz":
-		objectBuffer, err = rehydration.GzipDecompress(objectBuffer[:size])
-		if err != nil {
-			return fmt.Errorf("gzip: %w", err)
-		}
-	case ext == ".json":
-		// Do nothing for json files
-	default:
-		return fmt.Errorf("unsupported file type: %s", ext)
+	format, compression, err := rehydration.DetectEncoding(object.Name, r.cfg.Format, r.cfg.Compression)
+	if err != nil {
+		return err
+	}
+
+	objectBuffer, err = rehydration.Decompress(objectBuffer[:size], compression)
+	if err != nil {
+		return err
 	}
 
-	if err := r.consumer.Consume(r.ctx, objectBuffer); err != nil {
+	if err := r.consumer.Consume(ctx, objectBuffer, format); err != nil {
 		return fmt.Errorf("consume: %w", err)
 	}
 
@@ -39,6 +39,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("connection_string is required"),
 		},
@@ -53,6 +54,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("container is required"),
 		},
@@ -67,6 +69,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("starting_time is invalid: missing value"),
 		},
@@ -81,6 +84,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("ending_time is invalid: missing value"),
 		},
@@ -95,6 +99,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("starting_time is invalid: invalid timestamp"),
 		},
@@ -109,6 +114,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("ending_time is invalid: invalid timestamp"),
 		},
@@ -123,6 +129,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("ending_time must be at least one minute after starting_time"),
 		},
@@ -137,6 +144,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Millisecond,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("poll_interval must be at least one second"),
 		},
@@ -151,9 +159,73 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second * 2,
 				PollTimeout:      time.Millisecond,
+				Concurrency:      1,
 			},
 			expectErr: errors.New("poll_timeout must be at least one second"),
 		},
+		{
+			desc: "Bad concurrency",
+			cfg: &Config{
+				ConnectionString: "connection_string",
+				Container:        "container",
+				RootFolder:       "root",
+				StartingTime:     "2023-10-02T17:00",
+				EndingTime:       "2023-10-02T17:01",
+				DeleteOnRead:     false,
+				PollInterval:     time.Second,
+				PollTimeout:      time.Second * 10,
+				Concurrency:      0,
+			},
+			expectErr: errors.New("concurrency must be at least one"),
+		},
+		{
+			desc: "Bad format",
+			cfg: &Config{
+				ConnectionString: "connection_string",
+				Container:        "container",
+				RootFolder:       "root",
+				StartingTime:     "2023-10-02T17:00",
+				EndingTime:       "2023-10-02T17:01",
+				DeleteOnRead:     false,
+				PollInterval:     time.Second,
+				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
+				Format:           "csv",
+			},
+			expectErr: errors.New(`format is invalid: invalid format "csv"`),
+		},
+		{
+			desc: "Bad prefix_template",
+			cfg: &Config{
+				ConnectionString: "connection_string",
+				Container:        "container",
+				RootFolder:       "root",
+				StartingTime:     "2023-10-02T17:00",
+				EndingTime:       "2023-10-02T17:01",
+				DeleteOnRead:     false,
+				PollInterval:     time.Second,
+				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
+				PrefixTemplate:   "{host.name}/",
+			},
+			expectErr: errors.New("prefix_template is invalid: resource attributes not set for prefix template: host.name"),
+		},
+		{
+			desc: "Bad compression",
+			cfg: &Config{
+				ConnectionString: "connection_string",
+				Container:        "container",
+				RootFolder:       "root",
+				StartingTime:     "2023-10-02T17:00",
+				EndingTime:       "2023-10-02T17:01",
+				DeleteOnRead:     false,
+				PollInterval:     time.Second,
+				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
+				Compression:      "bz2",
+			},
+			expectErr: errors.New(`compression is invalid: invalid compression "bz2"`),
+		},
 		{
 			desc: "Valid config",
 			cfg: &Config{
@@ -165,6 +237,7 @@ func TestConfigValidate(t *testing.T) {
 				DeleteOnRead:     false,
 				PollInterval:     time.Second,
 				PollTimeout:      time.Second * 10,
+				Concurrency:      1,
 			},
 			expectErr: nil,
 		},
@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,6 +27,9 @@ func Test_createDefaultConfig(t *testing.T) {
 		DeleteOnRead: false,
 		PollInterval: time.Minute,
 		PollTimeout:  time.Second * 30,
+		Concurrency:  1,
+		Format:       rehydration.FormatAuto,
+		Compression:  rehydration.CompressionAuto,
 	}
 
 	componentCfg := createDefaultConfig()
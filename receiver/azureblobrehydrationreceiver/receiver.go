@@ -18,7 +18,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"path/filepath"
 	"time"
 
 	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
@@ -26,6 +25,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
 )
 
@@ -41,6 +41,10 @@ type rehydrationReceiver struct {
 	supportedTelemetry pipeline.Signal
 	consumer           rehydration.Consumer
 	checkpointStore    rehydration.CheckpointStorer
+	progress           *rehydration.Progress
+
+	// prefix is the rendered prefix used when listing blobs
+	prefix string
 
 	startingTime time.Time
 	endingTime   time.Time
@@ -52,8 +56,13 @@ type rehydrationReceiver struct {
 }
 
 // newMetricsReceiver creates a new metrics specific receiver.
-func newMetricsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsumer consumer.Metrics) (*rehydrationReceiver, error) {
-	r, err := newRehydrationReceiver(id, logger, cfg)
+func newMetricsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Metrics) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithMetricsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
 	if err != nil {
 		return nil, err
 	}
@@ -65,8 +74,13 @@ func newMetricsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextCo
 }
 
 // newLogsReceiver creates a new logs specific receiver.
-func newLogsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsumer consumer.Logs) (*rehydrationReceiver, error) {
-	r, err := newRehydrationReceiver(id, logger, cfg)
+func newLogsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Logs) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithLogsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
 	if err != nil {
 		return nil, err
 	}
@@ -78,8 +92,13 @@ func newLogsReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsu
 }
 
 // newTracesReceiver creates a new traces specific receiver.
-func newTracesReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextConsumer consumer.Traces) (*rehydrationReceiver, error) {
-	r, err := newRehydrationReceiver(id, logger, cfg)
+func newTracesReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Traces) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithTracesCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
 	if err != nil {
 		return nil, err
 	}
@@ -91,7 +110,7 @@ func newTracesReceiver(id component.ID, logger *zap.Logger, cfg *Config, nextCon
 }
 
 // newRehydrationReceiver creates a new rehydration receiver
-func newRehydrationReceiver(id component.ID, logger *zap.Logger, cfg *Config) (*rehydrationReceiver, error) {
+func newRehydrationReceiver(params receiver.Settings, cfg *Config) (*rehydrationReceiver, error) {
 	azureClient, err := newAzureBlobClient(cfg.ConnectionString)
 	if err != nil {
 		return nil, fmt.Errorf("new Azure client: %w", err)
@@ -109,15 +128,27 @@ func newRehydrationReceiver(id component.ID, logger *zap.Logger, cfg *Config) (*
 		return nil, fmt.Errorf("invalid ending_time timestamp: %w", err)
 	}
 
+	prefix, err := rehydration.RenderPrefix(cfg.RootFolder, cfg.PrefixTemplate, cfg.ResourceAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix_template: %w", err)
+	}
+
+	progress, err := rehydration.NewProgress(params.TelemetrySettings.MeterProvider, params.ID, startingTime, endingTime)
+	if err != nil {
+		return nil, fmt.Errorf("new progress: %w", err)
+	}
+
 	ctx, cancel := context.WithCancelCause(context.Background())
 
 	return &rehydrationReceiver{
-		logger:          logger,
-		id:              id,
+		logger:          params.Logger,
+		id:              params.ID,
 		cfg:             cfg,
 		azureClient:     azureClient,
 		doneChan:        make(chan struct{}),
 		checkpointStore: rehydration.NewNopStorage(),
+		progress:        progress,
+		prefix:          prefix,
 		startingTime:    startingTime,
 		endingTime:      endingTime,
 		ctx:             ctx,
@@ -156,7 +187,7 @@ func (r *rehydrationReceiver) Shutdown(ctx context.Context) error {
 		}
 	}
 
-	err = errors.Join(err, r.checkpointStore.Close(ctx))
+	err = errors.Join(err, r.checkpointStore.Close(ctx), r.progress.Close())
 
 	return err
 }
@@ -207,8 +238,8 @@ func (r *rehydrationReceiver) scrape() {
 // The count of blobs processed will be returned
 func (r *rehydrationReceiver) rehydrateBlobs(checkpoint *rehydration.CheckPoint, marker *string) (numBlobsRehydrated int) {
 	var prefix *string
-	if r.cfg.RootFolder != "" {
-		prefix = &r.cfg.RootFolder
+	if r.prefix != "" {
+		prefix = &r.prefix
 	}
 
 	ctxTimeout, cancel := context.WithTimeout(r.ctx, r.cfg.PollTimeout)
@@ -224,6 +255,7 @@ func (r *rehydrationReceiver) rehydrateBlobs(checkpoint *rehydration.CheckPoint,
 	marker = nextMarker
 
 	// Go through each blob and parse it's path to determine if we should consume it or not
+	entities := make([]rehydration.Entity, 0, len(blobs))
 	for _, blob := range blobs {
 		blobTime, telemetryType, err := rehydration.ParseEntityPath(blob.Name)
 		switch {
@@ -234,31 +266,43 @@ func (r *rehydrationReceiver) rehydrateBlobs(checkpoint *rehydration.CheckPoint,
 		case checkpoint.ShouldParse(*blobTime, blob.Name):
 			// if the blob is not in the specified time range or not of the telemetry type supported by this receiver
 			// then skip consuming it.
-			if !rehydration.IsInTimeRange(*blobTime, r.startingTime, r.endingTime) || telemetryType != r.supportedTelemetry {
+			if !rehydration.IsInTimeRange(*blobTime, r.startingTime, r.endingTime) || !rehydration.SupportsTelemetry(telemetryType, r.supportedTelemetry, r.cfg.Format) {
 				continue
 			}
 
-			// Process and consume the blob at the given path
-			if err := r.processBlob(blob); err != nil {
-				r.logger.Error("Error consuming blob", zap.String("blob", blob.Name), zap.Error(err))
-				continue
-			}
+			entities = append(entities, rehydration.Entity{Name: blob.Name, Time: *blobTime, Size: blob.Size})
+		}
+	}
 
-			numBlobsRehydrated++
+	r.progress.AddTotal(len(entities))
 
-			// Update and save the checkpoint with the most recently processed blob
-			checkpoint.UpdateCheckpoint(*blobTime, blob.Name)
-			if err := r.checkpointStore.SaveCheckpoint(r.ctx, r.checkpointKey(), checkpoint); err != nil {
-				r.logger.Error("Error while saving checkpoint", zap.Error(err))
-			}
+	// Blobs are downloaded and consumed concurrently but committed in listing order
+	// so the checkpoint never moves past a blob that hasn't been rehydrated.
+	rehydration.ProcessEntities(r.ctx, entities, r.cfg.Concurrency, r.processBlob, func(blob rehydration.Entity, err error) {
+		if err != nil {
+			r.logger.Error("Error consuming blob", zap.String("blob", blob.Name), zap.Error(err))
+			return
+		}
 
-			// Delete blob if configured to do so
-			if r.cfg.DeleteOnRead {
-				if err := r.azureClient.DeleteBlob(r.ctx, r.cfg.Container, blob.Name); err != nil {
-					r.logger.Error("Error while attempting to delete blob", zap.String("blob", blob.Name), zap.Error(err))
-				}
+		numBlobsRehydrated++
+		r.progress.Done(blob)
+
+		// Update and save the checkpoint with the most recently processed blob
+		checkpoint.UpdateCheckpoint(blob.Time, blob.Name)
+		if err := r.checkpointStore.SaveCheckpoint(r.ctx, r.checkpointKey(), checkpoint); err != nil {
+			r.logger.Error("Error while saving checkpoint", zap.Error(err))
+		}
+
+		// Delete blob if configured to do so
+		if r.cfg.DeleteOnRead {
+			if err := r.azureClient.DeleteBlob(r.ctx, r.cfg.Container, blob.Name); err != nil {
+				r.logger.Error("Error while attempting to delete blob", zap.String("blob", blob.Name), zap.Error(err))
 			}
 		}
+	})
+
+	if len(entities) > 0 {
+		r.progress.Log(r.logger)
 	}
 
 	return
@@ -266,32 +310,28 @@ func (r *rehydrationReceiver) rehydrateBlobs(checkpoint *rehydration.CheckPoint,
 
 // processBlob does the following:
 // 1. Downloads the blob
-// 2. Decompresses the blob if applicable
+// 2. Decompresses the blob if applicable and detects its format
 // 3. Pass the blob to the consumer
-func (r *rehydrationReceiver) processBlob(blob *azureblob.BlobInfo) error {
+func (r *rehydrationReceiver) processBlob(ctx context.Context, blob rehydration.Entity) error {
 	// Allocate a buffer the size of the blob. If the buffer isn't big enough download errors.
 	blobBuffer := make([]byte, blob.Size)
 
-	size, err := r.azureClient.DownloadBlob(r.ctx, r.cfg.Container, blob.Name, blobBuffer)
+	size, err := r.azureClient.DownloadBlob(ctx, r.cfg.Container, blob.Name, blobBuffer)
 	if err != nil {
 		return fmt.Errorf("download blob: %w", err)
 	}
 
-	// Check file extension see if we need to decompress
-	ext := filepath.Ext(blob.Name)
-	switch {
-	case ext == ".gz":
-		blobBuffer, err = rehydration.GzipDecompress(blobBuffer[:size])
-		if err != nil {
-			return fmt.Errorf("gzip: %w", err)
-		}
-	case ext == ".json":
-		// Do nothing for json files
-	default:
-		return fmt.Errorf("unsupported file type: %s", ext)
+	format, compression, err := rehydration.DetectEncoding(blob.Name, r.cfg.Format, r.cfg.Compression)
+	if err != nil {
+		return err
+	}
+
+	blobBuffer, err = rehydration.Decompress(blobBuffer[:size], compression)
+	if err != nil {
+		return err
 	}
 
-	if err := r.consumer.Consume(r.ctx, blobBuffer); err != nil {
+	if err := r.consumer.Consume(ctx, blobBuffer, format); err != nil {
 		return fmt.Errorf("consume: %w", err)
 	}
 	return nil
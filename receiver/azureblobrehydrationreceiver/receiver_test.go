@@ -33,6 +33,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
 	"go.uber.org/zap"
 )
 
@@ -48,7 +50,7 @@ func Test_newMetricsReceiver(t *testing.T) {
 		EndingTime:   "2023-10-02T17:01",
 	}
 	co := consumertest.NewNop()
-	r, err := newMetricsReceiver(id, testLogger, cfg, co)
+	r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, co)
 	require.NoError(t, err)
 
 	require.Equal(t, testLogger, r.logger)
@@ -70,7 +72,7 @@ func Test_newLogsReceiver(t *testing.T) {
 		EndingTime:   "2023-10-02T17:01",
 	}
 	co := consumertest.NewNop()
-	r, err := newLogsReceiver(id, testLogger, cfg, co)
+	r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, co)
 	require.NoError(t, err)
 
 	require.Equal(t, testLogger, r.logger)
@@ -92,7 +94,7 @@ func Test_newTracesReceiver(t *testing.T) {
 		EndingTime:   "2023-10-02T17:01",
 	}
 	co := consumertest.NewNop()
-	r, err := newTracesReceiver(id, testLogger, cfg, co)
+	r, err := newTracesReceiver(newTestSettings(id, testLogger), cfg, co)
 	require.NoError(t, err)
 
 	require.Equal(t, testLogger, r.logger)
@@ -102,6 +104,27 @@ func Test_newTracesReceiver(t *testing.T) {
 	require.IsType(t, &rehydration.TracesConsumer{}, r.consumer)
 }
 
+func Test_newReceiverFilter(t *testing.T) {
+	setNewAzureBlobClient(t)
+	id := component.MustNewID("test")
+	cfg := &Config{
+		StartingTime:       "2023-10-02T17:00",
+		EndingTime:         "2023-10-02T17:01",
+		RootFolder:         "root",
+		Condition:          `resource.attributes["host.name"] == "web-1"`,
+		PrefixTemplate:     "host.name={host.name}/",
+		ResourceAttributes: map[string]string{"host.name": "web-1"},
+	}
+
+	r, err := newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, "root/host.name=web-1/", r.prefix)
+
+	cfg.Condition = "not a condition"
+	_, err = newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.ErrorContains(t, err, "invalid condition")
+}
+
 func Test_fullRehydration(t *testing.T) {
 	testType, err := component.NewType("test")
 	require.NoError(t, err)
@@ -128,7 +151,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.MetricsSink{}
-		r, err := newMetricsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -168,7 +191,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.MetricsSink{}
-		r, err := newMetricsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -209,7 +232,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.TracesSink{}
-		r, err := newTracesReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newTracesReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -250,7 +273,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -292,7 +315,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -339,7 +362,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -386,7 +409,7 @@ func Test_fullRehydration(t *testing.T) {
 
 		// Create new receiver
 		testConsumer := &consumertest.LogsSink{}
-		r, err := newLogsReceiver(id, testLogger, cfg, testConsumer)
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
 		require.NoError(t, err)
 
 		checkFunc := func() bool {
@@ -406,13 +429,14 @@ func Test_processBlob(t *testing.T) {
 
 	testcases := []struct {
 		desc        string
-		info        *azureblob.BlobInfo
+		info        rehydration.Entity
+		format      rehydration.Format
 		mockSetup   func(*blobmocks.MockBlobClient, *rehydration.MockConsumer)
 		expectedErr error
 	}{
 		{
 			desc: "Download blob error",
-			info: &azureblob.BlobInfo{
+			info: rehydration.Entity{
 				Name: "blob.json",
 				Size: 10,
 			},
@@ -423,7 +447,7 @@ func Test_processBlob(t *testing.T) {
 		},
 		{
 			desc: "unsupported extension",
-			info: &azureblob.BlobInfo{
+			info: rehydration.Entity{
 				Name: "blob.nope",
 				Size: 10,
 			},
@@ -434,7 +458,7 @@ func Test_processBlob(t *testing.T) {
 		},
 		{
 			desc: "Gzip compression",
-			info: &azureblob.BlobInfo{
+			info: rehydration.Entity{
 				Name: "blob.json.gz",
 				Size: int64(len(gzipData)),
 			},
@@ -444,13 +468,13 @@ func Test_processBlob(t *testing.T) {
 					return int64(len(gzipData)), nil
 				})
 
-				mockConsumer.EXPECT().Consume(mock.Anything, jsonData).Return(nil)
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatOTLPJSON).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
 			desc: "Json no compression",
-			info: &azureblob.BlobInfo{
+			info: rehydration.Entity{
 				Name: "blob.json",
 				Size: int64(len(jsonData)),
 			},
@@ -460,13 +484,13 @@ func Test_processBlob(t *testing.T) {
 					return int64(len(jsonData)), nil
 				})
 
-				mockConsumer.EXPECT().Consume(mock.Anything, jsonData).Return(nil)
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatOTLPJSON).Return(nil)
 			},
 			expectedErr: nil,
 		},
 		{
 			desc: "Consume error",
-			info: &azureblob.BlobInfo{
+			info: rehydration.Entity{
 				Name: "blob.json",
 				Size: int64(len(jsonData)),
 			},
@@ -476,10 +500,27 @@ func Test_processBlob(t *testing.T) {
 					return int64(len(jsonData)), nil
 				})
 
-				mockConsumer.EXPECT().Consume(mock.Anything, jsonData).Return(errors.New("bad"))
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatOTLPJSON).Return(errors.New("bad"))
 			},
 			expectedErr: errors.New("consume: bad"),
 		},
+		{
+			desc: "Explicit format without extension",
+			info: rehydration.Entity{
+				Name: "blob",
+				Size: int64(len(jsonData)),
+			},
+			format: rehydration.FormatNDJSON,
+			mockSetup: func(mockClient *blobmocks.MockBlobClient, mockConsumer *rehydration.MockConsumer) {
+				mockClient.EXPECT().DownloadBlob(mock.Anything, containerName, "blob", mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+					copy(buf, jsonData)
+					return int64(len(jsonData)), nil
+				})
+
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatNDJSON).Return(nil)
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -493,13 +534,14 @@ func Test_processBlob(t *testing.T) {
 				logger: zap.NewNop(),
 				cfg: &Config{
 					Container: containerName,
+					Format:    tc.format,
 				},
 				consumer:    mockConsumer,
 				azureClient: mockClient,
 				ctx:         context.Background(),
 			}
 
-			err := r.processBlob(tc.info)
+			err := r.processBlob(context.Background(), tc.info)
 			if tc.expectedErr == nil {
 				require.NoError(t, err)
 			} else {
@@ -509,6 +551,14 @@ func Test_processBlob(t *testing.T) {
 	}
 }
 
+// newTestSettings returns receiver settings with the given id and logger
+func newTestSettings(id component.ID, logger *zap.Logger) receiver.Settings {
+	params := receivertest.NewNopSettings()
+	params.ID = id
+	params.Logger = logger
+	return params
+}
+
 // setNewAzureBlobClient helper function used to set the newAzureBlobClient
 // function with a mock and return the mock.
 func setNewAzureBlobClient(t *testing.T) *blobmocks.MockBlobClient {
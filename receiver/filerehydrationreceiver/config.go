@@ -0,0 +1,129 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/filerehydrationreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config is the configuration for the file rehydration receiver
+type Config struct {
+	// Directory is the root directory to rehydrate files from. (no default)
+	Directory string `mapstructure:"directory"`
+
+	// StartingTime the UTC timestamp to start rehydration from.
+	StartingTime string `mapstructure:"starting_time"`
+
+	// EndingTime the UTC timestamp to rehydrate up until.
+	EndingTime string `mapstructure:"ending_time"`
+
+	// DeleteOnRead indicates if a file should be deleted once it has been processed
+	// Default value of false
+	DeleteOnRead bool `mapstructure:"delete_on_read"`
+
+	// PollInterval is the interval at which the directory is scanned for files.
+	// Default value of 1m
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// ID of the storage extension to use for storing progress
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// Concurrency is the maximum number of files read and consumed at the same time.
+	// Default value of 1
+	Concurrency int `mapstructure:"concurrency"`
+
+	// Format is the encoding of the telemetry in each file.
+	// Default value of auto detects the format from the file extension.
+	Format rehydration.Format `mapstructure:"format"`
+
+	// Compression is the compression applied to each file.
+	// Default value of auto detects the compression from the file extension.
+	Compression rehydration.Compression `mapstructure:"compression"`
+
+	// Condition is an OTTL condition evaluated against each rehydrated record.
+	// Only records matching the condition are emitted. Default value of "" emits all records.
+	Condition string `mapstructure:"condition"`
+
+	// PrefixTemplate is appended to the directory when scanning for files.
+	// Placeholders in the form {attribute} are replaced with the value from ResourceAttributes
+	// so files outside of that partition are never read.
+	PrefixTemplate string `mapstructure:"prefix_template"`
+
+	// ResourceAttributes are the values used to render PrefixTemplate
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+}
+
+// Validate validates the config
+func (c *Config) Validate() error {
+	if c.Directory == "" {
+		return errors.New("directory is required")
+	}
+
+	startingTs, err := validateTimestamp(c.StartingTime)
+	if err != nil {
+		return fmt.Errorf("starting_time is invalid: %w", err)
+	}
+
+	endingTs, err := validateTimestamp(c.EndingTime)
+	if err != nil {
+		return fmt.Errorf("ending_time is invalid: %w", err)
+	}
+
+	// Check case where ending_time is to close or before starting time
+	if endingTs.Sub(*startingTs) < time.Minute {
+		return errors.New("ending_time must be at least one minute after starting_time")
+	}
+
+	if c.PollInterval < time.Second {
+		return errors.New("poll_interval must be at least one second")
+	}
+
+	if c.Concurrency < 1 {
+		return errors.New("concurrency must be at least one")
+	}
+
+	if err := c.Format.Validate(); err != nil {
+		return fmt.Errorf("format is invalid: %w", err)
+	}
+
+	if err := c.Compression.Validate(); err != nil {
+		return fmt.Errorf("compression is invalid: %w", err)
+	}
+
+	if _, err := rehydration.RenderPrefix("", c.PrefixTemplate, c.ResourceAttributes); err != nil {
+		return fmt.Errorf("prefix_template is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// validateTimestamp validates the passed in timestamp string
+func validateTimestamp(timestamp string) (*time.Time, error) {
+	if timestamp == "" {
+		return nil, errors.New("missing value")
+	}
+
+	ts, err := time.Parse(rehydration.TimeFormat, timestamp)
+	if err != nil {
+		return nil, errors.New("invalid timestamp format must be in the form YYYY-MM-DDTHH:MM")
+	}
+
+	return &ts, nil
+}
@@ -0,0 +1,125 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/filerehydrationreceiver"
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	validConfig := func() *Config {
+		return &Config{
+			Directory:    "/var/lib/archive",
+			StartingTime: "2023-10-02T17:00",
+			EndingTime:   "2023-10-02T17:01",
+			DeleteOnRead: false,
+			PollInterval: time.Second,
+			Concurrency:  1,
+		}
+	}
+
+	testCases := []struct {
+		desc      string
+		modify    func(*Config)
+		expectErr error
+	}{
+		{
+			desc: "Missing directory",
+			modify: func(c *Config) {
+				c.Directory = ""
+			},
+			expectErr: errors.New("directory is required"),
+		},
+		{
+			desc: "Missing starting_time",
+			modify: func(c *Config) {
+				c.StartingTime = ""
+			},
+			expectErr: errors.New("starting_time is invalid: missing value"),
+		},
+		{
+			desc: "Invalid ending_time",
+			modify: func(c *Config) {
+				c.EndingTime = "2023-10-02 17:01"
+			},
+			expectErr: errors.New("ending_time is invalid: invalid timestamp"),
+		},
+		{
+			desc: "ending_time before starting_time",
+			modify: func(c *Config) {
+				c.EndingTime = "2023-10-02T16:01"
+			},
+			expectErr: errors.New("ending_time must be at least one minute after starting_time"),
+		},
+		{
+			desc: "Bad poll_interval",
+			modify: func(c *Config) {
+				c.PollInterval = time.Millisecond
+			},
+			expectErr: errors.New("poll_interval must be at least one second"),
+		},
+		{
+			desc: "Bad concurrency",
+			modify: func(c *Config) {
+				c.Concurrency = 0
+			},
+			expectErr: errors.New("concurrency must be at least one"),
+		},
+		{
+			desc: "Bad format",
+			modify: func(c *Config) {
+				c.Format = "csv"
+			},
+			expectErr: errors.New(`format is invalid: invalid format "csv"`),
+		},
+		{
+			desc: "Bad compression",
+			modify: func(c *Config) {
+				c.Compression = "bz2"
+			},
+			expectErr: errors.New(`compression is invalid: invalid compression "bz2"`),
+		},
+		{
+			desc: "Bad prefix_template",
+			modify: func(c *Config) {
+				c.PrefixTemplate = "{host.name}/"
+			},
+			expectErr: errors.New("prefix_template is invalid: resource attributes not set for prefix template: host.name"),
+		},
+		{
+			desc:      "Valid config",
+			modify:    func(_ *Config) {},
+			expectErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := validConfig()
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.expectErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,384 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/filerehydrationreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+type rehydrationReceiver struct {
+	logger             *zap.Logger
+	id                 component.ID
+	cfg                *Config
+	supportedTelemetry pipeline.Signal
+	consumer           rehydration.Consumer
+	checkpointStore    rehydration.CheckpointStorer
+	progress           *rehydration.Progress
+
+	// root is the directory scanned for files, including the rendered prefix
+	root string
+
+	startingTime time.Time
+	endingTime   time.Time
+
+	doneChan   chan struct{}
+	started    bool
+	ctx        context.Context
+	cancelFunc context.CancelCauseFunc
+}
+
+// newMetricsReceiver creates a new metrics specific receiver.
+func newMetricsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Metrics) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithMetricsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	r.supportedTelemetry = pipeline.SignalMetrics
+	r.consumer = rehydration.NewMetricsConsumer(nextConsumer)
+
+	return r, nil
+}
+
+// newLogsReceiver creates a new logs specific receiver.
+func newLogsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Logs) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithLogsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	r.supportedTelemetry = pipeline.SignalLogs
+	r.consumer = rehydration.NewLogsConsumer(nextConsumer)
+
+	return r, nil
+}
+
+// newTracesReceiver creates a new traces specific receiver.
+func newTracesReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Traces) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithTracesCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	r.supportedTelemetry = pipeline.SignalTraces
+	r.consumer = rehydration.NewTracesConsumer(nextConsumer)
+
+	return r, nil
+}
+
+// newRehydrationReceiver creates a new rehydration receiver
+func newRehydrationReceiver(params receiver.Settings, cfg *Config) (*rehydrationReceiver, error) {
+	// We should not get an error for either of these time parsings as we check in config validate.
+	// Doing error checking anyways just in case.
+	startingTime, err := time.Parse(rehydration.TimeFormat, cfg.StartingTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid starting_time timestamp: %w", err)
+	}
+
+	endingTime, err := time.Parse(rehydration.TimeFormat, cfg.EndingTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ending_time timestamp: %w", err)
+	}
+
+	prefix, err := rehydration.RenderPrefix("", cfg.PrefixTemplate, cfg.ResourceAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix_template: %w", err)
+	}
+
+	progress, err := rehydration.NewProgress(params.TelemetrySettings.MeterProvider, params.ID, startingTime, endingTime)
+	if err != nil {
+		return nil, fmt.Errorf("new progress: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	return &rehydrationReceiver{
+		logger:          params.Logger,
+		id:              params.ID,
+		cfg:             cfg,
+		doneChan:        make(chan struct{}),
+		checkpointStore: rehydration.NewNopStorage(),
+		progress:        progress,
+		root:            filepath.Join(cfg.Directory, filepath.FromSlash(prefix)),
+		startingTime:    startingTime,
+		endingTime:      endingTime,
+		ctx:             ctx,
+		cancelFunc:      cancel,
+	}, nil
+}
+
+// Start starts the rehydration receiver
+func (r *rehydrationReceiver) Start(ctx context.Context, host component.Host) error {
+	info, err := os.Stat(r.cfg.Directory)
+	if err != nil {
+		return fmt.Errorf("directory is invalid: %w", err)
+	}
+
+	if !info.IsDir() {
+		return errors.New("directory is invalid: not a directory")
+	}
+
+	if r.cfg.StorageID != nil {
+		checkpointStore, err := rehydration.NewCheckpointStorage(ctx, host, *r.cfg.StorageID, r.id, r.supportedTelemetry)
+		if err != nil {
+			return fmt.Errorf("NewCheckpointStorage: %w", err)
+		}
+
+		r.checkpointStore = checkpointStore
+	}
+
+	r.started = true
+	go r.scrape()
+	return nil
+}
+
+// Shutdown shuts down the rehydration receiver
+func (r *rehydrationReceiver) Shutdown(ctx context.Context) error {
+	r.cancelFunc(errors.New("shutdown"))
+	var err error
+
+	// If we have called started then close and wait for goroutine to finish
+	if r.started {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-r.doneChan:
+		}
+	}
+
+	err = errors.Join(err, r.checkpointStore.Close(ctx), r.progress.Close())
+
+	return err
+}
+
+// emptyPollLimit is the number of consecutive empty polling cycles that can
+// occur before we stop polling.
+const emptyPollLimit = 3
+
+// scrape scans the directory on interval
+func (r *rehydrationReceiver) scrape() {
+	defer close(r.doneChan)
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	// load the previous checkpoint. If not exist should return zero value for time
+	checkpoint, err := r.checkpointStore.LoadCheckPoint(r.ctx, r.checkpointKey())
+	if err != nil {
+		r.logger.Warn("Error loading checkpoint, continuing without a previous checkpoint", zap.Error(err))
+		checkpoint = rehydration.NewCheckpoint()
+	}
+
+	// Call once before the loop to ensure we do a collection before the first ticker
+	numFilesRehydrated := r.rehydrateFiles(checkpoint)
+	emptyFileCounter := checkFileCount(numFilesRehydrated, 0)
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			// Stop polling once we stop finding files to match the behavior of the
+			// other rehydration receivers.
+			if emptyFileCounter == emptyPollLimit {
+				return
+			}
+
+			numFilesRehydrated := r.rehydrateFiles(checkpoint)
+			emptyFileCounter = checkFileCount(numFilesRehydrated, emptyFileCounter)
+		}
+	}
+}
+
+// rehydrateFiles walks the directory and if files are within the specified
+// time range then they will be read and rehydrated.
+// The passed in checkpoint will be updated and should be used in the next iteration.
+// The count of files processed will be returned
+func (r *rehydrationReceiver) rehydrateFiles(checkpoint *rehydration.CheckPoint) (numFilesRehydrated int) {
+	files, err := r.listFiles()
+	if err != nil {
+		r.logger.Error("Failed to list files", zap.Error(err))
+		return
+	}
+
+	// Go through each file and parse it's path to determine if we should consume it or not
+	entities := make([]rehydration.Entity, 0, len(files))
+	for _, file := range files {
+		fileTime, telemetryType, err := rehydration.ParseEntityPath(file.Name)
+		switch {
+		case errors.Is(err, rehydration.ErrInvalidEntityPath):
+			r.logger.Debug("Skipping File, non-matching file path", zap.String("file", file.Name))
+		case err != nil:
+			r.logger.Error("Error processing file path", zap.String("file", file.Name), zap.Error(err))
+		case checkpoint.ShouldParse(*fileTime, file.Name):
+			// if the file is not in the specified time range or not of the telemetry type supported by this receiver
+			// then skip consuming it.
+			if !rehydration.IsInTimeRange(*fileTime, r.startingTime, r.endingTime) || !rehydration.SupportsTelemetry(telemetryType, r.supportedTelemetry, r.cfg.Format) {
+				continue
+			}
+
+			entities = append(entities, rehydration.Entity{Name: file.Name, Time: *fileTime, Size: file.Size})
+		}
+	}
+
+	r.progress.AddTotal(len(entities))
+
+	// Files are read and consumed concurrently but committed in path order
+	// so the checkpoint never moves past a file that hasn't been rehydrated.
+	rehydration.ProcessEntities(r.ctx, entities, r.cfg.Concurrency, r.processFile, func(file rehydration.Entity, err error) {
+		if err != nil {
+			r.logger.Error("Error consuming file", zap.String("file", file.Name), zap.Error(err))
+			return
+		}
+
+		numFilesRehydrated++
+		r.progress.Done(file)
+
+		// Update and save the checkpoint with the most recently processed file
+		checkpoint.UpdateCheckpoint(file.Time, file.Name)
+		if err := r.checkpointStore.SaveCheckpoint(r.ctx, r.checkpointKey(), checkpoint); err != nil {
+			r.logger.Error("Error while saving checkpoint", zap.Error(err))
+		}
+
+		// Delete file if configured to do so
+		if r.cfg.DeleteOnRead {
+			if err := os.Remove(r.filePath(file.Name)); err != nil {
+				r.logger.Error("Error while attempting to delete file", zap.String("file", file.Name), zap.Error(err))
+			}
+		}
+	})
+
+	if len(entities) > 0 {
+		r.progress.Log(r.logger)
+	}
+
+	return
+}
+
+// listFiles returns all regular files under the root in lexical order.
+// File names are relative to the configured directory and use forward slashes
+// so they match the entity paths written by the exporters.
+func (r *rehydrationReceiver) listFiles() ([]rehydration.Entity, error) {
+	files := make([]rehydration.Entity, 0)
+	err := filepath.WalkDir(r.root, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil && path == r.root && errors.Is(err, fs.ErrNotExist):
+			// Nothing has been written under the prefix yet
+			return filepath.SkipAll
+		case err != nil:
+			return err
+		case !d.Type().IsRegular():
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("file info: %w", err)
+		}
+
+		name, err := filepath.Rel(r.cfg.Directory, path)
+		if err != nil {
+			return fmt.Errorf("relative path: %w", err)
+		}
+
+		files = append(files, rehydration.Entity{Name: filepath.ToSlash(name), Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walk directory: %w", err)
+	}
+
+	return files, nil
+}
+
+// processFile does the following:
+// 1. Reads the file
+// 2. Decompresses the file if applicable and detects its format
+// 3. Pass the file to the consumer
+func (r *rehydrationReceiver) processFile(ctx context.Context, file rehydration.Entity) error {
+	contents, err := os.ReadFile(r.filePath(file.Name))
+	if err != nil {
+		return fmt.Errorf("read file: %w", err)
+	}
+
+	format, compression, err := rehydration.DetectEncoding(file.Name, r.cfg.Format, r.cfg.Compression)
+	if err != nil {
+		return err
+	}
+
+	contents, err = rehydration.Decompress(contents, compression)
+	if err != nil {
+		return err
+	}
+
+	if err := r.consumer.Consume(ctx, contents, format); err != nil {
+		return fmt.Errorf("consume: %w", err)
+	}
+	return nil
+}
+
+// filePath returns the path on disk of the file with the given entity name
+func (r *rehydrationReceiver) filePath(name string) string {
+	return filepath.Join(r.cfg.Directory, filepath.FromSlash(name))
+}
+
+// checkpointStorageKey the key used for storing the checkpoint
+const checkpointStorageKey = "file_checkpoint"
+
+// checkpointKey returns the key used for storing the checkpoint
+func (r *rehydrationReceiver) checkpointKey() string {
+	return fmt.Sprintf("%s_%s_%s", checkpointStorageKey, r.id, r.supportedTelemetry.String())
+}
+
+// checkFileCount checks the number of files rehydrated and the current state of the
+// empty counter. If zero files were rehydrated increment the counter.
+// If there were files rehydrated reset the counter as we want to track consecutive zero sized polls.
+func checkFileCount(numFilesRehydrated, emptyFilesCounter int) int {
+	switch {
+	case emptyFilesCounter == emptyPollLimit: // If we are at the limit return the limit
+		return emptyPollLimit
+	case numFilesRehydrated == 0: // If no files were rehydrated then increment the empty files counter
+		return emptyFilesCounter + 1
+	default: // Default case is numFilesRehydrated > 0 so reset emptyFilesCounter to 0
+		return 0
+	}
+}
@@ -0,0 +1,481 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/filerehydrationreceiver"
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
+	"github.com/observiq/bindplane-otel-collector/internal/testutils"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+func Test_newMetricsReceiver(t *testing.T) {
+	id := component.MustNewID("test")
+	testLogger := zap.NewNop()
+	cfg := &Config{
+		Directory:    "/var/lib/archive",
+		StartingTime: "2023-10-02T17:00",
+		EndingTime:   "2023-10-02T17:01",
+	}
+	co := consumertest.NewNop()
+	r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, co)
+	require.NoError(t, err)
+
+	require.Equal(t, testLogger, r.logger)
+	require.Equal(t, id, r.id)
+	require.Equal(t, filepath.FromSlash("/var/lib/archive"), r.root)
+	require.Equal(t, pipeline.SignalMetrics, r.supportedTelemetry)
+	require.IsType(t, &rehydration.MetricsConsumer{}, r.consumer)
+}
+
+func Test_newLogsReceiver(t *testing.T) {
+	id := component.MustNewID("test")
+	testLogger := zap.NewNop()
+	cfg := &Config{
+		Directory:    "/var/lib/archive",
+		StartingTime: "2023-10-02T17:00",
+		EndingTime:   "2023-10-02T17:01",
+	}
+	co := consumertest.NewNop()
+	r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, co)
+	require.NoError(t, err)
+
+	require.Equal(t, testLogger, r.logger)
+	require.Equal(t, id, r.id)
+	require.Equal(t, pipeline.SignalLogs, r.supportedTelemetry)
+	require.IsType(t, &rehydration.LogsConsumer{}, r.consumer)
+}
+
+func Test_newTracesReceiver(t *testing.T) {
+	id := component.MustNewID("test")
+	testLogger := zap.NewNop()
+	cfg := &Config{
+		Directory:    "/var/lib/archive",
+		StartingTime: "2023-10-02T17:00",
+		EndingTime:   "2023-10-02T17:01",
+	}
+	co := consumertest.NewNop()
+	r, err := newTracesReceiver(newTestSettings(id, testLogger), cfg, co)
+	require.NoError(t, err)
+
+	require.Equal(t, testLogger, r.logger)
+	require.Equal(t, id, r.id)
+	require.Equal(t, pipeline.SignalTraces, r.supportedTelemetry)
+	require.IsType(t, &rehydration.TracesConsumer{}, r.consumer)
+}
+
+func Test_newReceiverFilter(t *testing.T) {
+	id := component.MustNewID("test")
+	cfg := &Config{
+		Directory:          "/var/lib/archive",
+		StartingTime:       "2023-10-02T17:00",
+		EndingTime:         "2023-10-02T17:01",
+		Condition:          `resource.attributes["host.name"] == "web-1"`,
+		PrefixTemplate:     "host.name={host.name}/",
+		ResourceAttributes: map[string]string{"host.name": "web-1"},
+	}
+
+	r, err := newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, filepath.FromSlash("/var/lib/archive/host.name=web-1"), r.root)
+
+	cfg.Condition = "not a condition"
+	_, err = newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.ErrorContains(t, err, "invalid condition")
+}
+
+func Test_StartInvalidDirectory(t *testing.T) {
+	id := component.MustNewID("test")
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.json")
+	require.NoError(t, os.WriteFile(file, []byte("{}"), 0600))
+
+	testCases := []struct {
+		desc      string
+		directory string
+		expectErr string
+	}{
+		{
+			desc:      "Directory does not exist",
+			directory: filepath.Join(dir, "missing"),
+			expectErr: "directory is invalid",
+		},
+		{
+			desc:      "Directory is a file",
+			directory: file,
+			expectErr: "directory is invalid: not a directory",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := &Config{
+				Directory:    tc.directory,
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				PollInterval: time.Second,
+				Concurrency:  1,
+			}
+
+			r, err := newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+
+			err = r.Start(context.Background(), componenttest.NewNopHost())
+			require.ErrorContains(t, err, tc.expectErr)
+			require.NoError(t, r.Shutdown(context.Background()))
+		})
+	}
+}
+
+func Test_fullRehydration(t *testing.T) {
+	id := component.MustNewID("test")
+	testLogger := zap.NewNop()
+	newConfig := func(dir string) *Config {
+		return &Config{
+			Directory:    dir,
+			StartingTime: "2023-10-02T17:00",
+			EndingTime:   "2023-10-02T18:00",
+			PollInterval: 10 * time.Millisecond,
+			DeleteOnRead: false,
+			Concurrency:  1,
+		}
+	}
+
+	t.Run("empty directory polling", func(t *testing.T) {
+		cfg := newConfig(t.TempDir())
+
+		testConsumer := &consumertest.MetricsSink{}
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+		// The receiver stops polling after emptyPollLimit empty polls
+		select {
+		case <-r.doneChan:
+		case <-time.After(time.Second):
+			t.Fatal("receiver did not stop polling")
+		}
+		require.NoError(t, r.Shutdown(context.Background()))
+		require.Equal(t, 0, testConsumer.DataPointCount())
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		metrics, jsonBytes := testutils.GenerateTestMetrics(t)
+
+		dir := t.TempDir()
+		writeTestFile(t, dir, "year=2023/month=10/day=02/hour=17/minute=05/metrics_12345.json", jsonBytes)
+		writeTestFile(t, dir, "year=2023/month=10/day=01/hour=17/minute=05/metrics_7890.json", []byte("bad"))
+
+		testConsumer := &consumertest.MetricsSink{}
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), newConfig(dir), testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.DataPointCount() == metrics.DataPointCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		traces, jsonBytes := testutils.GenerateTestTraces(t)
+
+		dir := t.TempDir()
+		writeTestFile(t, dir, "year=2023/month=10/day=02/hour=17/minute=05/traces_12345.json", jsonBytes)
+		writeTestFile(t, dir, "year=2023/month=10/day=02/hour=17/minute=05/metrics_12345.json", []byte("bad"))
+
+		testConsumer := &consumertest.TracesSink{}
+		r, err := newTracesReceiver(newTestSettings(id, testLogger), newConfig(dir), testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.SpanCount() == traces.SpanCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+
+		dir := t.TempDir()
+		writeTestFile(t, dir, "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json", jsonBytes)
+
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), newConfig(dir), testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.LogRecordCount() == logs.LogRecordCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("gzip compression", func(t *testing.T) {
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+
+		dir := t.TempDir()
+		writeTestFile(t, dir, "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json.gz", testutils.GZipCompressData(t, jsonBytes))
+
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), newConfig(dir), testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.LogRecordCount() == logs.LogRecordCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("Delete on Read", func(t *testing.T) {
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+
+		dir := t.TempDir()
+		name := "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json"
+		writeTestFile(t, dir, name, jsonBytes)
+
+		cfg := newConfig(dir)
+		cfg.DeleteOnRead = true
+
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+			return testConsumer.LogRecordCount() == logs.LogRecordCount() && errors.Is(err, os.ErrNotExist)
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("Prefix template", func(t *testing.T) {
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+
+		dir := t.TempDir()
+		writeTestFile(t, dir, "host.name=web-1/year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json", jsonBytes)
+		writeTestFile(t, dir, "host.name=web-2/year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json", []byte("bad"))
+
+		cfg := newConfig(dir)
+		cfg.PrefixTemplate = "host.name={host.name}"
+		cfg.ResourceAttributes = map[string]string{"host.name": "web-1"}
+
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+		select {
+		case <-r.doneChan:
+		case <-time.After(time.Second):
+			t.Fatal("receiver did not stop polling")
+		}
+		require.NoError(t, r.Shutdown(context.Background()))
+		require.Equal(t, logs.LogRecordCount(), testConsumer.LogRecordCount())
+	})
+
+	t.Run("Missing prefix directory", func(t *testing.T) {
+		cfg := newConfig(t.TempDir())
+		cfg.PrefixTemplate = "host.name={host.name}"
+		cfg.ResourceAttributes = map[string]string{"host.name": "web-1"}
+
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, consumertest.NewNop())
+		require.NoError(t, err)
+
+		files, err := r.listFiles()
+		require.NoError(t, err)
+		require.Empty(t, files)
+	})
+
+	t.Run("Checkpoint", func(t *testing.T) {
+		_, jsonBytes := testutils.GenerateTestLogs(t)
+
+		dir := t.TempDir()
+		processed := "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json"
+		pending := "year=2023/month=10/day=02/hour=17/minute=10/logs_12345.json"
+		writeTestFile(t, dir, processed, jsonBytes)
+		writeTestFile(t, dir, pending, jsonBytes)
+
+		checkpoint := rehydration.NewCheckpoint()
+		checkpoint.UpdateCheckpoint(time.Date(2023, 10, 2, 17, 5, 0, 0, time.UTC), processed)
+
+		store := &testCheckpointStore{checkpoint: checkpoint, saved: make(chan *rehydration.CheckPoint, 2)}
+
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), newConfig(dir), testConsumer)
+		require.NoError(t, err)
+		r.checkpointStore = store
+
+		require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+		select {
+		case cp := <-store.saved:
+			require.Contains(t, cp.ParsedEntities, pending)
+		case <-time.After(time.Second):
+			t.Fatal("checkpoint was not saved")
+		}
+
+		require.NoError(t, r.Shutdown(context.Background()))
+		require.Equal(t, 1, len(testConsumer.AllLogs()))
+	})
+}
+
+func Test_processFile(t *testing.T) {
+	_, jsonBytes := testutils.GenerateTestLogs(t)
+	dir := t.TempDir()
+
+	testCases := []struct {
+		desc        string
+		name        string
+		contents    []byte
+		format      rehydration.Format
+		expectedErr error
+	}{
+		{
+			desc:        "Missing file",
+			name:        "year=2023/month=10/day=02/hour=17/minute=05/logs_missing.json",
+			expectedErr: errors.New("read file"),
+		},
+		{
+			desc:        "unsupported extension",
+			name:        "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.txt",
+			contents:    []byte("hello"),
+			expectedErr: errors.New("unsupported file type: .txt"),
+		},
+		{
+			desc:     "Gzip compression",
+			name:     "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json.gz",
+			contents: testutils.GZipCompressData(t, jsonBytes),
+		},
+		{
+			desc:     "Json no compression",
+			name:     "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json",
+			contents: jsonBytes,
+		},
+		{
+			desc:        "Consume error",
+			name:        "year=2023/month=10/day=02/hour=17/minute=05/logs_bad.json",
+			contents:    []byte("not json"),
+			expectedErr: errors.New("consume"),
+		},
+		{
+			desc:     "Explicit format without extension",
+			name:     "year=2023/month=10/day=02/hour=17/minute=05/archive",
+			contents: []byte("line one\nline two\n"),
+			format:   rehydration.FormatNDJSON,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.contents != nil {
+				writeTestFile(t, dir, tc.name, tc.contents)
+			}
+
+			cfg := &Config{
+				Directory:    dir,
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T18:00",
+				Format:       tc.format,
+			}
+
+			r, err := newLogsReceiver(newTestSettings(component.MustNewID("test"), zap.NewNop()), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+
+			err = r.processFile(context.Background(), rehydration.Entity{Name: tc.name})
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+// newTestSettings returns receiver settings with the given id and logger
+func newTestSettings(id component.ID, logger *zap.Logger) receiver.Settings {
+	params := receivertest.NewNopSettings()
+	params.ID = id
+	params.Logger = logger
+	return params
+}
+
+// writeTestFile writes contents to the file with the given entity name under dir
+func writeTestFile(t *testing.T, dir, name string, contents []byte) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, contents, 0o600))
+}
+
+// testCheckpointStore is a CheckpointStorer that starts from a fixed checkpoint
+// and reports each saved checkpoint on a channel
+type testCheckpointStore struct {
+	checkpoint *rehydration.CheckPoint
+	saved      chan *rehydration.CheckPoint
+}
+
+func (s *testCheckpointStore) SaveCheckpoint(_ context.Context, _ string, checkpoint *rehydration.CheckPoint) error {
+	select {
+	case s.saved <- checkpoint:
+	default:
+	}
+	return nil
+}
+
+func (s *testCheckpointStore) LoadCheckPoint(_ context.Context, _ string) (*rehydration.CheckPoint, error) {
+	return s.checkpoint, nil
+}
+
+func (s *testCheckpointStore) Close(_ context.Context) error {
+	return nil
+}
+
+// runRehydrationValidateTest runs the rehydration tests with the passed in checkFunc
+func runRehydrationValidateTest(t *testing.T, r *rehydrationReceiver, checkFunc func() bool) {
+	// Start the receiver
+	err := r.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	// Wait for telemetry to be consumed
+	require.Eventually(t, checkFunc, time.Second, 10*time.Millisecond)
+
+	// Shutdown receivers
+	err = r.Shutdown(context.Background())
+	require.NoError(t, err)
+}
+
+func Test_checkFileCount(t *testing.T) {
+	require.Equal(t, 1, checkFileCount(0, 0))
+	require.Equal(t, 0, checkFileCount(2, 1))
+	require.Equal(t, emptyPollLimit, checkFileCount(0, emptyPollLimit))
+}
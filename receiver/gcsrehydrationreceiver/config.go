@@ -0,0 +1,154 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsrehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config is the configuration for the GCS rehydration receiver
+type Config struct {
+	// Bucket is the name of the GCS bucket to pull from. (no default)
+	Bucket string `mapstructure:"bucket"`
+
+	// Prefix is the prefix of the object paths.
+	Prefix string `mapstructure:"prefix"`
+
+	// CredentialsFile is the path to a service account key file.
+	// If empty, Application Default Credentials are used.
+	CredentialsFile string `mapstructure:"credentials_file"`
+
+	// Endpoint overrides the GCS API endpoint, such as a private or regional endpoint.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Emulator sends requests to Endpoint without authentication, for emulators such as fake-gcs-server.
+	// Default value of false
+	Emulator bool `mapstructure:"emulator"`
+
+	// StartingTime the UTC timestamp to start rehydration from.
+	StartingTime string `mapstructure:"starting_time"`
+
+	// EndingTime the UTC timestamp to rehydrate up until.
+	EndingTime string `mapstructure:"ending_time"`
+
+	// DeleteOnRead indicates if a file should be deleted once it has been processed
+	// Default value of false
+	DeleteOnRead bool `mapstructure:"delete_on_read"`
+
+	// PollInterval is the interval at which the GCS API is scanned for objects.
+	// Default value of 1m
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// PollTimeout is the timeout for the GCS API to scan for objects.
+	PollTimeout time.Duration `mapstructure:"poll_timeout"`
+
+	// ID of the storage extension to use for storing progress
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// Concurrency is the maximum number of objects downloaded and consumed at the same time.
+	// Default value of 1
+	Concurrency int `mapstructure:"concurrency"`
+
+	// Format is the encoding of the telemetry in each object.
+	// Default value of auto detects the format from the object extension.
+	Format rehydration.Format `mapstructure:"format"`
+
+	// Compression is the compression applied to each object.
+	// Default value of auto detects the compression from the object extension.
+	Compression rehydration.Compression `mapstructure:"compression"`
+
+	// Condition is an OTTL condition evaluated against each rehydrated record.
+	// Only records matching the condition are emitted. Default value of "" emits all records.
+	Condition string `mapstructure:"condition"`
+
+	// PrefixTemplate is appended to the prefix when listing objects.
+	// Placeholders in the form {attribute} are replaced with the value from ResourceAttributes
+	// so objects outside of that partition are never listed.
+	PrefixTemplate string `mapstructure:"prefix_template"`
+
+	// ResourceAttributes are the values used to render PrefixTemplate
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+}
+
+// Validate validates the config
+func (c *Config) Validate() error {
+	if c.Bucket == "" {
+		return errors.New("bucket is required")
+	}
+
+	startingTs, err := validateTimestamp(c.StartingTime)
+	if err != nil {
+		return fmt.Errorf("starting_time is invalid: %w", err)
+	}
+
+	endingTs, err := validateTimestamp(c.EndingTime)
+	if err != nil {
+		return fmt.Errorf("ending_time is invalid: %w", err)
+	}
+
+	// Check case where ending_time is to close or before starting time
+	if endingTs.Sub(*startingTs) < time.Minute {
+		return errors.New("ending_time must be at least one minute after starting_time")
+	}
+
+	if c.Emulator && c.Endpoint == "" {
+		return errors.New("endpoint is required when emulator is set")
+	}
+
+	if c.PollInterval < time.Second {
+		return errors.New("poll_interval must be at least one second")
+	}
+
+	if c.PollTimeout < time.Second {
+		return errors.New("poll_timeout must be at least one second")
+	}
+
+	if c.Concurrency < 1 {
+		return errors.New("concurrency must be at least one")
+	}
+
+	if err := c.Format.Validate(); err != nil {
+		return fmt.Errorf("format is invalid: %w", err)
+	}
+
+	if err := c.Compression.Validate(); err != nil {
+		return fmt.Errorf("compression is invalid: %w", err)
+	}
+
+	if _, err := rehydration.RenderPrefix(c.Prefix, c.PrefixTemplate, c.ResourceAttributes); err != nil {
+		return fmt.Errorf("prefix_template is invalid: %w", err)
+	}
+
+	return nil
+}
+
+// validateTimestamp validates the passed in timestamp string
+func validateTimestamp(timestamp string) (*time.Time, error) {
+	if timestamp == "" {
+		return nil, errors.New("missing value")
+	}
+
+	ts, err := time.Parse(rehydration.TimeFormat, timestamp)
+	if err != nil {
+		return nil, errors.New("invalid timestamp format must be in the form YYYY-MM-DDTHH:MM")
+	}
+
+	return &ts, nil
+}
@@ -0,0 +1,242 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsrehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver"
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		cfg       *Config
+		expectErr error
+	}{
+		{
+			desc: "Missing bucket",
+			cfg: &Config{
+				Bucket:       "",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("bucket is required"),
+		},
+		{
+			desc: "Missing starting_time",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("starting_time is invalid: missing value"),
+		},
+		{
+			desc: "Missing ending_time",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("ending_time is invalid: missing value"),
+		},
+		{
+			desc: "Invalid starting_time",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "invalid_time",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("starting_time is invalid: invalid timestamp"),
+		},
+		{
+			desc: "Missing ending_time",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "invalid_time",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("ending_time is invalid: invalid timestamp"),
+		},
+		{
+			desc: "ending_time not after starting_time",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T16:00",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("ending_time must be at least one minute after starting_time"),
+		},
+		{
+			desc: "Emulator without endpoint",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				Emulator:     true,
+				PollInterval: time.Second * 10,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("endpoint is required when emulator is set"),
+		},
+		{
+			desc: "Bad poll_interval",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Millisecond,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("poll_interval must be at least one second"),
+		},
+		{
+			desc: "Bad poll_timeout",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second * 2,
+				PollTimeout:  time.Millisecond,
+				Concurrency:  1,
+			},
+			expectErr: errors.New("poll_timeout must be at least one second"),
+		},
+		{
+			desc: "Bad concurrency",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  0,
+			},
+			expectErr: errors.New("concurrency must be at least one"),
+		},
+		{
+			desc: "Bad format",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+				Format:       "csv",
+			},
+			expectErr: errors.New(`format is invalid: invalid format "csv"`),
+		},
+		{
+			desc: "Bad prefix_template",
+			cfg: &Config{
+				Bucket:         "bucket",
+				Prefix:         "root",
+				StartingTime:   "2023-10-02T17:00",
+				EndingTime:     "2023-10-02T17:01",
+				DeleteOnRead:   false,
+				PollInterval:   time.Second,
+				PollTimeout:    time.Second * 10,
+				Concurrency:    1,
+				PrefixTemplate: "{host.name}/",
+			},
+			expectErr: errors.New("prefix_template is invalid: resource attributes not set for prefix template: host.name"),
+		},
+		{
+			desc: "Bad compression",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+				Compression:  "bz2",
+			},
+			expectErr: errors.New(`compression is invalid: invalid compression "bz2"`),
+		},
+		{
+			desc: "Valid config",
+			cfg: &Config{
+				Bucket:       "bucket",
+				Prefix:       "root",
+				StartingTime: "2023-10-02T17:00",
+				EndingTime:   "2023-10-02T17:01",
+				DeleteOnRead: false,
+				PollInterval: time.Second,
+				PollTimeout:  time.Second * 10,
+				Concurrency:  1,
+			},
+			expectErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.expectErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsrehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_createDefaultConfig(t *testing.T) {
+	expectedCfg := &Config{
+		DeleteOnRead: false,
+		PollInterval: time.Minute,
+		PollTimeout:  time.Second * 30,
+		Concurrency:  1,
+		Format:       rehydration.FormatAuto,
+		Compression:  rehydration.CompressionAuto,
+	}
+
+	componentCfg := createDefaultConfig()
+	actualCfg, ok := componentCfg.(*Config)
+	require.True(t, ok)
+	require.Equal(t, expectedCfg, actualCfg)
+}
@@ -0,0 +1,154 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gcs contains client interfaces and implementations for accessing Google Cloud Storage
+package gcs //import "github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver/internal/gcs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// ObjectInfo contains the necessary info to process a GCS object
+type ObjectInfo struct {
+	Name string
+	Size int64
+}
+
+// StorageClient provides a client for GCS object operations
+//
+//go:generate mockery --name StorageClient --output ./mocks --with-expecter --filename mock_storage_client.go --structname MockStorageClient
+type StorageClient interface {
+	// ListObjects returns a list of ObjectInfo objects present in the bucket with the given prefix
+	ListObjects(ctx context.Context, bucket string, prefix, pageToken *string) ([]*ObjectInfo, *string, error)
+
+	// DownloadObject downloads the contents of the object into the supplied buffer.
+	// It will return the count of bytes used in the buffer.
+	DownloadObject(ctx context.Context, bucket, name string, buf []byte) (int64, error)
+
+	// DeleteObject deletes the object in the specified bucket
+	DeleteObject(ctx context.Context, bucket, name string) error
+}
+
+// DefaultClient is an implementation of the StorageClient for GCS
+type DefaultClient struct {
+	service *storage.Service
+}
+
+// ClientConfig configures how the client connects to GCS
+type ClientConfig struct {
+	// CredentialsFile is the path to a service account key file. If empty, Application Default Credentials are used.
+	CredentialsFile string
+
+	// Endpoint overrides the GCS API endpoint
+	Endpoint string
+
+	// Emulator sends requests without authentication, for emulators such as fake-gcs-server
+	Emulator bool
+
+	// DeleteObjects requests access to delete objects, otherwise the client only has read access
+	DeleteObjects bool
+}
+
+// NewStorageClient creates a new GCS client
+func NewStorageClient(ctx context.Context, cfg ClientConfig) (StorageClient, error) {
+	service, err := storage.NewService(ctx, clientOptions(cfg)...)
+	if err != nil {
+		return nil, fmt.Errorf("new storage service: %w", err)
+	}
+
+	return &DefaultClient{
+		service: service,
+	}, nil
+}
+
+// clientOptions returns the options to create the storage service with
+func clientOptions(cfg ClientConfig) []option.ClientOption {
+	scope := storage.DevstorageReadOnlyScope
+	if cfg.DeleteObjects {
+		scope = storage.DevstorageReadWriteScope
+	}
+
+	opts := []option.ClientOption{option.WithScopes(scope)}
+	if cfg.Endpoint != "" {
+		opts = append(opts, option.WithEndpoint(cfg.Endpoint))
+	}
+
+	switch {
+	case cfg.Emulator:
+		opts = append(opts, option.WithoutAuthentication())
+	case cfg.CredentialsFile != "":
+		opts = append(opts, option.WithCredentialsFile(cfg.CredentialsFile))
+	}
+	return opts
+}
+
+// ListObjects returns a list of ObjectInfo objects present in the bucket with the given prefix.
+// All pages after pageToken are listed.
+func (c *DefaultClient) ListObjects(ctx context.Context, bucket string, prefix, pageToken *string) ([]*ObjectInfo, *string, error) {
+	call := c.service.Objects.List(bucket).Context(ctx).Fields("nextPageToken", "items(name,size)")
+	if prefix != nil {
+		call = call.Prefix(*prefix)
+	}
+	if pageToken != nil {
+		call = call.PageToken(*pageToken)
+	}
+
+	objects := make([]*ObjectInfo, 0)
+	err := call.Pages(ctx, func(resp *storage.Objects) error {
+		for _, object := range resp.Items {
+			info := &ObjectInfo{
+				Name: object.Name,
+				Size: int64(object.Size), // #nosec G115 object sizes are well under the max int64
+			}
+			objects = append(objects, info)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list objects: %w", err)
+	}
+
+	return objects, nil, nil
+}
+
+// DownloadObject downloads the contents of the object into the supplied buffer.
+// It will return the count of bytes used in the buffer.
+func (c *DefaultClient) DownloadObject(ctx context.Context, bucket, name string, buf []byte) (int64, error) {
+	resp, err := c.service.Objects.Get(bucket, name).Context(ctx).Download()
+	if err != nil {
+		return 0, fmt.Errorf("download: %w", err)
+	}
+	defer resp.Body.Close()
+
+	n, err := io.ReadFull(resp.Body, buf)
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		// The object is smaller than the buffer
+	case err != nil && !errors.Is(err, io.EOF):
+		return int64(n), fmt.Errorf("read object: %w", err)
+	}
+
+	return int64(n), nil
+}
+
+// DeleteObject deletes the object in the specified bucket
+func (c *DefaultClient) DeleteObject(ctx context.Context, bucket, name string) error {
+	return c.service.Objects.Delete(bucket, name).Context(ctx).Do()
+}
@@ -0,0 +1,236 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// fakeServer is a minimal in memory implementation of the GCS JSON API
+type fakeServer struct {
+	bucket   string
+	pageSize int
+
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// newFakeServer starts a fake GCS server with the given objects and returns a client pointed at it
+func newFakeServer(t *testing.T, bucket string, pageSize int, objects map[string][]byte) (*fakeServer, StorageClient) {
+	t.Helper()
+
+	fs := &fakeServer{
+		bucket:   bucket,
+		pageSize: pageSize,
+		objects:  objects,
+	}
+
+	server := httptest.NewServer(fs)
+	t.Cleanup(server.Close)
+
+	client, err := NewStorageClient(context.Background(), ClientConfig{
+		Endpoint:      server.URL + "/storage/v1/",
+		Emulator:      true,
+		DeleteObjects: true,
+	})
+	require.NoError(t, err)
+
+	return fs, client
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	bucketPath := "/storage/v1/b/" + f.bucket + "/o"
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == bucketPath:
+		f.list(w, r)
+	case strings.HasPrefix(r.URL.Path, bucketPath+"/"):
+		name := strings.TrimPrefix(r.URL.Path, bucketPath+"/")
+		contents, ok := f.objects[name]
+		if !ok {
+			http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Query().Get("alt") == "media":
+			_, _ = w.Write(contents)
+		case r.Method == http.MethodDelete:
+			delete(f.objects, name)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(w, "unsupported", http.StatusBadRequest)
+		}
+	default:
+		http.Error(w, `{"error":{"code":404,"message":"not found"}}`, http.StatusNotFound)
+	}
+}
+
+// list returns a page of objects matching the prefix. The page token is the index of the first object.
+func (f *fakeServer) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	names := make([]string, 0, len(f.objects))
+	for name := range f.objects {
+		if strings.HasPrefix(name, prefix) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	start := 0
+	if token := r.URL.Query().Get("pageToken"); token != "" {
+		start, _ = strconv.Atoi(token)
+	}
+	end := min(start+f.pageSize, len(names))
+
+	type item struct {
+		Name string `json:"name"`
+		Size string `json:"size"`
+	}
+	resp := struct {
+		NextPageToken string `json:"nextPageToken,omitempty"`
+		Items         []item `json:"items"`
+	}{}
+	for _, name := range names[start:end] {
+		resp.Items = append(resp.Items, item{Name: name, Size: strconv.Itoa(len(f.objects[name]))})
+	}
+	if end < len(names) {
+		resp.NextPageToken = strconv.Itoa(end)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func TestListObjects(t *testing.T) {
+	_, client := newFakeServer(t, "bucket", 2, map[string][]byte{
+		"root/year=2023/month=10/day=02/hour=17/minute=05/logs_1.json": []byte("one"),
+		"root/year=2023/month=10/day=02/hour=17/minute=06/logs_2.json": []byte("two"),
+		"root/year=2023/month=10/day=02/hour=17/minute=07/logs_3.json": []byte("three"),
+		"other/year=2023/month=10/day=02/hour=17/minute=05/logs.json":  []byte("other"),
+	})
+
+	prefix := "root/"
+	objects, nextPageToken, err := client.ListObjects(context.Background(), "bucket", &prefix, nil)
+	require.NoError(t, err)
+	require.Nil(t, nextPageToken)
+	require.Equal(t, []*ObjectInfo{
+		{Name: "root/year=2023/month=10/day=02/hour=17/minute=05/logs_1.json", Size: 3},
+		{Name: "root/year=2023/month=10/day=02/hour=17/minute=06/logs_2.json", Size: 3},
+		{Name: "root/year=2023/month=10/day=02/hour=17/minute=07/logs_3.json", Size: 5},
+	}, objects)
+
+	pageToken := "2"
+	objects, _, err = client.ListObjects(context.Background(), "bucket", &prefix, &pageToken)
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+
+	_, _, err = client.ListObjects(context.Background(), "missing", nil, nil)
+	require.ErrorContains(t, err, "list objects")
+}
+
+func TestDownloadObject(t *testing.T) {
+	name := "year=2023/month=10/day=02/hour=17/minute=05/logs_1.json"
+	_, client := newFakeServer(t, "bucket", 10, map[string][]byte{
+		name: []byte(`{"resourceLogs":[]}`),
+	})
+
+	buf := make([]byte, 19)
+	n, err := client.DownloadObject(context.Background(), "bucket", name, buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(19), n)
+	require.Equal(t, `{"resourceLogs":[]}`, string(buf[:n]))
+
+	// A buffer larger than the object is only partially filled
+	buf = make([]byte, 32)
+	n, err = client.DownloadObject(context.Background(), "bucket", name, buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(19), n)
+
+	_, err = client.DownloadObject(context.Background(), "bucket", "missing.json", buf)
+	require.ErrorContains(t, err, "download")
+}
+
+func TestDeleteObject(t *testing.T) {
+	name := "year=2023/month=10/day=02/hour=17/minute=05/logs_1.json"
+	fs, client := newFakeServer(t, "bucket", 10, map[string][]byte{
+		name: []byte("one"),
+	})
+
+	require.NoError(t, client.DeleteObject(context.Background(), "bucket", name))
+	require.Empty(t, fs.objects)
+
+	require.Error(t, client.DeleteObject(context.Background(), "bucket", name))
+}
+
+func TestClientOptions(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		cfg      ClientConfig
+		expected []option.ClientOption
+	}{
+		{
+			desc:     "Application Default Credentials",
+			cfg:      ClientConfig{},
+			expected: []option.ClientOption{option.WithScopes(storage.DevstorageReadOnlyScope)},
+		},
+		{
+			desc: "Delete objects",
+			cfg:  ClientConfig{CredentialsFile: "creds.json", DeleteObjects: true},
+			expected: []option.ClientOption{
+				option.WithScopes(storage.DevstorageReadWriteScope),
+				option.WithCredentialsFile("creds.json"),
+			},
+		},
+		{
+			desc: "Custom endpoint keeps credentials",
+			cfg:  ClientConfig{CredentialsFile: "creds.json", Endpoint: "https://storage.us-east1.rep.googleapis.com/storage/v1/"},
+			expected: []option.ClientOption{
+				option.WithScopes(storage.DevstorageReadOnlyScope),
+				option.WithEndpoint("https://storage.us-east1.rep.googleapis.com/storage/v1/"),
+				option.WithCredentialsFile("creds.json"),
+			},
+		},
+		{
+			desc: "Emulator",
+			cfg:  ClientConfig{CredentialsFile: "creds.json", Endpoint: "http://localhost:4443/storage/v1/", Emulator: true},
+			expected: []option.ClientOption{
+				option.WithScopes(storage.DevstorageReadOnlyScope),
+				option.WithEndpoint("http://localhost:4443/storage/v1/"),
+				option.WithoutAuthentication(),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Equal(t, tc.expected, clientOptions(tc.cfg))
+		})
+	}
+}
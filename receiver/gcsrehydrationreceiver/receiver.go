@@ -0,0 +1,365 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsrehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
+	"github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver/internal/gcs"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap"
+)
+
+// newStorageClient is the function use to create new GCS Clients.
+// Meant to be overwritten for tests
+var newStorageClient = gcs.NewStorageClient
+
+type rehydrationReceiver struct {
+	logger             *zap.Logger
+	id                 component.ID
+	cfg                *Config
+	storageClient      gcs.StorageClient
+	supportedTelemetry pipeline.Signal
+	consumer           rehydration.Consumer
+	checkpointStore    rehydration.CheckpointStorer
+	progress           *rehydration.Progress
+
+	// prefix is the rendered prefix used when listing objects
+	prefix string
+
+	startingTime time.Time
+	endingTime   time.Time
+
+	doneChan   chan struct{}
+	started    bool
+	ctx        context.Context
+	cancelFunc context.CancelCauseFunc
+}
+
+// newMetricsReceiver creates a new metrics specific receiver.
+func newMetricsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Metrics) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithMetricsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	r.supportedTelemetry = pipeline.SignalMetrics
+	r.consumer = rehydration.NewMetricsConsumer(nextConsumer)
+
+	return r, nil
+}
+
+// newLogsReceiver creates a new logs specific receiver.
+func newLogsReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Logs) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithLogsCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	r.supportedTelemetry = pipeline.SignalLogs
+	r.consumer = rehydration.NewLogsConsumer(nextConsumer)
+
+	return r, nil
+}
+
+// newTracesReceiver creates a new traces specific receiver.
+func newTracesReceiver(params receiver.Settings, cfg *Config, nextConsumer consumer.Traces) (*rehydrationReceiver, error) {
+	r, err := newRehydrationReceiver(params, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	nextConsumer, err = rehydration.WithTracesCondition(cfg.Condition, params.TelemetrySettings, r.progress, nextConsumer)
+	if err != nil {
+		return nil, err
+	}
+
+	r.supportedTelemetry = pipeline.SignalTraces
+	r.consumer = rehydration.NewTracesConsumer(nextConsumer)
+
+	return r, nil
+}
+
+// newRehydrationReceiver creates a new rehydration receiver
+func newRehydrationReceiver(params receiver.Settings, cfg *Config) (*rehydrationReceiver, error) {
+	storageClient, err := newStorageClient(context.Background(), gcs.ClientConfig{
+		CredentialsFile: cfg.CredentialsFile,
+		Endpoint:        cfg.Endpoint,
+		Emulator:        cfg.Emulator,
+		DeleteObjects:   cfg.DeleteOnRead,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("new GCS client: %w", err)
+	}
+
+	// We should not get an error for either of these time parsings as we check in config validate.
+	// Doing error checking anyways just in case.
+	startingTime, err := time.Parse(rehydration.TimeFormat, cfg.StartingTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid starting_time timestamp: %w", err)
+	}
+
+	endingTime, err := time.Parse(rehydration.TimeFormat, cfg.EndingTime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ending_time timestamp: %w", err)
+	}
+
+	prefix, err := rehydration.RenderPrefix(cfg.Prefix, cfg.PrefixTemplate, cfg.ResourceAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix_template: %w", err)
+	}
+
+	progress, err := rehydration.NewProgress(params.TelemetrySettings.MeterProvider, params.ID, startingTime, endingTime)
+	if err != nil {
+		return nil, fmt.Errorf("new progress: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+
+	return &rehydrationReceiver{
+		logger:          params.Logger,
+		id:              params.ID,
+		cfg:             cfg,
+		storageClient:   storageClient,
+		doneChan:        make(chan struct{}),
+		checkpointStore: rehydration.NewNopStorage(),
+		progress:        progress,
+		prefix:          prefix,
+		startingTime:    startingTime,
+		endingTime:      endingTime,
+		ctx:             ctx,
+		cancelFunc:      cancel,
+	}, nil
+}
+
+// Start starts the rehydration receiver
+func (r *rehydrationReceiver) Start(ctx context.Context, host component.Host) error {
+
+	if r.cfg.StorageID != nil {
+		checkpointStore, err := rehydration.NewCheckpointStorage(ctx, host, *r.cfg.StorageID, r.id, r.supportedTelemetry)
+		if err != nil {
+			return fmt.Errorf("NewCheckpointStorage: %w", err)
+		}
+
+		r.checkpointStore = checkpointStore
+	}
+
+	r.started = true
+	go r.scrape()
+	return nil
+}
+
+// Shutdown shuts down the rehydration receiver
+func (r *rehydrationReceiver) Shutdown(ctx context.Context) error {
+	r.cancelFunc(errors.New("shutdown"))
+	var err error
+
+	// If we have called started then close and wait for goroutine to finish
+	if r.started {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		case <-r.doneChan:
+		}
+	}
+
+	err = errors.Join(err, r.checkpointStore.Close(ctx), r.progress.Close())
+
+	return err
+}
+
+// emptyPollLimit is the number of consecutive empty polling cycles that can
+// occur before we stop polling.
+const emptyPollLimit = 3
+
+// scrape scrapes the GCS api on interval
+func (r *rehydrationReceiver) scrape() {
+	defer close(r.doneChan)
+	ticker := time.NewTicker(r.cfg.PollInterval)
+	defer ticker.Stop()
+
+	var pageToken *string
+
+	// load the previous checkpoint. If not exist should return zero value for time
+	checkpoint, err := r.checkpointStore.LoadCheckPoint(r.ctx, r.checkpointKey())
+	if err != nil {
+		r.logger.Warn("Error loading checkpoint, continuing without a previous checkpoint", zap.Error(err))
+		checkpoint = rehydration.NewCheckpoint()
+	}
+
+	// Call once before the loop to ensure we do a collection before the first ticker
+	numObjectsRehydrated := r.rehydrateObjects(checkpoint, pageToken)
+	emptyObjectCounter := checkObjectCount(numObjectsRehydrated, 0)
+
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			// Polling for objects has egress charges so we want to stop polling
+			// after we stop finding objects.
+			if emptyObjectCounter == emptyPollLimit {
+				return
+			}
+
+			numObjectsRehydrated := r.rehydrateObjects(checkpoint, pageToken)
+			emptyObjectCounter = checkObjectCount(numObjectsRehydrated, emptyObjectCounter)
+		}
+	}
+}
+
+// rehydrateObjects pulls object paths from the UI and if they are within the specified
+// time range then the objects will be downloaded and rehydrated.
+// The passed in checkpoint and pageToken will be updated and should be used in the next iteration.
+// The count of objects processed will be returned
+func (r *rehydrationReceiver) rehydrateObjects(checkpoint *rehydration.CheckPoint, pageToken *string) (numObjectsRehydrated int) {
+	var prefix *string
+	if r.prefix != "" {
+		prefix = &r.prefix
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(r.ctx, r.cfg.PollTimeout)
+	defer cancel()
+
+	// get objects from GCS
+	objects, nextPageToken, err := r.storageClient.ListObjects(ctxTimeout, r.cfg.Bucket, prefix, pageToken)
+	if err != nil {
+		r.logger.Error("Failed to list objects", zap.Error(err))
+		return
+	}
+
+	pageToken = nextPageToken
+
+	// Go through each object and parse it's path to determine if we should consume it or not
+	entities := make([]rehydration.Entity, 0, len(objects))
+	for _, object := range objects {
+		objectTime, telemetryType, err := rehydration.ParseEntityPath(object.Name)
+		switch {
+		case errors.Is(err, rehydration.ErrInvalidEntityPath):
+			r.logger.Debug("Skipping Object, non-matching object path", zap.String("object", object.Name))
+		case err != nil:
+			r.logger.Error("Error processing object path", zap.String("object", object.Name), zap.Error(err))
+		case checkpoint.ShouldParse(*objectTime, object.Name):
+			// if the object is not in the specified time range or not of the telemetry type supported by this receiver
+			// then skip consuming it.
+			if !rehydration.IsInTimeRange(*objectTime, r.startingTime, r.endingTime) || !rehydration.SupportsTelemetry(telemetryType, r.supportedTelemetry, r.cfg.Format) {
+				continue
+			}
+
+			entities = append(entities, rehydration.Entity{Name: object.Name, Time: *objectTime, Size: object.Size})
+		}
+	}
+
+	r.progress.AddTotal(len(entities))
+
+	// Objects are downloaded and consumed concurrently but committed in listing order
+	// so the checkpoint never moves past a object that hasn't been rehydrated.
+	rehydration.ProcessEntities(r.ctx, entities, r.cfg.Concurrency, r.processObject, func(object rehydration.Entity, err error) {
+		if err != nil {
+			r.logger.Error("Error consuming object", zap.String("object", object.Name), zap.Error(err))
+			return
+		}
+
+		numObjectsRehydrated++
+		r.progress.Done(object)
+
+		// Update and save the checkpoint with the most recently processed object
+		checkpoint.UpdateCheckpoint(object.Time, object.Name)
+		if err := r.checkpointStore.SaveCheckpoint(r.ctx, r.checkpointKey(), checkpoint); err != nil {
+			r.logger.Error("Error while saving checkpoint", zap.Error(err))
+		}
+
+		// Delete object if configured to do so
+		if r.cfg.DeleteOnRead {
+			if err := r.storageClient.DeleteObject(r.ctx, r.cfg.Bucket, object.Name); err != nil {
+				r.logger.Error("Error while attempting to delete object", zap.String("object", object.Name), zap.Error(err))
+			}
+		}
+	})
+
+	if len(entities) > 0 {
+		r.progress.Log(r.logger)
+	}
+
+	return
+}
+
+// processObject does the following:
+// 1. Downloads the object
+// 2. Decompresses the object if applicable and detects its format
+// 3. Pass the object to the consumer
+func (r *rehydrationReceiver) processObject(ctx context.Context, object rehydration.Entity) error {
+	// Allocate a buffer the size of the object. If the buffer isn't big enough download errors.
+	objectBuffer := make([]byte, object.Size)
+
+	size, err := r.storageClient.DownloadObject(ctx, r.cfg.Bucket, object.Name, objectBuffer)
+	if err != nil {
+		return fmt.Errorf("download object: %w", err)
+	}
+
+	format, compression, err := rehydration.DetectEncoding(object.Name, r.cfg.Format, r.cfg.Compression)
+	if err != nil {
+		return err
+	}
+
+	objectBuffer, err = rehydration.Decompress(objectBuffer[:size], compression)
+	if err != nil {
+		return err
+	}
+
+	if err := r.consumer.Consume(ctx, objectBuffer, format); err != nil {
+		return fmt.Errorf("consume: %w", err)
+	}
+	return nil
+}
+
+// checkpointStorageKey the key used for storing the checkpoint
+const checkpointStorageKey = "gcs_object_checkpoint"
+
+// checkpointKey returns the key used for storing the checkpoint
+func (r *rehydrationReceiver) checkpointKey() string {
+	return fmt.Sprintf("%s_%s_%s", checkpointStorageKey, r.id, r.supportedTelemetry.String())
+}
+
+// checkObjectCount checks the number of objects rehydrated and the current state of the
+// empty counter. If zero objects were rehydrated increment the counter.
+// If there were objects rehydrated reset the counter as we want to track consecutive zero sized polls.
+func checkObjectCount(numObjectsRehydrated, emptyObjectsCounter int) int {
+	switch {
+	case emptyObjectsCounter == emptyPollLimit: // If we are at the limit return the limit
+		return emptyPollLimit
+	case numObjectsRehydrated == 0: // If no objects were rehydrated then increment the empty objects counter
+		return emptyObjectsCounter + 1
+	default: // Default case is numObjectsRehydrated > 0 so reset emptyObjectsCounter to 0
+		return 0
+	}
+}
@@ -0,0 +1,609 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcsrehydrationreceiver //import "github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/internal/rehydration"
+	"github.com/observiq/bindplane-otel-collector/internal/testutils"
+	"github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver/internal/gcs"
+	gcsmocks "github.com/observiq/bindplane-otel-collector/receiver/gcsrehydrationreceiver/internal/gcs/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+)
+
+func Test_newMetricsReceiver(t *testing.T) {
+	mockClient := setNewStorageClient(t)
+	testType, err := component.NewType("test")
+	require.NoError(t, err)
+
+	id := component.NewID(testType)
+	testLogger := zap.NewNop()
+	cfg := &Config{
+		StartingTime: "2023-10-02T17:00",
+		EndingTime:   "2023-10-02T17:01",
+	}
+	co := consumertest.NewNop()
+	r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, co)
+	require.NoError(t, err)
+
+	require.Equal(t, testLogger, r.logger)
+	require.Equal(t, id, r.id)
+	require.Equal(t, mockClient, r.storageClient)
+	require.Equal(t, pipeline.SignalMetrics, r.supportedTelemetry)
+	require.IsType(t, &rehydration.MetricsConsumer{}, r.consumer)
+}
+
+func Test_newLogsReceiver(t *testing.T) {
+	mockClient := setNewStorageClient(t)
+	testType, err := component.NewType("test")
+	require.NoError(t, err)
+
+	id := component.NewID(testType)
+	testLogger := zap.NewNop()
+	cfg := &Config{
+		StartingTime: "2023-10-02T17:00",
+		EndingTime:   "2023-10-02T17:01",
+	}
+	co := consumertest.NewNop()
+	r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, co)
+	require.NoError(t, err)
+
+	require.Equal(t, testLogger, r.logger)
+	require.Equal(t, id, r.id)
+	require.Equal(t, mockClient, r.storageClient)
+	require.Equal(t, pipeline.SignalLogs, r.supportedTelemetry)
+	require.IsType(t, &rehydration.LogsConsumer{}, r.consumer)
+}
+
+func Test_newTracesReceiver(t *testing.T) {
+	mockClient := setNewStorageClient(t)
+	testType, err := component.NewType("test")
+	require.NoError(t, err)
+
+	id := component.NewID(testType)
+	testLogger := zap.NewNop()
+	cfg := &Config{
+		StartingTime: "2023-10-02T17:00",
+		EndingTime:   "2023-10-02T17:01",
+	}
+	co := consumertest.NewNop()
+	r, err := newTracesReceiver(newTestSettings(id, testLogger), cfg, co)
+	require.NoError(t, err)
+
+	require.Equal(t, testLogger, r.logger)
+	require.Equal(t, id, r.id)
+	require.Equal(t, mockClient, r.storageClient)
+	require.Equal(t, pipeline.SignalTraces, r.supportedTelemetry)
+	require.IsType(t, &rehydration.TracesConsumer{}, r.consumer)
+}
+
+func Test_newReceiverFilter(t *testing.T) {
+	setNewStorageClient(t)
+	id := component.MustNewID("test")
+	cfg := &Config{
+		StartingTime:       "2023-10-02T17:00",
+		EndingTime:         "2023-10-02T17:01",
+		Prefix:             "root",
+		Condition:          `resource.attributes["host.name"] == "web-1"`,
+		PrefixTemplate:     "host.name={host.name}/",
+		ResourceAttributes: map[string]string{"host.name": "web-1"},
+	}
+
+	r, err := newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, "root/host.name=web-1/", r.prefix)
+
+	cfg.Condition = "not a condition"
+	_, err = newLogsReceiver(newTestSettings(id, zap.NewNop()), cfg, consumertest.NewNop())
+	require.ErrorContains(t, err, "invalid condition")
+}
+
+func Test_fullRehydration(t *testing.T) {
+	testType, err := component.NewType("test")
+	require.NoError(t, err)
+
+	id := component.NewID(testType)
+	testLogger := zap.NewNop()
+	cfg := &Config{
+		StartingTime: "2023-10-02T17:00",
+		EndingTime:   "2023-10-02T18:00",
+		PollInterval: 10 * time.Millisecond,
+		Bucket:       "bucket",
+		DeleteOnRead: false,
+	}
+
+	t.Run("empty object polling", func(t *testing.T) {
+		var listCounter atomic.Int32
+
+		// Setup mocks
+		mockClient := setNewStorageClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.Bucket, (*string)(nil), (*string)(nil)).Times(3).Return([]*gcs.ObjectInfo{}, nil, nil).
+			Run(func(_ mock.Arguments) {
+				listCounter.Add(1)
+			})
+
+		// Create new receiver
+		testConsumer := &consumertest.MetricsSink{}
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return listCounter.Load() == 3
+		}
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		// Test data
+		metrics, jsonBytes := testutils.GenerateTestMetrics(t)
+		expectedBuffSize := int64(len(jsonBytes))
+
+		returnedObjectInfo := []*gcs.ObjectInfo{
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=05/metrics_12345.json",
+				Size: expectedBuffSize,
+			},
+			{
+				Name: "year=2023/month=10/day=01/hour=17/minute=05/metrics_7890.json",
+				Size: 5,
+			},
+		}
+
+		targetObject := returnedObjectInfo[0]
+
+		// Setup mocks
+		mockClient := setNewStorageClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.Bucket, (*string)(nil), (*string)(nil)).Return(returnedObjectInfo, nil, nil)
+		mockClient.EXPECT().DownloadObject(mock.Anything, cfg.Bucket, targetObject.Name, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+			require.Len(t, buf, int(expectedBuffSize))
+
+			copy(buf, jsonBytes)
+
+			return expectedBuffSize, nil
+		})
+
+		// Create new receiver
+		testConsumer := &consumertest.MetricsSink{}
+		r, err := newMetricsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.DataPointCount() == metrics.DataPointCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("traces", func(t *testing.T) {
+		// Test data
+		traces, jsonBytes := testutils.GenerateTestTraces(t)
+		expectedBuffSize := int64(len(jsonBytes))
+
+		returnedObjectInfo := []*gcs.ObjectInfo{
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=05/traces_12345.json",
+				Size: expectedBuffSize,
+			},
+			{
+				Name: "year=2023/month=10/day=01/hour=17/minute=05/traces_7890.json",
+				Size: 5,
+			},
+		}
+
+		targetObject := returnedObjectInfo[0]
+
+		// Setup mocks
+		mockClient := setNewStorageClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.Bucket, (*string)(nil), (*string)(nil)).Return(returnedObjectInfo, nil, nil)
+		mockClient.EXPECT().DownloadObject(mock.Anything, cfg.Bucket, targetObject.Name, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+			require.Len(t, buf, int(expectedBuffSize))
+
+			copy(buf, jsonBytes)
+
+			return expectedBuffSize, nil
+		})
+
+		// Create new receiver
+		testConsumer := &consumertest.TracesSink{}
+		r, err := newTracesReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.SpanCount() == traces.SpanCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		// Test data
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+		expectedBuffSize := int64(len(jsonBytes))
+
+		returnedObjectInfo := []*gcs.ObjectInfo{
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json",
+				Size: expectedBuffSize,
+			},
+			{
+				Name: "year=2023/month=10/day=01/hour=17/minute=05/logs_7890.json",
+				Size: 5,
+			},
+		}
+
+		targetObject := returnedObjectInfo[0]
+
+		// Setup mocks
+		mockClient := setNewStorageClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.Bucket, (*string)(nil), (*string)(nil)).Return(returnedObjectInfo, nil, nil)
+		mockClient.EXPECT().DownloadObject(mock.Anything, cfg.Bucket, targetObject.Name, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+			require.Len(t, buf, int(expectedBuffSize))
+
+			copy(buf, jsonBytes)
+
+			return expectedBuffSize, nil
+		})
+
+		// Create new receiver
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.LogRecordCount() == logs.LogRecordCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("gzip compression", func(t *testing.T) {
+		// Test data
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+		compressedBytes := gzipCompressData(t, jsonBytes)
+		expectedBuffSize := int64(len(compressedBytes))
+
+		returnedObjectInfo := []*gcs.ObjectInfo{
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json.gz",
+				Size: expectedBuffSize,
+			},
+			{
+				Name: "year=2023/month=10/day=01/hour=17/minute=05/logs_7890.json.gz",
+				Size: 5,
+			},
+		}
+
+		targetObject := returnedObjectInfo[0]
+
+		// Setup mocks
+		mockClient := setNewStorageClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.Bucket, (*string)(nil), (*string)(nil)).Return(returnedObjectInfo, nil, nil)
+		mockClient.EXPECT().DownloadObject(mock.Anything, cfg.Bucket, targetObject.Name, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+			require.Len(t, buf, int(expectedBuffSize))
+
+			copy(buf, compressedBytes)
+
+			return expectedBuffSize, nil
+		})
+
+		// Create new receiver
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.LogRecordCount() == logs.LogRecordCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	t.Run("Delete on Read", func(t *testing.T) {
+		cfg.DeleteOnRead = true
+		t.Cleanup(func() {
+			cfg.DeleteOnRead = false
+		})
+
+		// Test data
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+		expectedBuffSize := int64(len(jsonBytes))
+
+		returnedObjectInfo := []*gcs.ObjectInfo{
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json",
+				Size: expectedBuffSize,
+			},
+			{
+				Name: "year=2023/month=10/day=01/hour=17/minute=05/logs_7890.json",
+				Size: 5,
+			},
+		}
+
+		targetObject := returnedObjectInfo[0]
+
+		// Setup mocks
+		mockClient := setNewStorageClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.Bucket, (*string)(nil), (*string)(nil)).Return(returnedObjectInfo, nil, nil)
+		mockClient.EXPECT().DownloadObject(mock.Anything, cfg.Bucket, targetObject.Name, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+			require.Len(t, buf, int(expectedBuffSize))
+
+			copy(buf, jsonBytes)
+
+			return expectedBuffSize, nil
+		})
+		mockClient.EXPECT().DeleteObject(mock.Anything, cfg.Bucket, targetObject.Name).Return(nil)
+
+		// Create new receiver
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.LogRecordCount() == logs.LogRecordCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+
+	// This tests verifies all objects supplied paths are not attempted to be rehydrated.
+	t.Run("Skip parsing out of range or invalid paths", func(t *testing.T) {
+		// Test data
+		logs, jsonBytes := testutils.GenerateTestLogs(t)
+		expectedBuffSize := int64(len(jsonBytes))
+
+		returnedObjectInfo := []*gcs.ObjectInfo{
+			{
+				Name: "year=2022/month=10/day=02/hour=17/minute=05/logs_12345.json", // Out of time range
+			},
+			{
+				Name: "year=nope/month=10/day=02/hour=17/minute=05/logs_12345.json", // Bad time parsing
+			},
+			{
+				Name: "logs_7890.json", // Invalid path
+			},
+			{
+				Name: "year=2023/month=10/day=02/hour=17/minute=05/logs_12345.json", // objects are processed in order so adding a good one at the end to test when we are done
+				Size: expectedBuffSize,
+			},
+		}
+
+		targetObject := returnedObjectInfo[3]
+
+		// Setup mocks
+		mockClient := setNewStorageClient(t)
+		mockClient.EXPECT().ListObjects(mock.Anything, cfg.Bucket, (*string)(nil), (*string)(nil)).Return(returnedObjectInfo, nil, nil)
+		mockClient.EXPECT().DownloadObject(mock.Anything, cfg.Bucket, targetObject.Name, mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+			require.Len(t, buf, int(expectedBuffSize))
+
+			copy(buf, jsonBytes)
+
+			return expectedBuffSize, nil
+		})
+
+		// Create new receiver
+		testConsumer := &consumertest.LogsSink{}
+		r, err := newLogsReceiver(newTestSettings(id, testLogger), cfg, testConsumer)
+		require.NoError(t, err)
+
+		checkFunc := func() bool {
+			return testConsumer.LogRecordCount() == logs.LogRecordCount()
+		}
+
+		runRehydrationValidateTest(t, r, checkFunc)
+	})
+}
+
+func Test_processObject(t *testing.T) {
+	bucketName := "bucket"
+
+	// Tests jsonData to return for mock jsonData
+	jsonData := []byte(`{"one": "two"}`)
+	gzipData := gzipCompressData(t, jsonData)
+
+	testcases := []struct {
+		desc        string
+		info        rehydration.Entity
+		format      rehydration.Format
+		mockSetup   func(*gcsmocks.MockStorageClient, *rehydration.MockConsumer)
+		expectedErr error
+	}{
+		{
+			desc: "Download object error",
+			info: rehydration.Entity{
+				Name: "object.json",
+				Size: 10,
+			},
+			mockSetup: func(mockClient *gcsmocks.MockStorageClient, _ *rehydration.MockConsumer) {
+				mockClient.EXPECT().DownloadObject(mock.Anything, bucketName, "object.json", mock.Anything).Return(0, errors.New("bad"))
+			},
+			expectedErr: errors.New("download object: bad"),
+		},
+		{
+			desc: "unsupported extension",
+			info: rehydration.Entity{
+				Name: "object.nope",
+				Size: 10,
+			},
+			mockSetup: func(mockClient *gcsmocks.MockStorageClient, _ *rehydration.MockConsumer) {
+				mockClient.EXPECT().DownloadObject(mock.Anything, bucketName, "object.nope", mock.Anything).Return(0, nil)
+			},
+			expectedErr: errors.New("unsupported file type: .nope"),
+		},
+		{
+			desc: "Gzip compression",
+			info: rehydration.Entity{
+				Name: "object.json.gz",
+				Size: int64(len(gzipData)),
+			},
+			mockSetup: func(mockClient *gcsmocks.MockStorageClient, mockConsumer *rehydration.MockConsumer) {
+				mockClient.EXPECT().DownloadObject(mock.Anything, bucketName, "object.json.gz", mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+					copy(buf, gzipData)
+					return int64(len(gzipData)), nil
+				})
+
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatOTLPJSON).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "Json no compression",
+			info: rehydration.Entity{
+				Name: "object.json",
+				Size: int64(len(jsonData)),
+			},
+			mockSetup: func(mockClient *gcsmocks.MockStorageClient, mockConsumer *rehydration.MockConsumer) {
+				mockClient.EXPECT().DownloadObject(mock.Anything, bucketName, "object.json", mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+					copy(buf, jsonData)
+					return int64(len(jsonData)), nil
+				})
+
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatOTLPJSON).Return(nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			desc: "Consume error",
+			info: rehydration.Entity{
+				Name: "object.json",
+				Size: int64(len(jsonData)),
+			},
+			mockSetup: func(mockClient *gcsmocks.MockStorageClient, mockConsumer *rehydration.MockConsumer) {
+				mockClient.EXPECT().DownloadObject(mock.Anything, bucketName, "object.json", mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+					copy(buf, jsonData)
+					return int64(len(jsonData)), nil
+				})
+
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatOTLPJSON).Return(errors.New("bad"))
+			},
+			expectedErr: errors.New("consume: bad"),
+		},
+		{
+			desc: "Explicit format without extension",
+			info: rehydration.Entity{
+				Name: "object",
+				Size: int64(len(jsonData)),
+			},
+			format: rehydration.FormatNDJSON,
+			mockSetup: func(mockClient *gcsmocks.MockStorageClient, mockConsumer *rehydration.MockConsumer) {
+				mockClient.EXPECT().DownloadObject(mock.Anything, bucketName, "object", mock.Anything).RunAndReturn(func(_ context.Context, _ string, _ string, buf []byte) (int64, error) {
+					copy(buf, jsonData)
+					return int64(len(jsonData)), nil
+				})
+
+				mockConsumer.EXPECT().Consume(mock.Anything, jsonData, rehydration.FormatNDJSON).Return(nil)
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			mockClient := gcsmocks.NewMockStorageClient(t)
+			mockConsumer := rehydration.NewMockConsumer(t)
+
+			tc.mockSetup(mockClient, mockConsumer)
+
+			r := &rehydrationReceiver{
+				logger: zap.NewNop(),
+				cfg: &Config{
+					Bucket: bucketName,
+					Format: tc.format,
+				},
+				consumer:      mockConsumer,
+				storageClient: mockClient,
+				ctx:           context.Background(),
+			}
+
+			err := r.processObject(context.Background(), tc.info)
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			}
+		})
+	}
+}
+
+// newTestSettings returns receiver settings with the given id and logger
+func newTestSettings(id component.ID, logger *zap.Logger) receiver.Settings {
+	params := receivertest.NewNopSettings()
+	params.ID = id
+	params.Logger = logger
+	return params
+}
+
+// setNewStorageClient helper function used to set the newStorageClient
+// function with a mock and return the mock.
+func setNewStorageClient(t *testing.T) *gcsmocks.MockStorageClient {
+	t.Helper()
+	oldfunc := newStorageClient
+
+	mockClient := gcsmocks.NewMockStorageClient(t)
+
+	newStorageClient = func(_ context.Context, _ gcs.ClientConfig) (gcs.StorageClient, error) {
+		return mockClient, nil
+	}
+
+	t.Cleanup(func() {
+		newStorageClient = oldfunc
+	})
+
+	return mockClient
+}
+
+// runRehydrationValidateTest runs the rehydration tests with the passed in checkFunc
+func runRehydrationValidateTest(t *testing.T, r *rehydrationReceiver, checkFunc func() bool) {
+	// Start the receiver
+	err := r.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	// Wait for telemetry to be consumed
+	require.Eventually(t, checkFunc, time.Second, 10*time.Millisecond)
+
+	// Shutdown receivers
+	err = r.Shutdown(context.Background())
+	require.NoError(t, err)
+}
+
+// gzipCompressData compresses data for testing
+func gzipCompressData(t *testing.T, input []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+
+	_, err := writer.Write(input)
+	require.NoError(t, err)
+
+	err = writer.Close()
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
@@ -0,0 +1,161 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package m365receiver // import "github.com/observiq/bindplane-otel-collector/receiver/m365receiver"
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" // #nosec G505 -- x5t is defined as a SHA-1 thumbprint by RFC 7515
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// clientAssertionType is the OAuth client assertion type used for certificate credentials
+const clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// assertionLifetime is how long a signed client assertion is valid for
+const assertionLifetime = 10 * time.Minute
+
+// clientCertificate is a certificate and matching private key registered to the application
+type clientCertificate struct {
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+}
+
+// loadClientCertificate reads a PEM or PFX (PKCS#12) file containing a certificate and RSA private key.
+// Files ending in .pfx or .p12 are decoded as PKCS#12 using the given password.
+func loadClientCertificate(path, password string) (*clientCertificate, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read certificate file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".pfx", ".p12":
+		return parsePFX(data, password)
+	default:
+		return parsePEM(data)
+	}
+}
+
+func parsePFX(data []byte, password string) (*clientCertificate, error) {
+	key, cert, _, err := pkcs12.DecodeChain(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("decode pfx certificate: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("certificate private key must be an RSA key")
+	}
+	return &clientCertificate{cert: cert, key: rsaKey}, nil
+}
+
+func parsePEM(data []byte) (*clientCertificate, error) {
+	cc := &clientCertificate{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case "CERTIFICATE":
+			// the first certificate is the leaf, any others are the chain
+			if cc.cert != nil {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parse certificate: %w", err)
+			}
+			cc.cert = cert
+		case "RSA PRIVATE KEY":
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parse private key: %w", err)
+			}
+			cc.key = key
+		case "PRIVATE KEY":
+			key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("parse private key: %w", err)
+			}
+			rsaKey, ok := key.(*rsa.PrivateKey)
+			if !ok {
+				return nil, errors.New("certificate private key must be an RSA key")
+			}
+			cc.key = rsaKey
+		}
+	}
+
+	if cc.cert == nil {
+		return nil, errors.New("no certificate found in certificate file")
+	}
+	if cc.key == nil {
+		return nil, errors.New("no private key found in certificate file")
+	}
+	return cc, nil
+}
+
+// assertion creates a signed JWT client assertion for the given token endpoint
+func (c *clientCertificate) assertion(clientID, audience string, now time.Time) (string, error) {
+	thumbprint := sha1.Sum(c.cert.Raw) // #nosec G401 -- x5t is defined as a SHA-1 thumbprint by RFC 7515
+	header := map[string]string{
+		"alg": "RS256",
+		"typ": "JWT",
+		"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	}
+	claims := map[string]any{
+		"aud": audience,
+		"iss": clientID,
+		"sub": clientID,
+		"jti": uuid.NewString(),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
+		"exp": now.Add(assertionLifetime).Unix(),
+	}
+
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+	claimBytes, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(claimBytes)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, c.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign client assertion: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,144 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package m365receiver // import "github.com/observiq/bindplane-otel-collector/receiver/m365receiver"
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestLoadClientCertificate(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	dir := t.TempDir()
+
+	t.Run("pem pkcs8", func(t *testing.T) {
+		path := writeTestPEM(t, dir, cert, key)
+		cc, err := loadClientCertificate(path, "")
+		require.NoError(t, err)
+		require.Equal(t, cert.Raw, cc.cert.Raw)
+		require.True(t, key.Equal(cc.key))
+	})
+
+	t.Run("pfx", func(t *testing.T) {
+		path := writeTestPFX(t, dir, cert, key, "password")
+		cc, err := loadClientCertificate(path, "password")
+		require.NoError(t, err)
+		require.Equal(t, cert.Raw, cc.cert.Raw)
+		require.True(t, key.Equal(cc.key))
+
+		_, err = loadClientCertificate(path, "wrong")
+		require.ErrorContains(t, err, "decode pfx certificate")
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		path := filepath.Join(dir, "nokey.pem")
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), 0600))
+		_, err := loadClientCertificate(path, "")
+		require.EqualError(t, err, "no private key found in certificate file")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := loadClientCertificate(filepath.Join(dir, "missing.pem"), "")
+		require.ErrorContains(t, err, "read certificate file")
+	})
+}
+
+func TestClientAssertion(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	cc := &clientCertificate{cert: cert, key: key}
+	now := time.Now()
+
+	assertion, err := cc.assertion("testClientID", "https://login.microsoftonline.com/tenant/oauth2/v2.0/token", now)
+	require.NoError(t, err)
+	claims := verifyTestAssertion(t, assertion, cert)
+	require.Equal(t, "testClientID", claims["iss"])
+	require.Equal(t, "testClientID", claims["sub"])
+	require.Equal(t, "https://login.microsoftonline.com/tenant/oauth2/v2.0/token", claims["aud"])
+	require.EqualValues(t, now.Add(assertionLifetime).Unix(), claims["exp"])
+}
+
+// verifyTestAssertion checks the signature of a client assertion and returns its claims
+func verifyTestAssertion(t *testing.T, assertion string, cert *x509.Certificate) map[string]any {
+	parts := strings.Split(assertion, ".")
+	require.Len(t, parts, 3)
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	header := map[string]string{}
+	require.NoError(t, json.Unmarshal(headerBytes, &header))
+	require.Equal(t, "RS256", header["alg"])
+	require.NotEmpty(t, header["x5t"])
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(cert.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], signature))
+
+	claimBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	claims := map[string]any{}
+	require.NoError(t, json.Unmarshal(claimBytes, &claims))
+	return claims
+}
+
+func newTestCertificate(t *testing.T) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "m365receiver test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func writeTestPEM(t *testing.T, dir string, cert *x509.Certificate, key *rsa.PrivateKey) string {
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})...)
+	path := filepath.Join(dir, "cert.pem")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
+
+func writeTestPFX(t *testing.T, dir string, cert *x509.Certificate, key *rsa.PrivateKey, password string) string {
+	data, err := pkcs12.Modern.Encode(key, cert, nil, password)
+	require.NoError(t, err)
+	path := filepath.Join(dir, "cert.pfx")
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	return path
+}
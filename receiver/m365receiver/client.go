@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 type m365Client struct {
@@ -31,6 +32,8 @@ type m365Client struct {
 	authEndpoint string
 	clientID     string
 	clientSecret string
+	certFile     string
+	certPassword string
 	token        string
 	scope        string
 }
@@ -151,9 +154,11 @@ type Invitation struct {
 func newM365Client(c *http.Client, cfg *Config, scope string) *m365Client {
 	return &m365Client{
 		client:       c,
-		authEndpoint: fmt.Sprintf("https://login.microsoftonline.com/%s/oauth2/v2.0/token", cfg.TenantID),
+		authEndpoint: fmt.Sprintf("https://%s/%s/oauth2/v2.0/token", cfg.endpoints().authority, cfg.TenantID),
 		clientID:     cfg.ClientID,
 		clientSecret: cfg.ClientSecret,
+		certFile:     cfg.CertificateFile,
+		certPassword: cfg.CertificatePassword,
 		scope:        scope,
 	}
 }
@@ -166,12 +171,27 @@ func (m *m365Client) shutdown() error {
 // Get authorization token
 // metrics token has scope = "https://graph.microsoft.com/.default"
 // logs token has scope = "https://manage.office.com/.default"
+// hosts differ for national clouds
 func (m *m365Client) GetToken(ctx context.Context) error {
 	formData := url.Values{
-		"grant_type":    {"client_credentials"},
-		"scope":         {m.scope},
-		"client_id":     {m.clientID},
-		"client_secret": {m.clientSecret},
+		"grant_type": {"client_credentials"},
+		"scope":      {m.scope},
+		"client_id":  {m.clientID},
+	}
+	if m.certFile != "" {
+		// certificate is read on every token request so a renewed certificate is picked up without a restart
+		cert, err := loadClientCertificate(m.certFile, m.certPassword)
+		if err != nil {
+			return err
+		}
+		assertion, err := cert.assertion(m.clientID, m.authEndpoint, time.Now())
+		if err != nil {
+			return err
+		}
+		formData.Set("client_assertion_type", clientAssertionType)
+		formData.Set("client_assertion", assertion)
+	} else {
+		formData.Set("client_secret", m.clientSecret)
 	}
 	requestBody := strings.NewReader(formData.Encode())
 	resp, err := m.makeRequest(ctx, requestBody, "POST", m.authEndpoint, WithBody())
@@ -200,6 +220,9 @@ func (m *m365Client) GetToken(ctx context.Context) error {
 		case "unauthorized_client":
 			return fmt.Errorf("the provided client_id is incorrect or does not exist within the given tenant directory")
 		case "invalid_client":
+			if m.certFile != "" {
+				return fmt.Errorf("the provided certificate is not registered for the given client_id")
+			}
 			return fmt.Errorf("the provided client_secret is incorrect or does not belong to the given client_id")
 		case "invalid_request":
 			return fmt.Errorf("the provided tenant_id is incorrect or does not exist")
@@ -317,6 +340,45 @@ func (m *m365Client) StartSubscription(ctx context.Context, endpoint string) err
 	return nil
 }
 
+// GetGraphJSON retrieves records from a Microsoft Graph collection endpoint,
+// following @odata.nextLink until every page has been read
+func (m *m365Client) GetGraphJSON(ctx context.Context, endpoint string) ([]logData, error) {
+	var data = []logData{}
+	for endpoint != "" {
+		resp, err := m.makeRequest(ctx, nil, "GET", endpoint, WithToken(m.token))
+		if err != nil {
+			return []logData{}, err
+		}
+
+		// troubleshoot error code
+		if resp.StatusCode != 200 {
+			err = m.handleErrors(resp)
+			_ = resp.Body.Close()
+			if err == nil {
+				err = fmt.Errorf("got non 200 status code from request, got %d", resp.StatusCode)
+			}
+			return []logData{}, err
+		}
+
+		page := struct {
+			Value    []json.RawMessage `json:"value"`
+			NextLink string            `json:"@odata.nextLink"`
+		}{}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return []logData{}, err
+		}
+
+		for _, b := range page.Value {
+			data = append(data, logData{body: string(b)})
+		}
+		endpoint = page.NextLink
+	}
+
+	return data, nil
+}
+
 // followLink will follow the response of a first request that has a link to the actual content
 func (m *m365Client) followLink(ctx context.Context, endpoint string) ([]byte, error) {
 	resp, err := m.makeRequest(ctx, nil, "GET", endpoint, WithToken(m.token))
@@ -57,6 +57,39 @@ func TestGetToken(t *testing.T) {
 	m365Mock.Close()
 }
 
+func TestGetTokenCertificate(t *testing.T) {
+	cert, key := newTestCertificate(t)
+	certFile := writeTestPEM(t, t.TempDir(), cert, key)
+
+	var claims map[string]any
+	m365Mock := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+		if req.Form.Get("client_secret") != "" || req.Form.Get("client_assertion_type") != clientAssertionType {
+			rw.WriteHeader(401)
+			rw.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		claims = verifyTestAssertion(t, req.Form.Get("client_assertion"), cert)
+		rw.WriteHeader(200)
+		rw.Write([]byte(`{"token_type": "Bearer", "access_token": "testAccessToken"}`))
+	}))
+	defer m365Mock.Close()
+
+	testClient := newM365Client(m365Mock.Client(), &Config{ClientID: "testClientID", CertificateFile: certFile}, "https://graph.microsoft.us/.default")
+	testClient.authEndpoint = m365Mock.URL + "/testTenantID"
+
+	err := testClient.GetToken(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "testAccessToken", testClient.token)
+	require.Equal(t, m365Mock.URL+"/testTenantID", claims["aud"])
+	require.Equal(t, "testClientID", claims["iss"])
+
+	// certificate file removed
+	testClient.certFile = certFile + ".missing"
+	err = testClient.GetToken(context.Background())
+	require.ErrorContains(t, err, "read certificate file")
+}
+
 func TestGetCSV(t *testing.T) {
 	m365Mock := newMockServerCSV()
 	testClient := newM365Client(m365Mock.Client(), &Config{}, "https://graph.microsoft.com/.default")
@@ -129,6 +162,26 @@ func TestGetJSON(t *testing.T) {
 	m365Mock.Close()
 }
 
+func TestGetGraphJSON(t *testing.T) {
+	m365Mock := newMockServerGraph()
+	testClient := newM365Client(m365Mock.Client(), &Config{}, "https://graph.microsoft.com/.default")
+	testClient.token = "foo"
+
+	// expected behavior, follows next link
+	testJSON, err := testClient.GetGraphJSON(context.Background(), m365Mock.URL+"/auditLogs/signIns")
+	require.NoError(t, err)
+	require.Len(t, testJSON, 2)
+	require.Equal(t, `{"id":"first","createdDateTime":"2023-05-09T22:25:14Z"}`, testJSON[0].body)
+	require.Equal(t, `{"id":"second","createdDateTime":"2023-05-09T22:26:14Z"}`, testJSON[1].body)
+
+	// bad token
+	testClient.token = "bad"
+	_, err = testClient.GetGraphJSON(context.Background(), m365Mock.URL+"/auditLogs/signIns")
+	require.EqualError(t, err, "access token invalid")
+
+	m365Mock.Close()
+}
+
 func TestFollowLinkErr(t *testing.T) {
 	m365Mock := newMockServerJSON()
 	testClient := newM365Client(m365Mock.Client(), &Config{}, "https://manage.office.com/.default")
@@ -232,6 +285,29 @@ func newMockServerJSON() *httptest.Server {
 	}))
 }
 
+func newMockServerGraph() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if a := req.Header.Get("Authorization"); a != "Bearer foo" {
+			rw.WriteHeader(401)
+			rw.Write([]byte(`{"error": {"code": "InvalidAuthenticationToken", "message": "Access token is empty."}}`))
+			return
+		}
+		if req.URL.Path == "/auditLogs/signIns" {
+			if req.URL.Query().Get("page") == "2" {
+				rw.WriteHeader(200)
+				rw.Write([]byte(`{"value":[{"id":"second","createdDateTime":"2023-05-09T22:26:14Z"}]}`))
+				return
+			}
+			rw.WriteHeader(200)
+			rw.Write([]byte(fmt.Sprintf(
+				`{"@odata.nextLink":"%s/auditLogs/signIns?page=2","value":[{"id":"first","createdDateTime":"2023-05-09T22:25:14Z"}]}`,
+				"http://"+req.Host)))
+			return
+		}
+		rw.WriteHeader(404)
+	}))
+}
+
 func newMockServerSub() *httptest.Server {
 	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if req.URL.String() == "/testStartSub" {
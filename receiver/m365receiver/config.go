@@ -28,6 +28,30 @@ import (
 
 var clientSecretRegEx = regexp.MustCompile("^[a-zA-Z0-9-_.~]{1,40}$")
 
+// Supported Microsoft cloud environments
+const (
+	cloudCommercial = "commercial"
+	cloudGCC        = "gcc"
+	cloudGCCHigh    = "gcc_high"
+	cloudDoD        = "dod"
+	cloudChina      = "china"
+)
+
+// cloudEndpoints are the hosts used to authenticate and collect data in a Microsoft cloud environment
+type cloudEndpoints struct {
+	authority  string
+	management string
+	graph      string
+}
+
+var clouds = map[string]cloudEndpoints{
+	cloudCommercial: {"login.microsoftonline.com", "manage.office.com", "graph.microsoft.com"},
+	cloudGCC:        {"login.microsoftonline.com", "manage-gcc.office.com", "graph.microsoft.com"},
+	cloudGCCHigh:    {"login.microsoftonline.us", "manage.office365.us", "graph.microsoft.us"},
+	cloudDoD:        {"login.microsoftonline.us", "manage.protection.apps.mil", "dod-graph.microsoft.us"},
+	cloudChina:      {"login.chinacloudapi.cn", "manage.protection.partner.outlook.cn", "microsoftgraph.chinacloudapi.cn"},
+}
+
 // Config defines configuration for Microsoft Office 365 receiver.
 type Config struct {
 	scraperhelper.ControllerConfig `mapstructure:",squash"`
@@ -36,6 +60,12 @@ type Config struct {
 	TenantID                       string                        `mapstructure:"tenant_id"`
 	ClientID                       string                        `mapstructure:"client_id"`
 	ClientSecret                   string                        `mapstructure:"client_secret"`
+	CertificateFile                string                        `mapstructure:"certificate_file"`
+	CertificatePassword            string                        `mapstructure:"certificate_password"`
+	Cloud                          string                        `mapstructure:"cloud"`
+	AuthorityHost                  string                        `mapstructure:"authority_host"`
+	ManagementHost                 string                        `mapstructure:"management_host"`
+	GraphHost                      string                        `mapstructure:"graph_host"`
 	Logs                           *LogsConfig                   `mapstructure:"logs"`
 	StorageID                      *component.ID                 `mapstructure:"storage"`
 }
@@ -48,6 +78,12 @@ type LogsConfig struct {
 	SharepointLogs bool          `mapstructure:"sharepoint"`
 	AzureADLogs    bool          `mapstructure:"azureAD"`
 	DLPLogs        bool          `mapstructure:"dlp"`
+
+	// Microsoft Graph sources, these require additional application permissions
+	SignInLogs         bool `mapstructure:"sign_in"`
+	DirectoryAuditLogs bool `mapstructure:"directory_audit"`
+	SecurityAlerts     bool `mapstructure:"security_alerts"`
+	RiskyUsers         bool `mapstructure:"risky_users"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
@@ -68,13 +104,38 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("client_id is invalid; must be a GUID")
 	}
 
-	if c.ClientSecret == "" {
-		return fmt.Errorf("missing client_secret; required")
+	switch {
+	case c.ClientSecret == "" && c.CertificateFile == "":
+		return fmt.Errorf("missing client_secret or certificate_file; one is required")
+	case c.ClientSecret != "" && c.CertificateFile != "":
+		return fmt.Errorf("client_secret and certificate_file are mutually exclusive; only one may be set")
+	case c.ClientSecret != "" && !clientSecretRegEx.MatchString(c.ClientSecret):
+		return fmt.Errorf("client_secret is invalid; does not follow correct structure")
+	case c.CertificatePassword != "" && c.CertificateFile == "":
+		return fmt.Errorf("certificate_password is set without certificate_file")
 	}
 
-	if !clientSecretRegEx.MatchString(c.ClientSecret) {
-		return fmt.Errorf("client_secret is invalid; does not follow correct structure")
+	if _, ok := clouds[c.Cloud]; !ok && c.Cloud != "" {
+		return fmt.Errorf("cloud is invalid; must be one of %s, %s, %s, %s or %s", cloudCommercial, cloudGCC, cloudGCCHigh, cloudDoD, cloudChina)
 	}
 
 	return nil
 }
+
+// endpoints returns the hosts for the configured cloud, with any explicitly configured hosts taking precedence
+func (c *Config) endpoints() cloudEndpoints {
+	e, ok := clouds[c.Cloud]
+	if !ok {
+		e = clouds[cloudCommercial]
+	}
+	if c.AuthorityHost != "" {
+		e.authority = c.AuthorityHost
+	}
+	if c.ManagementHost != "" {
+		e.management = c.ManagementHost
+	}
+	if c.GraphHost != "" {
+		e.graph = c.GraphHost
+	}
+	return e
+}
@@ -47,6 +47,7 @@ func createDefaultConfig() component.Config {
 			Timeout: 10 * time.Second,
 		},
 		MetricsBuilderConfig: metadata.DefaultMetricsBuilderConfig(),
+		Cloud:                cloudCommercial,
 		Logs: &LogsConfig{
 			PollInterval:   5 * time.Minute,
 			GeneralLogs:    true,
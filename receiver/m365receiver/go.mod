@@ -22,6 +22,7 @@ require (
 	go.opentelemetry.io/collector/scraper v0.116.0
 	go.uber.org/goleak v1.3.0
 	go.uber.org/zap v1.27.0
+	software.sslmate.com/src/go-pkcs12 v0.5.0
 )
 
 require (
@@ -80,6 +81,7 @@ require (
 	go.opentelemetry.io/otel/sdk/metric v1.32.0 // indirect
 	go.opentelemetry.io/otel/trace v1.32.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
+	golang.org/x/crypto v0.31.0 // indirect
 	golang.org/x/net v0.32.0 // indirect
 	golang.org/x/sys v0.28.0 // indirect
 	golang.org/x/text v0.21.0 // indirect
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strconv"
 	"sync"
 	"time"
@@ -39,6 +40,7 @@ const (
 
 type lClient interface {
 	GetJSON(ctx context.Context, endpoint string, end string, start string) ([]logData, error)
+	GetGraphJSON(ctx context.Context, endpoint string) ([]logData, error)
 	GetToken(ctx context.Context) error
 	StartSubscription(ctx context.Context, endpoint string) error
 	shutdown() error
@@ -50,6 +52,7 @@ type m365LogsReceiver struct {
 	consumer      consumer.Logs
 	cfg           *Config
 	client        lClient
+	graphClient   lClient
 	storageClient storage.Client
 	id            component.ID
 
@@ -58,9 +61,11 @@ type m365LogsReceiver struct {
 	pollInterval time.Duration
 	cancel       context.CancelFunc
 	audits       []auditMetaData
+	graphAudits  []graphMetaData
 	record       *logRecord
 	root         string
 	startRoot    string
+	graphRoot    string
 }
 
 type logRecord struct {
@@ -73,7 +78,33 @@ type auditMetaData struct {
 	enabled bool
 }
 
+// graphMetaData describes a log source served by the Microsoft Graph API
+type graphMetaData struct {
+	name      string
+	route     string
+	timeField string
+	enabled   bool
+}
+
+// graphLog holds the Microsoft Graph fields that are promoted to attributes
+type graphLog struct {
+	ID                  string          `json:"id"`
+	UserID              string          `json:"userId"`
+	UserPrincipalName   string          `json:"userPrincipalName"`
+	AppDisplayName      string          `json:"appDisplayName"`
+	IPAddress           string          `json:"ipAddress"`
+	ActivityDisplayName string          `json:"activityDisplayName"`
+	Category            string          `json:"category"`
+	Result              string          `json:"result"`
+	Title               string          `json:"title"`
+	Severity            string          `json:"severity"`
+	RiskLevel           string          `json:"riskLevel"`
+	RiskState           string          `json:"riskState"`
+	Status              json.RawMessage `json:"status,omitempty"`
+}
+
 func newM365Logs(cfg *Config, settings receiver.Settings, consumer consumer.Logs) *m365LogsReceiver {
+	endpoints := cfg.endpoints()
 	return &m365LogsReceiver{
 		settings:      settings.TelemetrySettings,
 		logger:        settings.Logger,
@@ -90,8 +121,15 @@ func newM365Logs(cfg *Config, settings receiver.Settings, consumer consumer.Logs
 			{"azureAD", "Audit.AzureActiveDirectory", cfg.Logs.AzureADLogs},
 			{"dlp", "DLP.All", cfg.Logs.DLPLogs},
 		},
-		root:      fmt.Sprintf("https://manage.office.com/api/v1.0/%s/activity/feed/subscriptions/content?contentType=", cfg.TenantID),
-		startRoot: fmt.Sprintf("https://manage.office.com/api/v1.0/%s/activity/feed/subscriptions/start?contentType=", cfg.TenantID),
+		graphAudits: []graphMetaData{
+			{"sign_in", "auditLogs/signIns", "createdDateTime", cfg.Logs.SignInLogs},
+			{"directory_audit", "auditLogs/directoryAudits", "activityDateTime", cfg.Logs.DirectoryAuditLogs},
+			{"security_alerts", "security/alerts_v2", "createdDateTime", cfg.Logs.SecurityAlerts},
+			{"risky_users", "identityProtection/riskyUsers", "riskLastUpdatedDateTime", cfg.Logs.RiskyUsers},
+		},
+		root:      fmt.Sprintf("https://%s/api/v1.0/%s/activity/feed/subscriptions/content?contentType=", endpoints.management, cfg.TenantID),
+		startRoot: fmt.Sprintf("https://%s/api/v1.0/%s/activity/feed/subscriptions/start?contentType=", endpoints.management, cfg.TenantID),
+		graphRoot: fmt.Sprintf("https://%s/v1.0/", endpoints.graph),
 	}
 }
 
@@ -105,7 +143,7 @@ func (l *m365LogsReceiver) Start(ctx context.Context, host component.Host) error
 	}
 
 	// create m365 log client, create token and start audit subscriptions
-	l.client = newM365Client(httpClient, l.cfg, "https://manage.office.com/.default")
+	l.client = newM365Client(httpClient, l.cfg, fmt.Sprintf("https://%s/.default", l.cfg.endpoints().management))
 	err = l.client.GetToken(ctx)
 	if err != nil {
 		l.logger.Error("error creating authorization token", zap.Error(err))
@@ -119,6 +157,16 @@ func (l *m365LogsReceiver) Start(ctx context.Context, host component.Host) error
 		}
 	}
 
+	// create graph log client only when a graph source is enabled, as it requires its own token scope
+	if l.graphEnabled() {
+		l.graphClient = newM365Client(httpClient, l.cfg, fmt.Sprintf("https://%s/.default", l.cfg.endpoints().graph))
+		err = l.graphClient.GetToken(ctx)
+		if err != nil {
+			l.logger.Error("error creating graph authorization token", zap.Error(err))
+			return err
+		}
+	}
+
 	// set cancel function
 	cancelCtx, cancel := context.WithCancel(ctx)
 	l.cancel = cancel
@@ -187,6 +235,10 @@ func (l *m365LogsReceiver) pollLogs(ctx context.Context) error {
 		auditWG.Add(1)
 		go l.poll(ctx, now, st, &l.audits[i], auditWG)
 	}
+	for i := 0; i < len(l.graphAudits); i++ {
+		auditWG.Add(1)
+		go l.pollGraph(ctx, now, st, &l.graphAudits[i], auditWG)
+	}
 	auditWG.Wait()
 
 	l.record.NextStartTime = &now
@@ -214,6 +266,27 @@ func (l *m365LogsReceiver) poll(ctx context.Context, now time.Time, st string, a
 	}
 }
 
+// collects log data from a graph endpoint, transforms logs, consumes logs
+func (l *m365LogsReceiver) pollGraph(ctx context.Context, now time.Time, st string, audit *graphMetaData, wg *sync.WaitGroup) {
+	defer wg.Done()
+	if !audit.enabled {
+		return
+	}
+
+	data, err := l.getGraphLogs(ctx, now.Format(layout), st, audit)
+	if err != nil {
+		return
+	}
+
+	logs := l.transformGraphLogs(pcommon.NewTimestampFromTime(now), audit, data)
+
+	if logs.LogRecordCount() > 0 {
+		if err = l.consumer.ConsumeLogs(ctx, logs); err != nil {
+			l.logger.Error("error consuming events", zap.Error(err))
+		}
+	}
+}
+
 func (l *m365LogsReceiver) getLogs(ctx context.Context, end string, start string, audit *auditMetaData) ([]logData, error) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
@@ -241,6 +314,47 @@ func (l *m365LogsReceiver) getLogs(ctx context.Context, end string, start string
 	return data, nil
 }
 
+func (l *m365LogsReceiver) getGraphLogs(ctx context.Context, end string, start string, audit *graphMetaData) ([]logData, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// graph filters expect UTC timestamps with an explicit zone designator
+	filter := fmt.Sprintf("%s ge %sZ and %s lt %sZ", audit.timeField, start, audit.timeField, end)
+	endpoint := l.graphRoot + audit.route + "?$filter=" + url.QueryEscape(filter)
+
+	data, err := l.graphClient.GetGraphJSON(ctx, endpoint)
+	if err != nil {
+		if err.Error() == "access token invalid" || err.Error() == "authorization denied" { // troubleshoot stale token
+			l.logger.Debug("possible stale graph token; attempting to regenerate")
+			err = l.graphClient.GetToken(ctx)
+			if err != nil { // something went wrong generating token
+				l.logger.Error("error creating graph authorization token", zap.Error(err))
+				return []logData{}, err
+			}
+			data, err = l.graphClient.GetGraphJSON(ctx, endpoint)
+			if err != nil { // not a stale token error, unsure what is wrong
+				l.logger.Error("unable to retrieve graph logs", zap.Error(err))
+				return []logData{}, err
+			}
+		} else {
+			l.logger.Error("error retrieving graph logs", zap.Error(err))
+			return []logData{}, err
+		}
+	}
+
+	return data, nil
+}
+
+// returns true if any graph log source is enabled
+func (l *m365LogsReceiver) graphEnabled() bool {
+	for _, a := range l.graphAudits {
+		if a.enabled {
+			return true
+		}
+	}
+	return false
+}
+
 // constructs logs from logData
 func (l *m365LogsReceiver) transformLogs(now pcommon.Timestamp, audit *auditMetaData, data []logData) plog.Logs {
 	logs := plog.NewLogs()
@@ -291,6 +405,55 @@ func (l *m365LogsReceiver) transformLogs(now pcommon.Timestamp, audit *auditMeta
 	return logs
 }
 
+// constructs logs from graph logData
+func (l *m365LogsReceiver) transformGraphLogs(now pcommon.Timestamp, audit *graphMetaData, data []logData) plog.Logs {
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+
+	ra := resourceLogs.Resource().Attributes()
+	ra.PutStr("m365.audit", audit.name)
+	ra.PutStr("m365.organization.id", l.cfg.TenantID)
+
+	for _, logData := range data {
+		logRecord := scopeLogs.LogRecords().AppendEmpty()
+		logRecord.SetObservedTimestamp(now)
+		logRecord.SetTimestamp(now)
+
+		// parses body string and sets that as log body, but uses string if parsing fails
+		parsedBody := map[string]any{}
+		if err := json.Unmarshal([]byte(logData.body), &parsedBody); err != nil {
+			l.logger.Warn("unable to unmarshal log body", zap.Error(err))
+			logRecord.Body().SetStr(logData.body)
+			continue
+		}
+		if err := logRecord.Body().SetEmptyMap().FromRaw(parsedBody); err != nil {
+			l.logger.Warn("failed to set body to parsed value", zap.Error(err))
+			logRecord.Body().SetStr(logData.body)
+		}
+
+		// timestamp
+		if raw, ok := parsedBody[audit.timeField].(string); ok {
+			ts, err := time.Parse(time.RFC3339Nano, raw)
+			if err != nil {
+				l.logger.Warn("unable to interpret when an event was created, expecting a RFC3339 timestamp", zap.String("timestamp", raw))
+			} else {
+				logRecord.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+			}
+		}
+
+		// attributes
+		var log graphLog
+		if err := json.Unmarshal([]byte(logData.body), &log); err != nil {
+			l.logger.Warn("unable to parse graph attributes", zap.Error(err))
+		}
+		attrs := logRecord.Attributes()
+		parseGraphAttributes(&attrs, audit, &log)
+	}
+
+	return logs
+}
+
 // sets the checkpoint
 func (l *m365LogsReceiver) checkpoint(ctx context.Context) error {
 	if l.record == nil {
@@ -431,6 +594,41 @@ func parseOptionalAttributes(m *pcommon.Map, log *jsonLog) {
 
 }
 
+// adds the attributes relevant to the graph source a log came from
+func parseGraphAttributes(m *pcommon.Map, audit *graphMetaData, log *graphLog) {
+	setAttributeIfNotEmpty(m, "id", log.ID)
+
+	switch audit.name {
+	case "sign_in":
+		setAttributeIfNotEmpty(m, "user.id", log.UserID)
+		setAttributeIfNotEmpty(m, "user.principal_name", log.UserPrincipalName)
+		setAttributeIfNotEmpty(m, "app.name", log.AppDisplayName)
+		setAttributeIfNotEmpty(m, "client.ip", log.IPAddress)
+		status := struct {
+			ErrorCode *int `json:"errorCode"`
+		}{}
+		if err := json.Unmarshal(log.Status, &status); err == nil && status.ErrorCode != nil {
+			m.PutInt("sign_in.error_code", int64(*status.ErrorCode))
+		}
+	case "directory_audit":
+		setAttributeIfNotEmpty(m, "operation", log.ActivityDisplayName)
+		setAttributeIfNotEmpty(m, "category", log.Category)
+		setAttributeIfNotEmpty(m, "result", log.Result)
+	case "security_alerts":
+		setAttributeIfNotEmpty(m, "security.alert.id", log.ID)
+		setAttributeIfNotEmpty(m, "security.alert.name", log.Title)
+		setAttributeIfNotEmpty(m, "security.alert.severity", log.Severity)
+		var status string
+		if err := json.Unmarshal(log.Status, &status); err == nil {
+			setAttributeIfNotEmpty(m, "security.alert.status", status)
+		}
+	case "risky_users":
+		setAttributeIfNotEmpty(m, "user.principal_name", log.UserPrincipalName)
+		setAttributeIfNotEmpty(m, "risk.level", log.RiskLevel)
+		setAttributeIfNotEmpty(m, "risk.state", log.RiskState)
+	}
+}
+
 func matchUserType(x int) string {
 	switch x {
 	case 0:
@@ -154,6 +154,80 @@ func TestPollErrHandle(t *testing.T) {
 	}, 5*time.Second, 1*time.Second)
 }
 
+func TestPollGraphLogs(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantID = "testTenantID"
+	cfg.Logs.GeneralLogs = false
+	cfg.Logs.ExchangeLogs = false
+	cfg.Logs.SharepointLogs = false
+	cfg.Logs.AzureADLogs = false
+	cfg.Logs.DLPLogs = false
+	cfg.Logs.SignInLogs = true
+	cfg.Logs.SecurityAlerts = true
+
+	sink := &consumertest.LogsSink{}
+	rcv := newM365Logs(cfg, receivertest.NewNopSettings(), sink)
+	require.True(t, rcv.graphEnabled())
+	client := &mockLogsClient{}
+	rcv.client = client
+	rcv.graphClient = client
+	rcv.record = &logRecord{}
+
+	client.On("GetGraphJSON", mock.Anything, mock.MatchedBy(func(e string) bool {
+		return strings.HasPrefix(e, rcv.graphRoot+"auditLogs/signIns?$filter=")
+	})).Return(loadGraphLogs(t, filepath.Join("testdata", "logs", "testPollGraphLogs", "sign_in_input.json")), nil).Once()
+	client.On("GetGraphJSON", mock.Anything, mock.MatchedBy(func(e string) bool {
+		return strings.HasPrefix(e, rcv.graphRoot+"security/alerts_v2?$filter=")
+	})).Return(loadGraphLogs(t, filepath.Join("testdata", "logs", "testPollGraphLogs", "security_alerts_input.json")), nil).Once()
+
+	err := rcv.pollLogs(context.Background())
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	require.Equal(t, 2, sink.LogRecordCount())
+
+	for _, l := range sink.AllLogs() {
+		audit, exist := l.ResourceLogs().At(0).Resource().Attributes().Get("m365.audit")
+		require.True(t, exist)
+
+		expected, err := ReadLogs(filepath.Join("testdata", "logs", "testPollGraphLogs", fmt.Sprintf("%s.json", audit.Str())))
+		require.NoError(t, err)
+		require.NoError(t, plogtest.CompareLogs(expected, l, plogtest.IgnoreObservedTimestamp()))
+	}
+}
+
+func TestPollGraphErrHandle(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.TenantID = "test"
+	sink := &consumertest.LogsSink{}
+	client := &mockLogsClient{}
+	audit := graphMetaData{
+		name:      "sign_in",
+		route:     "auditLogs/signIns",
+		timeField: "createdDateTime",
+		enabled:   true,
+	}
+	wg := &sync.WaitGroup{}
+	rcv := newM365Logs(cfg, receivertest.NewNopSettings(), sink)
+	rcv.graphClient = client
+
+	// unable to fix token
+	client.On("GetGraphJSON", mock.Anything, mock.Anything).Return([]logData{}, fmt.Errorf("access token invalid")).Once()
+	client.On("GetToken", mock.Anything).Return(fmt.Errorf("err")).Once()
+	wg.Add(1)
+	rcv.pollGraph(context.Background(), time.Now(), "", &audit, wg)
+	require.Equal(t, 0, sink.LogRecordCount())
+
+	// regenerate token works
+	file := filepath.Join("testdata", "logs", "testPollGraphLogs", "sign_in_input.json")
+	client.On("GetGraphJSON", mock.Anything, mock.Anything).Return([]logData{}, fmt.Errorf("access token invalid")).Once()
+	client.On("GetToken", mock.Anything).Return(nil).Once()
+	client.On("GetGraphJSON", mock.Anything, mock.Anything).Return(loadGraphLogs(t, file), nil).Once()
+	wg.Add(1)
+	rcv.pollGraph(context.Background(), time.Now(), "", &audit, wg)
+	require.Equal(t, 1, sink.LogRecordCount())
+	client.AssertExpectations(t)
+}
+
 func TestParseOptionalAttributes(t *testing.T) {
 	m := pcommon.NewMap()
 	log := jsonLog{
@@ -181,6 +255,20 @@ type mockLogsClient struct {
 	mock.Mock
 }
 
+func loadGraphLogs(t *testing.T, file string) []logData {
+	logBytes, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var records []json.RawMessage
+	require.NoError(t, json.Unmarshal(logBytes, &records))
+
+	var ret = []logData{}
+	for _, r := range records {
+		ret = append(ret, logData{body: string(r)})
+	}
+	return ret
+}
+
 func (mc *mockLogsClient) loadTestLogs(t *testing.T, file string) []logData {
 	logBytes, err := os.ReadFile(file)
 	require.NoError(t, err)
@@ -214,6 +302,11 @@ func (mc *mockLogsClient) GetJSON(ctx context.Context, endpoint string, end stri
 	return args.Get(0).([]logData), args.Error(1)
 }
 
+func (mc *mockLogsClient) GetGraphJSON(ctx context.Context, endpoint string) ([]logData, error) {
+	args := mc.Called(ctx, endpoint)
+	return args.Get(0).([]logData), args.Error(1)
+}
+
 func (mc *mockLogsClient) GetToken(ctx context.Context) error {
 	args := mc.Called(ctx)
 	return args.Error(0)
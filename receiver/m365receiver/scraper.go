@@ -16,6 +16,7 @@ package m365receiver // import "github.com/observiq/bindplane-otel-collector/rec
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -271,7 +272,7 @@ func newM365Scraper(
 		cfg:      cfg,
 		rb:       metadata.NewResourceBuilder(cfg.MetricsBuilderConfig.ResourceAttributes),
 		mb:       metadata.NewMetricsBuilder(cfg.MetricsBuilderConfig, settings),
-		root:     "https://graph.microsoft.com/v1.0/reports/",
+		root:     fmt.Sprintf("https://%s/v1.0/reports/", cfg.endpoints().graph),
 	}
 	return m
 }
@@ -283,7 +284,7 @@ func (m *m365Scraper) start(ctx context.Context, host component.Host) error {
 		return err
 	}
 
-	m.client = newM365Client(httpClient, m.cfg, "https://graph.microsoft.com/.default")
+	m.client = newM365Client(httpClient, m.cfg, fmt.Sprintf("https://%s/.default", m.cfg.endpoints().graph))
 
 	err = m.client.GetToken(ctx)
 	if err != nil {
@@ -0,0 +1,183 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Catalog resolves plugins by name and version from a list of search paths and an embedded catalog
+type Catalog struct {
+	searchPaths []string
+	embedded    fs.FS
+}
+
+// NewCatalog creates a catalog that searches the directories in searchPaths in order,
+// followed by the embedded file system. The embedded file system may be nil.
+func NewCatalog(searchPaths []string, embedded fs.FS) *Catalog {
+	return &Catalog{
+		searchPaths: searchPaths,
+		embedded:    embedded,
+	}
+}
+
+// catalogEntry is a plugin found in the catalog that matches the requested name
+type catalogEntry struct {
+	plugin  *Plugin
+	version *version.Version
+	source  string
+}
+
+// Resolve returns the highest version of the named plugin that satisfies the version constraint.
+// An empty constraint matches any version.
+// If the same version is found more than once, the one found first in the search order is returned,
+// so plugins in a search path take precedence over the embedded catalog.
+//
+// A plugin's name is its file name without the extension. Multiple versions of a plugin can be
+// kept in one directory by naming the files <name>@<version>.yaml.
+func (c *Catalog) Resolve(name, constraint string) (*Plugin, error) {
+	var constraints version.Constraints
+	if constraint != "" {
+		var err error
+		constraints, err = version.NewConstraint(constraint)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+		}
+	}
+
+	entries, err := c.find(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("plugin %s not found", name)
+	}
+
+	var best *catalogEntry
+	found := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		found = append(found, entry.plugin.Version)
+
+		if constraints != nil && (entry.version == nil || !constraints.Check(entry.version)) {
+			continue
+		}
+
+		if best == nil || newerVersion(entry.version, best.version) {
+			best = entry
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no version of plugin %s satisfies %s, found: %s", name, constraint, strings.Join(found, ", "))
+	}
+
+	return best.plugin, nil
+}
+
+// find returns all plugins with the given name in search order
+func (c *Catalog) find(name string) ([]*catalogEntry, error) {
+	entries := make([]*catalogEntry, 0)
+	for _, searchPath := range c.searchPaths {
+		found, err := findInFS(os.DirFS(searchPath), name, searchPath)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, found...)
+	}
+
+	if c.embedded != nil {
+		found, err := findInFS(c.embedded, name, "embedded catalog")
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, found...)
+	}
+
+	return entries, nil
+}
+
+// findInFS returns all plugins in the root of fsys with the given name
+func findInFS(fsys fs.FS, name, source string) ([]*catalogEntry, error) {
+	dirEntries, err := fs.ReadDir(fsys, ".")
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+
+	entries := make([]*catalogEntry, 0)
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || pluginName(dirEntry.Name()) != name {
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, dirEntry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from %s: %w", dirEntry.Name(), source, err)
+		}
+
+		plugin, err := parsePlugin(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s from %s: %w", dirEntry.Name(), source, err)
+		}
+
+		// Plugins with an invalid version can still be resolved without a constraint
+		v, _ := version.NewVersion(plugin.Version)
+
+		entries = append(entries, &catalogEntry{
+			plugin:  plugin,
+			version: v,
+			source:  source,
+		})
+	}
+
+	return entries, nil
+}
+
+// pluginName returns the name of the plugin in the file, or an empty string if it is not a plugin file
+func pluginName(fileName string) string {
+	ext := path.Ext(fileName)
+	if ext != ".yaml" && ext != ".yml" {
+		return ""
+	}
+
+	name := strings.TrimSuffix(fileName, ext)
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+
+	return name
+}
+
+// newerVersion returns true if a is a newer version than b. A nil version is older than any valid version.
+func newerVersion(a, b *version.Version) bool {
+	switch {
+	case a == nil:
+		return false
+	case b == nil:
+		return true
+	default:
+		return a.GreaterThan(b)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogResolve(t *testing.T) {
+	embedded := fstest.MapFS{
+		"test-plugin.yaml": &fstest.MapFile{
+			Data: []byte("title: embedded\ntemplate: \"receivers:\"\nversion: 1.2.0\n"),
+		},
+		"embedded-only.yml": &fstest.MapFile{
+			Data: []byte("title: embedded-only\ntemplate: \"receivers:\"\nversion: 0.1.0\n"),
+		},
+		"not-a-plugin.txt": &fstest.MapFile{
+			Data: []byte("text"),
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		searchPaths     []string
+		plugin          string
+		constraint      string
+		expectedVersion string
+		expectedTitle   string
+		expectedErr     error
+	}{
+		{
+			name:            "highest version without constraint",
+			searchPaths:     []string{"./testdata/catalog/local"},
+			plugin:          "test-plugin",
+			expectedVersion: "2.0.0",
+		},
+		{
+			name:            "highest version matching constraint",
+			searchPaths:     []string{"./testdata/catalog/local"},
+			plugin:          "test-plugin",
+			constraint:      "< 2.0.0",
+			expectedVersion: "1.2.0",
+			expectedTitle:   "test-plugin",
+		},
+		{
+			name:            "search path takes precedence over embedded catalog",
+			searchPaths:     []string{"./testdata/catalog/local"},
+			plugin:          "test-plugin",
+			constraint:      "= 1.2.0",
+			expectedVersion: "1.2.0",
+			expectedTitle:   "test-plugin",
+		},
+		{
+			name:            "embedded catalog",
+			plugin:          "test-plugin",
+			expectedVersion: "1.2.0",
+			expectedTitle:   "embedded",
+		},
+		{
+			name:            "yml extension",
+			plugin:          "embedded-only",
+			expectedVersion: "0.1.0",
+		},
+		{
+			name:            "missing search path is skipped",
+			searchPaths:     []string{"./testdata/catalog/missing"},
+			plugin:          "embedded-only",
+			expectedVersion: "0.1.0",
+		},
+		{
+			name:        "no matching version",
+			searchPaths: []string{"./testdata/catalog/local"},
+			plugin:      "test-plugin",
+			constraint:  ">= 3.0.0",
+			expectedErr: errors.New("no version of plugin test-plugin satisfies >= 3.0.0, found: 1.2.0, 2.0.0, 1.2.0"),
+		},
+		{
+			name:        "invalid constraint",
+			plugin:      "test-plugin",
+			constraint:  "latest",
+			expectedErr: errors.New("invalid version constraint \"latest\""),
+		},
+		{
+			name:        "not found",
+			plugin:      "missing",
+			expectedErr: errors.New("plugin missing not found"),
+		},
+		{
+			name:        "invalid plugin yaml",
+			searchPaths: []string{"./testdata/catalog/local"},
+			plugin:      "invalid",
+			expectedErr: errors.New("failed to load invalid.yaml from ./testdata/catalog/local"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			catalog := NewCatalog(tc.searchPaths, embedded)
+			plugin, err := catalog.Resolve(tc.plugin, tc.constraint)
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedVersion, plugin.Version)
+			if tc.expectedTitle != "" {
+				require.Equal(t, tc.expectedTitle, plugin.Title)
+			}
+		})
+	}
+}
+
+func TestPluginName(t *testing.T) {
+	require.Equal(t, "apache_combined_logs", pluginName("apache_combined_logs.yaml"))
+	require.Equal(t, "apache_combined_logs", pluginName("apache_combined_logs@1.0.0.yml"))
+	require.Equal(t, "", pluginName("README.md"))
+}
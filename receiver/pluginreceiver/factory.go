@@ -16,13 +16,19 @@ package pluginreceiver
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"io/fs"
+	"net/http"
+	"time"
 
+	"github.com/hashicorp/go-version"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap/zapcore"
 )
 
 // componentType is the value of the "type" key in configuration.
@@ -32,55 +38,123 @@ const (
 	stability = component.StabilityLevelBeta
 )
 
+// fetchTimeout is the timeout used when fetching a plugin from a URL
+const fetchTimeout = 30 * time.Second
+
 // Config is the configuration of a plugin receiver
 type Config struct {
-	Path       string         `mapstructure:"path"`
+	// Path is the path to a plugin file
+	Path string `mapstructure:"path"`
+
+	// Name is the name of a plugin resolved from the search paths and embedded catalog
+	Name string `mapstructure:"name"`
+
+	// Version is a version constraint the plugin must satisfy, such as ">= 1.0, < 2.0"
+	Version string `mapstructure:"version"`
+
+	// SearchPaths are directories searched in order when resolving a plugin by name
+	SearchPaths []string `mapstructure:"search_paths"`
+
+	// URL is a URL the plugin is fetched from
+	URL string `mapstructure:"url"`
+
+	// SHA256 is the expected hex encoded SHA-256 digest of the plugin fetched from URL
+	SHA256 string `mapstructure:"sha256"`
+
+	// LogLevel is the minimum level of logs written by the plugin's internal service
+	LogLevel zapcore.Level `mapstructure:"log_level"`
+
 	Parameters map[string]any `mapstructure:"parameters"`
 }
 
+// Validate validates the config
+func (c *Config) Validate() error {
+	sources := 0
+	for _, source := range []string{c.Path, c.Name, c.URL} {
+		if source != "" {
+			sources++
+		}
+	}
+
+	switch {
+	case sources == 0:
+		return errors.New("one of path, name or url is required")
+	case sources > 1:
+		return errors.New("only one of path, name or url can be set")
+	}
+
+	if c.URL != "" {
+		digest, err := hex.DecodeString(c.SHA256)
+		if err != nil || len(digest) != 32 {
+			return errors.New("sha256 must be a hex encoded SHA-256 digest when url is set")
+		}
+	}
+
+	if c.Version != "" {
+		if _, err := version.NewConstraint(c.Version); err != nil {
+			return fmt.Errorf("invalid version constraint: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // createDefaultConfig creates a default config for a plugin receiver
 func createDefaultConfig() component.Config {
 	return &Config{
+		LogLevel:   zapcore.ErrorLevel,
 		Parameters: make(map[string]any),
 	}
 }
 
 // NewFactory creates a factory for a plugin receiver
 func NewFactory() receiver.Factory {
+	return NewFactoryWithCatalog(nil)
+}
+
+// NewFactoryWithCatalog creates a factory for a plugin receiver that resolves plugins by name
+// from the embedded catalog after the configured search paths.
+func NewFactoryWithCatalog(catalog fs.FS) receiver.Factory {
+	f := &factory{catalog: catalog}
 	return receiver.NewFactory(componentType,
 		createDefaultConfig,
-		receiver.WithLogs(createLogsReceiver, stability),
-		receiver.WithMetrics(createMetricsReceiver, stability),
-		receiver.WithTraces(createTracesReceiver, stability),
+		receiver.WithLogs(f.createLogsReceiver, stability),
+		receiver.WithMetrics(f.createMetricsReceiver, stability),
+		receiver.WithTraces(f.createTracesReceiver, stability),
 	)
 }
 
+// factory creates plugin receivers
+type factory struct {
+	catalog fs.FS
+}
+
 // createLogsReceiver creates a plugin receiver with a logs consumer
-func createLogsReceiver(_ context.Context, set receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
+func (f *factory) createLogsReceiver(ctx context.Context, set receiver.Settings, cfg component.Config, consumer consumer.Logs) (receiver.Logs, error) {
 	emitterFactory := createLogEmitterFactory(consumer)
-	return createReceiver(cfg, set, emitterFactory)
+	return f.createReceiver(ctx, cfg, set, emitterFactory)
 }
 
 // createMetricsReceiver creates a plugin receiver with a metrics consumer
-func createMetricsReceiver(_ context.Context, set receiver.Settings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
+func (f *factory) createMetricsReceiver(ctx context.Context, set receiver.Settings, cfg component.Config, consumer consumer.Metrics) (receiver.Metrics, error) {
 	emitterFactory := createMetricEmitterFactory(consumer)
-	return createReceiver(cfg, set, emitterFactory)
+	return f.createReceiver(ctx, cfg, set, emitterFactory)
 }
 
 // createTracesReceiver creates a plugin receiver with a traces consumer
-func createTracesReceiver(_ context.Context, set receiver.Settings, cfg component.Config, consumer consumer.Traces) (receiver.Traces, error) {
+func (f *factory) createTracesReceiver(ctx context.Context, set receiver.Settings, cfg component.Config, consumer consumer.Traces) (receiver.Traces, error) {
 	emitterFactory := createTraceEmitterFactory(consumer)
-	return createReceiver(cfg, set, emitterFactory)
+	return f.createReceiver(ctx, cfg, set, emitterFactory)
 }
 
 // createReceiver creates a plugin receiver with the supplied emitter
-func createReceiver(cfg component.Config, set receiver.Settings, emitterFactory exporter.Factory) (*Receiver, error) {
+func (f *factory) createReceiver(ctx context.Context, cfg component.Config, set receiver.Settings, emitterFactory exporter.Factory) (*Receiver, error) {
 	receiverConfig, ok := cfg.(*Config)
 	if !ok {
 		return nil, errors.New("config is not a plugin receiver config")
 	}
 
-	plugin, err := LoadPlugin(receiverConfig.Path)
+	plugin, err := f.loadPlugin(ctx, receiverConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load plugin: %w", err)
 	}
@@ -94,5 +168,32 @@ func createReceiver(cfg component.Config, set receiver.Settings, emitterFactory
 		return nil, fmt.Errorf("failed to render plugin: %w", err)
 	}
 
-	return NewReceiver(plugin, renderedCfg, emitterFactory, set.Logger), nil
+	return NewReceiver(plugin, renderedCfg, emitterFactory, set, receiverConfig.LogLevel), nil
+}
+
+// loadPlugin loads the plugin from the configured path, catalog or URL and checks its version
+func (f *factory) loadPlugin(ctx context.Context, cfg *Config) (*Plugin, error) {
+	// Resolve checks the version constraint while choosing between versions
+	if cfg.Name != "" {
+		return NewCatalog(cfg.SearchPaths, f.catalog).Resolve(cfg.Name, cfg.Version)
+	}
+
+	var plugin *Plugin
+	var err error
+	if cfg.URL != "" {
+		fetchCtx, cancel := context.WithTimeout(ctx, fetchTimeout)
+		defer cancel()
+		plugin, err = FetchPlugin(fetchCtx, http.DefaultClient, cfg.URL, cfg.SHA256)
+	} else {
+		plugin, err = LoadPlugin(cfg.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := plugin.CheckVersion(cfg.Version); err != nil {
+		return nil, err
+	}
+
+	return plugin, nil
 }
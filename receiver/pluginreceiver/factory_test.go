@@ -18,10 +18,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/zap/zapcore"
 )
 
 func TestCreateReceiver(t *testing.T) {
@@ -69,6 +71,40 @@ func TestCreateReceiver(t *testing.T) {
 			},
 			expectedErr: errors.New("failed to render plugin"),
 		},
+		{
+			name: "plugin version not satisfied",
+			cfg: &Config{
+				Path:    "./testdata/plugin-valid.yaml",
+				Version: ">= 1.0.0",
+				Parameters: map[string]any{
+					"env": "prod",
+				},
+			},
+			expectedErr: errors.New("plugin version 0.0.0 does not satisfy >= 1.0.0"),
+		},
+		{
+			name: "plugin by name",
+			cfg: &Config{
+				Name:        "test-plugin",
+				Version:     "~> 1.0",
+				SearchPaths: []string{"./testdata/catalog/local"},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "plugin by name from embedded catalog",
+			cfg: &Config{
+				Name: "embedded-plugin",
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "missing plugin by name",
+			cfg: &Config{
+				Name: "missing-plugin",
+			},
+			expectedErr: errors.New("plugin missing-plugin not found"),
+		},
 		{
 			name: "valid plugin",
 			cfg: &Config{
@@ -86,7 +122,10 @@ func TestCreateReceiver(t *testing.T) {
 			set := receiver.Settings{}
 			consumer := &MockConsumer{}
 			emitterFactory := createLogEmitterFactory(consumer)
-			receiver, err := createReceiver(tc.cfg, set, emitterFactory)
+			f := &factory{catalog: fstest.MapFS{
+				"embedded-plugin.yaml": &fstest.MapFile{Data: []byte("title: embedded\ntemplate: \"receivers:\"\nversion: 1.0.0\n")},
+			}}
+			receiver, err := f.createReceiver(context.Background(), tc.cfg, set, emitterFactory)
 
 			switch tc.expectedErr {
 			case nil:
@@ -158,4 +197,75 @@ func TestCreateDefaultConfig(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, make(map[string]any), pluginConfig.Parameters)
 	require.Empty(t, pluginConfig.Path)
+	require.Equal(t, zapcore.ErrorLevel, pluginConfig.LogLevel)
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			name:        "no plugin source",
+			cfg:         &Config{},
+			expectedErr: errors.New("one of path, name or url is required"),
+		},
+		{
+			name: "multiple plugin sources",
+			cfg: &Config{
+				Path: "./plugins/plugin.yaml",
+				Name: "plugin",
+			},
+			expectedErr: errors.New("only one of path, name or url can be set"),
+		},
+		{
+			name: "url without sha256",
+			cfg: &Config{
+				URL: "https://example.com/plugin.yaml",
+			},
+			expectedErr: errors.New("sha256 must be a hex encoded SHA-256 digest when url is set"),
+		},
+		{
+			name: "url with short sha256",
+			cfg: &Config{
+				URL:    "https://example.com/plugin.yaml",
+				SHA256: "abcd",
+			},
+			expectedErr: errors.New("sha256 must be a hex encoded SHA-256 digest when url is set"),
+		},
+		{
+			name: "invalid version constraint",
+			cfg: &Config{
+				Name:    "plugin",
+				Version: "latest",
+			},
+			expectedErr: errors.New("invalid version constraint"),
+		},
+		{
+			name: "valid url",
+			cfg: &Config{
+				URL:    "https://example.com/plugin.yaml",
+				SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			},
+		},
+		{
+			name: "valid name",
+			cfg: &Config{
+				Name:    "plugin",
+				Version: ">= 1.0, < 2.0",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			}
+		})
+	}
 }
@@ -0,0 +1,62 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxPluginSize is the maximum size of a plugin fetched from a URL
+const maxPluginSize = 10 * 1024 * 1024
+
+// FetchPlugin downloads a plugin from url and verifies its SHA-256 digest matches digest before parsing it
+func FetchPlugin(ctx context.Context, client *http.Client, url, digest string) (*Plugin, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch plugin: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch plugin: unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPluginSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin: %w", err)
+	}
+
+	if len(data) > maxPluginSize {
+		return nil, fmt.Errorf("plugin exceeds the maximum size of %d bytes", maxPluginSize)
+	}
+
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, digest) {
+		return nil, fmt.Errorf("plugin sha256 %s does not match %s", actual, digest)
+	}
+
+	return parsePlugin(data)
+}
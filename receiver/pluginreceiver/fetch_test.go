@@ -0,0 +1,61 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchPlugin(t *testing.T) {
+	data, err := os.ReadFile("./testdata/plugin-valid.yaml")
+	require.NoError(t, err)
+
+	sum := sha256.Sum256(data)
+	digest := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/plugin.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	t.Run("valid digest", func(t *testing.T) {
+		plugin, err := FetchPlugin(context.Background(), server.Client(), server.URL+"/plugin.yaml", digest)
+		require.NoError(t, err)
+		require.Equal(t, "test-plugin", plugin.Title)
+	})
+
+	t.Run("digest mismatch", func(t *testing.T) {
+		badDigest := hex.EncodeToString(make([]byte, 32))
+		_, err := FetchPlugin(context.Background(), server.Client(), server.URL+"/plugin.yaml", badDigest)
+		require.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := FetchPlugin(context.Background(), server.Client(), server.URL+"/missing.yaml", digest)
+		require.ErrorContains(t, err, "unexpected status 404 Not Found")
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// attributeMeterProvider is a meter provider that adds a fixed set of attributes
+// to every measurement recorded through the meters it creates.
+// Attributes set by the instrumentation take precedence on duplicate keys.
+type attributeMeterProvider struct {
+	metric.MeterProvider
+	attrs metric.MeasurementOption
+}
+
+// newAttributeMeterProvider returns a meter provider that records to mp with attrs added to every measurement
+func newAttributeMeterProvider(mp metric.MeterProvider, attrs ...attribute.KeyValue) metric.MeterProvider {
+	return &attributeMeterProvider{
+		MeterProvider: mp,
+		attrs:         metric.WithAttributeSet(attribute.NewSet(attrs...)),
+	}
+}
+
+// Meter returns a meter that adds the provider's attributes to its measurements
+func (p *attributeMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return &attributeMeter{
+		Meter: p.MeterProvider.Meter(name, opts...),
+		attrs: p.attrs,
+	}
+}
+
+// attributeMeter creates instruments that add attrs to their measurements
+type attributeMeter struct {
+	metric.Meter
+	attrs metric.MeasurementOption
+}
+
+// Int64Counter returns an Int64Counter that adds the meter's attributes
+func (m *attributeMeter) Int64Counter(name string, opts ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	inst, err := m.Meter.Int64Counter(name, opts...)
+	return &attributeInt64Counter{Int64Counter: inst, attrs: m.attrs}, err
+}
+
+// Int64UpDownCounter returns an Int64UpDownCounter that adds the meter's attributes
+func (m *attributeMeter) Int64UpDownCounter(name string, opts ...metric.Int64UpDownCounterOption) (metric.Int64UpDownCounter, error) {
+	inst, err := m.Meter.Int64UpDownCounter(name, opts...)
+	return &attributeInt64UpDownCounter{Int64UpDownCounter: inst, attrs: m.attrs}, err
+}
+
+// Int64Histogram returns an Int64Histogram that adds the meter's attributes
+func (m *attributeMeter) Int64Histogram(name string, opts ...metric.Int64HistogramOption) (metric.Int64Histogram, error) {
+	inst, err := m.Meter.Int64Histogram(name, opts...)
+	return &attributeInt64Histogram{Int64Histogram: inst, attrs: m.attrs}, err
+}
+
+// Int64Gauge returns an Int64Gauge that adds the meter's attributes
+func (m *attributeMeter) Int64Gauge(name string, opts ...metric.Int64GaugeOption) (metric.Int64Gauge, error) {
+	inst, err := m.Meter.Int64Gauge(name, opts...)
+	return &attributeInt64Gauge{Int64Gauge: inst, attrs: m.attrs}, err
+}
+
+// Float64Counter returns a Float64Counter that adds the meter's attributes
+func (m *attributeMeter) Float64Counter(name string, opts ...metric.Float64CounterOption) (metric.Float64Counter, error) {
+	inst, err := m.Meter.Float64Counter(name, opts...)
+	return &attributeFloat64Counter{Float64Counter: inst, attrs: m.attrs}, err
+}
+
+// Float64UpDownCounter returns a Float64UpDownCounter that adds the meter's attributes
+func (m *attributeMeter) Float64UpDownCounter(name string, opts ...metric.Float64UpDownCounterOption) (metric.Float64UpDownCounter, error) {
+	inst, err := m.Meter.Float64UpDownCounter(name, opts...)
+	return &attributeFloat64UpDownCounter{Float64UpDownCounter: inst, attrs: m.attrs}, err
+}
+
+// Float64Histogram returns a Float64Histogram that adds the meter's attributes
+func (m *attributeMeter) Float64Histogram(name string, opts ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	inst, err := m.Meter.Float64Histogram(name, opts...)
+	return &attributeFloat64Histogram{Float64Histogram: inst, attrs: m.attrs}, err
+}
+
+// Float64Gauge returns a Float64Gauge that adds the meter's attributes
+func (m *attributeMeter) Float64Gauge(name string, opts ...metric.Float64GaugeOption) (metric.Float64Gauge, error) {
+	inst, err := m.Meter.Float64Gauge(name, opts...)
+	return &attributeFloat64Gauge{Float64Gauge: inst, attrs: m.attrs}, err
+}
+
+// Int64ObservableCounter returns an Int64ObservableCounter whose callbacks observe with the meter's attributes
+func (m *attributeMeter) Int64ObservableCounter(name string, opts ...metric.Int64ObservableCounterOption) (metric.Int64ObservableCounter, error) {
+	cfg := metric.NewInt64ObservableCounterConfig(opts...)
+	return m.Meter.Int64ObservableCounter(name, int64ObservableOptions[metric.Int64ObservableCounterOption](m.attrs, cfg.Description(), cfg.Unit(), cfg.Callbacks())...)
+}
+
+// Int64ObservableUpDownCounter returns an Int64ObservableUpDownCounter whose callbacks observe with the meter's attributes
+func (m *attributeMeter) Int64ObservableUpDownCounter(name string, opts ...metric.Int64ObservableUpDownCounterOption) (metric.Int64ObservableUpDownCounter, error) {
+	cfg := metric.NewInt64ObservableUpDownCounterConfig(opts...)
+	return m.Meter.Int64ObservableUpDownCounter(name, int64ObservableOptions[metric.Int64ObservableUpDownCounterOption](m.attrs, cfg.Description(), cfg.Unit(), cfg.Callbacks())...)
+}
+
+// Int64ObservableGauge returns an Int64ObservableGauge whose callbacks observe with the meter's attributes
+func (m *attributeMeter) Int64ObservableGauge(name string, opts ...metric.Int64ObservableGaugeOption) (metric.Int64ObservableGauge, error) {
+	cfg := metric.NewInt64ObservableGaugeConfig(opts...)
+	return m.Meter.Int64ObservableGauge(name, int64ObservableOptions[metric.Int64ObservableGaugeOption](m.attrs, cfg.Description(), cfg.Unit(), cfg.Callbacks())...)
+}
+
+// Float64ObservableCounter returns a Float64ObservableCounter whose callbacks observe with the meter's attributes
+func (m *attributeMeter) Float64ObservableCounter(name string, opts ...metric.Float64ObservableCounterOption) (metric.Float64ObservableCounter, error) {
+	cfg := metric.NewFloat64ObservableCounterConfig(opts...)
+	return m.Meter.Float64ObservableCounter(name, float64ObservableOptions[metric.Float64ObservableCounterOption](m.attrs, cfg.Description(), cfg.Unit(), cfg.Callbacks())...)
+}
+
+// Float64ObservableUpDownCounter returns a Float64ObservableUpDownCounter whose callbacks observe with the meter's attributes
+func (m *attributeMeter) Float64ObservableUpDownCounter(name string, opts ...metric.Float64ObservableUpDownCounterOption) (metric.Float64ObservableUpDownCounter, error) {
+	cfg := metric.NewFloat64ObservableUpDownCounterConfig(opts...)
+	return m.Meter.Float64ObservableUpDownCounter(name, float64ObservableOptions[metric.Float64ObservableUpDownCounterOption](m.attrs, cfg.Description(), cfg.Unit(), cfg.Callbacks())...)
+}
+
+// Float64ObservableGauge returns a Float64ObservableGauge whose callbacks observe with the meter's attributes
+func (m *attributeMeter) Float64ObservableGauge(name string, opts ...metric.Float64ObservableGaugeOption) (metric.Float64ObservableGauge, error) {
+	cfg := metric.NewFloat64ObservableGaugeConfig(opts...)
+	return m.Meter.Float64ObservableGauge(name, float64ObservableOptions[metric.Float64ObservableGaugeOption](m.attrs, cfg.Description(), cfg.Unit(), cfg.Callbacks())...)
+}
+
+// RegisterCallback registers f with an observer that adds the meter's attributes
+func (m *attributeMeter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	return m.Meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return f(ctx, &attributeObserver{Observer: o, attrs: m.attrs})
+	}, instruments...)
+}
+
+// int64ObservableOptions returns the options of an int64 observable instrument with callbacks that add attrs
+func int64ObservableOptions[O any](attrs metric.MeasurementOption, description, unit string, callbacks []metric.Int64Callback) []O {
+	opts := []O{any(metric.WithDescription(description)).(O), any(metric.WithUnit(unit)).(O)}
+	for _, callback := range callbacks {
+		callback := callback
+		opts = append(opts, any(metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			return callback(ctx, &attributeInt64Observer{Int64Observer: o, attrs: attrs})
+		})).(O))
+	}
+	return opts
+}
+
+// float64ObservableOptions returns the options of a float64 observable instrument with callbacks that add attrs
+func float64ObservableOptions[O any](attrs metric.MeasurementOption, description, unit string, callbacks []metric.Float64Callback) []O {
+	opts := []O{any(metric.WithDescription(description)).(O), any(metric.WithUnit(unit)).(O)}
+	for _, callback := range callbacks {
+		callback := callback
+		opts = append(opts, any(metric.WithFloat64Callback(func(ctx context.Context, o metric.Float64Observer) error {
+			return callback(ctx, &attributeFloat64Observer{Float64Observer: o, attrs: attrs})
+		})).(O))
+	}
+	return opts
+}
+
+type attributeInt64Counter struct {
+	metric.Int64Counter
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeInt64Counter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	i.Int64Counter.Add(ctx, incr, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeInt64UpDownCounter struct {
+	metric.Int64UpDownCounter
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeInt64UpDownCounter) Add(ctx context.Context, incr int64, opts ...metric.AddOption) {
+	i.Int64UpDownCounter.Add(ctx, incr, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeInt64Histogram struct {
+	metric.Int64Histogram
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeInt64Histogram) Record(ctx context.Context, value int64, opts ...metric.RecordOption) {
+	i.Int64Histogram.Record(ctx, value, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeInt64Gauge struct {
+	metric.Int64Gauge
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeInt64Gauge) Record(ctx context.Context, value int64, opts ...metric.RecordOption) {
+	i.Int64Gauge.Record(ctx, value, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeFloat64Counter struct {
+	metric.Float64Counter
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeFloat64Counter) Add(ctx context.Context, incr float64, opts ...metric.AddOption) {
+	i.Float64Counter.Add(ctx, incr, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeFloat64UpDownCounter struct {
+	metric.Float64UpDownCounter
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeFloat64UpDownCounter) Add(ctx context.Context, incr float64, opts ...metric.AddOption) {
+	i.Float64UpDownCounter.Add(ctx, incr, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeFloat64Histogram struct {
+	metric.Float64Histogram
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeFloat64Histogram) Record(ctx context.Context, value float64, opts ...metric.RecordOption) {
+	i.Float64Histogram.Record(ctx, value, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeFloat64Gauge struct {
+	metric.Float64Gauge
+	attrs metric.MeasurementOption
+}
+
+func (i *attributeFloat64Gauge) Record(ctx context.Context, value float64, opts ...metric.RecordOption) {
+	i.Float64Gauge.Record(ctx, value, prependAttributes(i.attrs, opts)...)
+}
+
+type attributeObserver struct {
+	metric.Observer
+	attrs metric.MeasurementOption
+}
+
+func (o *attributeObserver) ObserveInt64(obsrv metric.Int64Observable, value int64, opts ...metric.ObserveOption) {
+	o.Observer.ObserveInt64(obsrv, value, prependAttributes(o.attrs, opts)...)
+}
+
+func (o *attributeObserver) ObserveFloat64(obsrv metric.Float64Observable, value float64, opts ...metric.ObserveOption) {
+	o.Observer.ObserveFloat64(obsrv, value, prependAttributes(o.attrs, opts)...)
+}
+
+type attributeInt64Observer struct {
+	metric.Int64Observer
+	attrs metric.MeasurementOption
+}
+
+func (o *attributeInt64Observer) Observe(value int64, opts ...metric.ObserveOption) {
+	o.Int64Observer.Observe(value, prependAttributes(o.attrs, opts)...)
+}
+
+type attributeFloat64Observer struct {
+	metric.Float64Observer
+	attrs metric.MeasurementOption
+}
+
+func (o *attributeFloat64Observer) Observe(value float64, opts ...metric.ObserveOption) {
+	o.Float64Observer.Observe(value, prependAttributes(o.attrs, opts)...)
+}
+
+// prependAttributes returns opts with attrs first, so attributes in opts win on duplicate keys
+func prependAttributes[T any](attrs metric.MeasurementOption, opts []T) []T {
+	out := make([]T, 0, len(opts)+1)
+	out = append(out, any(attrs).(T))
+	return append(out, opts...)
+}
@@ -0,0 +1,88 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestAttributeMeterProvider(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := newAttributeMeterProvider(
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		attribute.String("plugin_id", "plugin/mysql"),
+	)
+	meter := mp.Meter("test")
+	ctx := context.Background()
+	exporterAttr := metric.WithAttributes(attribute.String("exporter", "emitter"))
+
+	counter, err := meter.Int64Counter("otelcol_exporter_sent_log_records")
+	require.NoError(t, err)
+	counter.Add(ctx, 3, exporterAttr)
+
+	histogram, err := meter.Float64Histogram("otelcol_processor_batch_send_size")
+	require.NoError(t, err)
+	histogram.Record(ctx, 2.5)
+
+	_, err = meter.Int64ObservableGauge("otelcol_exporter_queue_size",
+		metric.WithDescription("Current size of the retry queue"),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(7, exporterAttr)
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	capacity, err := meter.Int64ObservableGauge("otelcol_exporter_queue_capacity")
+	require.NoError(t, err)
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		o.ObserveInt64(capacity, 100, metric.WithAttributes(attribute.String("plugin_id", "overridden")))
+		return nil
+	}, capacity)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, registration.Unregister()) }()
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	attrs := map[string]attribute.Set{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			require.Equal(t, int64(3), data.DataPoints[0].Value)
+			attrs[m.Name] = data.DataPoints[0].Attributes
+		case metricdata.Histogram[float64]:
+			require.Equal(t, uint64(1), data.DataPoints[0].Count)
+			attrs[m.Name] = data.DataPoints[0].Attributes
+		case metricdata.Gauge[int64]:
+			attrs[m.Name] = data.DataPoints[0].Attributes
+		}
+	}
+
+	require.Equal(t, map[string]attribute.Set{
+		"otelcol_exporter_sent_log_records": attribute.NewSet(attribute.String("plugin_id", "plugin/mysql"), attribute.String("exporter", "emitter")),
+		"otelcol_processor_batch_send_size": attribute.NewSet(attribute.String("plugin_id", "plugin/mysql")),
+		"otelcol_exporter_queue_size":       attribute.NewSet(attribute.String("plugin_id", "plugin/mysql"), attribute.String("exporter", "emitter")),
+		"otelcol_exporter_queue_capacity":   attribute.NewSet(attribute.String("plugin_id", "overridden")),
+	}, attrs)
+}
@@ -19,9 +19,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"strings"
 	"text/template"
+	"time"
 
+	"github.com/hashicorp/go-version"
 	"github.com/mitchellh/mapstructure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
 	"go.opentelemetry.io/collector/component"
@@ -45,14 +49,43 @@ func LoadPlugin(path string) (*Plugin, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return parsePlugin(bytes)
+}
+
+// parsePlugin parses a plugin from yaml
+func parsePlugin(data []byte) (*Plugin, error) {
 	var plugin Plugin
-	if err := yaml.Unmarshal(bytes, &plugin); err != nil {
+	if err := yaml.Unmarshal(data, &plugin); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal plugin from yaml: %w", err)
 	}
 
 	return &plugin, nil
 }
 
+// CheckVersion checks the plugin's version satisfies the version constraint.
+// An empty constraint is satisfied by any version.
+func (p *Plugin) CheckVersion(constraint string) error {
+	if constraint == "" {
+		return nil
+	}
+
+	constraints, err := version.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+
+	v, err := version.NewVersion(p.Version)
+	if err != nil {
+		return fmt.Errorf("invalid plugin version %q: %w", p.Version, err)
+	}
+
+	if !constraints.Check(v) {
+		return fmt.Errorf("plugin version %s does not satisfy %s", p.Version, constraint)
+	}
+
+	return nil
+}
+
 // Render renders the plugin's template as a config
 func (p *Plugin) Render(values map[string]any, pluginID component.ID) (*RenderedConfig, error) {
 	template, err := template.New(p.Title).Parse(p.Template)
@@ -160,6 +193,10 @@ func (p *Plugin) CheckParameters(values map[string]any) error {
 		return fmt.Errorf("supported value failure: %w", err)
 	}
 
+	if err := p.checkConstraints(values); err != nil {
+		return fmt.Errorf("constraint failure: %w", err)
+	}
+
 	return nil
 }
 
@@ -179,13 +216,23 @@ func (p *Plugin) checkDefined(values map[string]any) error {
 	return nil
 }
 
-// checkRequired checks if required values are defined
+// checkRequired checks if required values are defined.
+// Conditional requirements are evaluated against the values with defaults applied.
 func (p *Plugin) checkRequired(values map[string]any) error {
+	valuesWithDefaults := p.ApplyDefaults(values)
+
 	for _, parameter := range p.Parameters {
-		_, ok := values[parameter.Name]
-		if parameter.Required && !ok {
+		if _, ok := values[parameter.Name]; ok {
+			continue
+		}
+
+		if parameter.Required {
 			return fmt.Errorf("parameter %s is missing but required in plugin", parameter.Name)
 		}
+
+		if parameter.RequiredWhen != nil && parameter.RequiredWhen.matches(valuesWithDefaults) {
+			return fmt.Errorf("parameter %s is missing but required when %s", parameter.Name, parameter.RequiredWhen)
+		}
 	}
 
 	return nil
@@ -219,19 +266,40 @@ func (p *Plugin) checkType(values map[string]any) error {
 			if _, ok := value.(int); !ok {
 				return fmt.Errorf("parameter %s must be an int", parameter.Name)
 			}
+		case floatType:
+			if _, ok := toFloat(value); !ok {
+				return fmt.Errorf("parameter %s must be a float", parameter.Name)
+			}
 		case boolType:
 			if _, ok := value.(bool); !ok {
 				return fmt.Errorf("parameter %s must be a bool", parameter.Name)
 			}
+		case durationType:
+			raw, ok := value.(string)
+			if !ok {
+				return fmt.Errorf("parameter %s must be a duration string", parameter.Name)
+			}
+			if _, err := time.ParseDuration(raw); err != nil {
+				return fmt.Errorf("parameter %s must be a valid duration: %w", parameter.Name, err)
+			}
+		case mapType:
+			switch value.(type) {
+			case map[string]any, map[any]any:
+			default:
+				return fmt.Errorf("parameter %s must be a map", parameter.Name)
+			}
+		case enumType:
+			if len(parameter.Supported) == 0 {
+				return fmt.Errorf("parameter %s is an enum without supported values", parameter.Name)
+			}
 		case timezoneType:
 			raw, ok := value.(string)
 			if !ok {
 				return fmt.Errorf("parameter %s must be a string", parameter.Name)
 			}
-			if _, ok := tzlist[raw]; ok {
-				return nil
+			if _, ok := tzlist[raw]; !ok {
+				return fmt.Errorf("parameter %s must be a valid timezone", parameter.Name)
 			}
-			return fmt.Errorf("parameter %s must be a valid timezone", parameter.Name)
 		default:
 			return fmt.Errorf("unsupported parameter type: %s", parameter.Type)
 		}
@@ -265,14 +333,168 @@ OUTER:
 	return nil
 }
 
+// checkConstraints checks the values against the parameter's range, pattern and path constraints
+func (p *Plugin) checkConstraints(values map[string]any) error {
+	for _, parameter := range p.Parameters {
+		value, ok := values[parameter.Name]
+		if !ok {
+			continue
+		}
+
+		if err := parameter.checkRange(value); err != nil {
+			return err
+		}
+
+		if err := parameter.checkPattern(value); err != nil {
+			return err
+		}
+
+		if err := parameter.checkPathExists(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Parameter is the parameter of plugin
 type Parameter struct {
-	Name        string        `yaml:"name,omitempty"`
-	Type        ParameterType `yaml:"type,omitempty"`
-	Default     any           `yaml:"default,omitempty"`
-	Supported   []any         `yaml:"supported,omitempty"`
-	Description *string       `yaml:"description,omitempty"`
-	Required    bool          `yaml:"required,omitempty"`
+	Name         string        `yaml:"name,omitempty"`
+	Type         ParameterType `yaml:"type,omitempty"`
+	Default      any           `yaml:"default,omitempty"`
+	Supported    []any         `yaml:"supported,omitempty"`
+	Description  *string       `yaml:"description,omitempty"`
+	Required     bool          `yaml:"required,omitempty"`
+	RequiredWhen *Condition    `yaml:"required_when,omitempty"`
+	Min          *float64      `yaml:"min,omitempty"`
+	Max          *float64      `yaml:"max,omitempty"`
+	Pattern      string        `yaml:"pattern,omitempty"`
+	PathExists   bool          `yaml:"path_exists,omitempty"`
+}
+
+// checkRange checks a numeric value is within the parameter's min and max
+func (p Parameter) checkRange(value any) error {
+	if p.Min == nil && p.Max == nil {
+		return nil
+	}
+
+	number, ok := toFloat(value)
+	if !ok {
+		return fmt.Errorf("parameter %s must be a number to check its range", p.Name)
+	}
+
+	if p.Min != nil && number < *p.Min {
+		return fmt.Errorf("parameter %s must be at least %v", p.Name, *p.Min)
+	}
+
+	if p.Max != nil && number > *p.Max {
+		return fmt.Errorf("parameter %s must be at most %v", p.Name, *p.Max)
+	}
+
+	return nil
+}
+
+// checkPattern checks a string or each string in a []string matches the parameter's pattern
+func (p Parameter) checkPattern(value any) error {
+	if p.Pattern == "" {
+		return nil
+	}
+
+	pattern, err := regexp.Compile(p.Pattern)
+	if err != nil {
+		return fmt.Errorf("parameter %s has an invalid pattern: %w", p.Name, err)
+	}
+
+	for _, s := range toStrings(value) {
+		if !pattern.MatchString(s) {
+			return fmt.Errorf("parameter %s value %q does not match pattern %s", p.Name, s, p.Pattern)
+		}
+	}
+
+	return nil
+}
+
+// checkPathExists checks a string or each string in a []string is a path that exists.
+// Paths containing glob patterns must match at least one path.
+func (p Parameter) checkPathExists(value any) error {
+	if !p.PathExists {
+		return nil
+	}
+
+	for _, s := range toStrings(value) {
+		matches, err := filepath.Glob(s)
+		if err != nil {
+			return fmt.Errorf("parameter %s path %s is invalid: %w", p.Name, s, err)
+		}
+
+		if len(matches) == 0 {
+			return fmt.Errorf("parameter %s path %s does not exist", p.Name, s)
+		}
+	}
+
+	return nil
+}
+
+// Condition is a condition on the value of another parameter
+type Condition struct {
+	// Parameter is the name of the parameter the condition checks
+	Parameter string `yaml:"parameter,omitempty"`
+
+	// Value is the value the parameter must have for the condition to match. Defaults to true.
+	Value any `yaml:"value,omitempty"`
+}
+
+// matches returns true if the condition's parameter has the condition's value
+func (c *Condition) matches(values map[string]any) bool {
+	value, ok := values[c.Parameter]
+	if !ok {
+		return false
+	}
+
+	return reflect.DeepEqual(value, c.expected())
+}
+
+// expected returns the value the parameter must have for the condition to match
+func (c *Condition) expected() any {
+	if c.Value == nil {
+		return true
+	}
+	return c.Value
+}
+
+// String returns a description of the condition
+func (c *Condition) String() string {
+	return fmt.Sprintf("%s is %v", c.Parameter, c.expected())
+}
+
+// toFloat converts an int or float value to a float64
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// toStrings returns the string values of a string or []string value
+func toStrings(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
 }
 
 // ParameterType is the type of a parameter
@@ -283,5 +505,9 @@ const (
 	stringArrayType ParameterType = "[]string"
 	boolType        ParameterType = "bool"
 	intType         ParameterType = "int"
+	floatType       ParameterType = "float"
+	durationType    ParameterType = "duration"
+	mapType         ParameterType = "map"
+	enumType        ParameterType = "enum"
 	timezoneType    ParameterType = "timezone"
 )
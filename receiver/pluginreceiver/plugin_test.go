@@ -438,6 +438,256 @@ func TestCheckParameters(t *testing.T) {
 			},
 			expectedErr: errors.New("must be a valid timezone"),
 		},
+		{
+			name: "invalid float type",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name: "param1",
+						Type: floatType,
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": "1.5",
+			},
+			expectedErr: errors.New("parameter param1 must be a float"),
+		},
+		{
+			name: "invalid duration type",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name: "param1",
+						Type: durationType,
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": "5 minutes",
+			},
+			expectedErr: errors.New("parameter param1 must be a valid duration"),
+		},
+		{
+			name: "invalid map type",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name: "param1",
+						Type: mapType,
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": []any{"value1"},
+			},
+			expectedErr: errors.New("parameter param1 must be a map"),
+		},
+		{
+			name: "enum without supported values",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name: "param1",
+						Type: enumType,
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": "value1",
+			},
+			expectedErr: errors.New("parameter param1 is an enum without supported values"),
+		},
+		{
+			name: "unsupported enum value",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name:      "param1",
+						Type:      enumType,
+						Supported: []any{"tcp", "udp"},
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": "http",
+			},
+			expectedErr: errors.New("supported value failure"),
+		},
+		{
+			name: "below min",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name: "param1",
+						Type: intType,
+						Min:  ptr(1.0),
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": 0,
+			},
+			expectedErr: errors.New("parameter param1 must be at least 1"),
+		},
+		{
+			name: "above max",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name: "param1",
+						Type: floatType,
+						Max:  ptr(1.0),
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": 1.5,
+			},
+			expectedErr: errors.New("parameter param1 must be at most 1"),
+		},
+		{
+			name: "pattern mismatch",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name:    "param1",
+						Type:    stringArrayType,
+						Pattern: "^[a-z]+$",
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": []any{"valid", "Invalid"},
+			},
+			expectedErr: errors.New(`parameter param1 value "Invalid" does not match pattern ^[a-z]+$`),
+		},
+		{
+			name: "invalid pattern",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name:    "param1",
+						Type:    stringType,
+						Pattern: "[",
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": "value1",
+			},
+			expectedErr: errors.New("parameter param1 has an invalid pattern"),
+		},
+		{
+			name: "path does not exist",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name:       "param1",
+						Type:       stringType,
+						PathExists: true,
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": "./testdata/missing.yaml",
+			},
+			expectedErr: errors.New("parameter param1 path ./testdata/missing.yaml does not exist"),
+		},
+		{
+			name: "missing conditionally required parameter",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name:    "enable_tls",
+						Type:    boolType,
+						Default: false,
+					},
+					{
+						Name:         "cert_file",
+						Type:         stringType,
+						RequiredWhen: &Condition{Parameter: "enable_tls"},
+					},
+				},
+			},
+			values: map[string]any{
+				"enable_tls": true,
+			},
+			expectedErr: errors.New("parameter cert_file is missing but required when enable_tls is true"),
+		},
+		{
+			name: "missing conditionally required parameter from default",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name:    "protocol",
+						Type:    enumType,
+						Default: "tcp",
+						Supported: []any{
+							"tcp",
+							"udp",
+						},
+					},
+					{
+						Name:         "listen_address",
+						Type:         stringType,
+						RequiredWhen: &Condition{Parameter: "protocol", Value: "tcp"},
+					},
+				},
+			},
+			values:      map[string]any{},
+			expectedErr: errors.New("parameter listen_address is missing but required when protocol is tcp"),
+		},
+		{
+			name: "valid extended parameters",
+			plugin: &Plugin{
+				Parameters: []Parameter{
+					{
+						Name: "param1",
+						Type: floatType,
+						Min:  ptr(0.0),
+						Max:  ptr(1.0),
+					},
+					{
+						Name: "param2",
+						Type: durationType,
+					},
+					{
+						Name: "param3",
+						Type: mapType,
+					},
+					{
+						Name:      "param4",
+						Type:      enumType,
+						Supported: []any{"tcp", "udp"},
+					},
+					{
+						Name:       "param5",
+						Type:       stringArrayType,
+						Pattern:    `\.yaml$`,
+						PathExists: true,
+					},
+					{
+						Name:    "enable_tls",
+						Type:    boolType,
+						Default: false,
+					},
+					{
+						Name:         "cert_file",
+						Type:         stringType,
+						RequiredWhen: &Condition{Parameter: "enable_tls"},
+					},
+				},
+			},
+			values: map[string]any{
+				"param1": 1,
+				"param2": "30s",
+				"param3": map[string]any{"key": "value"},
+				"param4": "udp",
+				"param5": []any{"./testdata/plugin-valid.yaml", "./testdata/plugin-*.yaml"},
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "valid parameters",
 			plugin: &Plugin{
@@ -531,3 +781,8 @@ func Test_checkExtensions(t *testing.T) {
 		})
 	}
 }
+
+// ptr returns a pointer to the value
+func ptr[T any](v T) *T {
+	return &v
+}
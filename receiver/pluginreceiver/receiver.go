@@ -16,13 +16,18 @@ package pluginreceiver
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/receiver"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Receiver is a receiver that runs an embedded open telemetry config
@@ -31,28 +36,40 @@ type Receiver struct {
 	plugin         *Plugin
 	renderedCfg    *RenderedConfig
 	emitterFactory exporter.Factory
+	set            receiver.Settings
 	logger         *zap.Logger
+	logLevel       zapcore.Level
 	createService  createServiceFunc
 	service        Service
 
+	// host is the host the receiver was started with. Status of the internal service is reported to it.
+	host component.Host
+
+	// shuttingDown is set once Shutdown is called, so the service stopping is not reported as a failure
+	shuttingDown atomic.Bool
+
 	// serviceErrChan gets the error from the service once it stops running.
 	// If there is no error, `nil` is placed on the channel.
 	// It will be closed after the collector service shuts down.
 	serviceErrChan chan error
 }
 
-// NewReceiver creates a new plugin receiver
+// NewReceiver creates a new plugin receiver.
+// The internal service logs to the receiver's logger at logLevel.
 func NewReceiver(
 	plugin *Plugin,
 	renderedConfig *RenderedConfig,
 	emitterFactory exporter.Factory,
-	logger *zap.Logger,
+	set receiver.Settings,
+	logLevel zapcore.Level,
 ) *Receiver {
 	return &Receiver{
 		plugin:         plugin,
 		renderedCfg:    renderedConfig,
 		emitterFactory: emitterFactory,
-		logger:         logger,
+		set:            set,
+		logger:         set.Logger,
+		logLevel:       logLevel,
 		createService:  createService,
 		serviceErrChan: make(chan error, 1),
 	}
@@ -67,12 +84,17 @@ func (r *Receiver) Start(ctx context.Context, host component.Host) error {
 		return fmt.Errorf("failed to get factories from factory provider: %w", err)
 	}
 
-	cfgProviderSettings, err := r.renderedCfg.GetConfigProviderSettings()
+	r.host = host
+	factories.Extensions[statusWatcherType] = createStatusWatcherFactory(r.reportStatus)
+
+	cfgProviderSettings, err := r.renderedCfg.withExtension(component.NewID(statusWatcherType)).GetConfigProviderSettings()
 	if err != nil {
 		return fmt.Errorf("failed to get config provider: %w", err)
 	}
 
-	service, err := r.createService(*factories, *cfgProviderSettings, r.logger)
+	NewTelemetry(r.set.TelemetrySettings, r.set.ID).InstrumentFactories(factories)
+
+	service, err := r.createService(*factories, *cfgProviderSettings, r.logger, r.logLevel)
 	if err != nil {
 		return fmt.Errorf("failed to create internal service: %w", err)
 	}
@@ -87,6 +109,8 @@ func (r *Receiver) Start(ctx context.Context, host component.Host) error {
 
 // Shutdown stops the receiver's internal service
 func (r *Receiver) Shutdown(ctx context.Context) error {
+	r.shuttingDown.Store(true)
+
 	if r.service != nil {
 		r.service.Shutdown()
 
@@ -106,8 +130,20 @@ func (r *Receiver) Shutdown(ctx context.Context) error {
 
 // startService starts the provided service
 func (r *Receiver) startService(ctx context.Context, svc Service) error {
+	started := make(chan struct{})
 	go func() {
-		r.serviceErrChan <- svc.Run(ctx)
+		err := svc.Run(ctx)
+
+		// Once started, the service should only stop when the receiver is shut down
+		select {
+		case <-started:
+			if !r.shuttingDown.Load() {
+				r.reportServiceStopped(err)
+			}
+		default:
+		}
+
+		r.serviceErrChan <- err
 		close(r.serviceErrChan)
 	}()
 
@@ -122,8 +158,29 @@ func (r *Receiver) startService(ctx context.Context, svc Service) error {
 			return err
 		case <-ticker.C:
 			if svc.GetState() == otelcol.StateRunning {
+				close(started)
 				return nil
 			}
 		}
 	}
 }
+
+// reportServiceStopped reports the internal service stopping before the receiver was shut down
+func (r *Receiver) reportServiceStopped(err error) {
+	if err == nil {
+		err = errors.New("internal service stopped unexpectedly")
+	} else {
+		err = fmt.Errorf("internal service stopped unexpectedly: %w", err)
+	}
+
+	r.logger.Error("Plugin stopped running.", zap.String("plugin", r.plugin.Title), zap.Error(err))
+	r.reportStatus(componentstatus.NewPermanentErrorEvent(err))
+}
+
+// reportStatus reports the status of the plugin receiver to the host
+func (r *Receiver) reportStatus(event *componentstatus.Event) {
+	if r.host == nil {
+		return
+	}
+	componentstatus.ReportStatus(r.host, event)
+}
@@ -23,9 +23,20 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v2"
 )
 
 func TestReceiverGetFactoryFailure(t *testing.T) {
@@ -68,8 +79,8 @@ func TestReceiverCreateServiceFailure(t *testing.T) {
 
 	emitterFactory := createLogEmitterFactory(nil)
 
-	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, zap.NewNop())
-	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger) (Service, error) {
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
 		return nil, errors.New("failure")
 	}
 
@@ -96,8 +107,8 @@ func TestReceiverStartServiceFailure(t *testing.T) {
 	svc := &MockService{}
 	svc.On("Run", mock.Anything).Return(errors.New("failure"))
 	svc.On("GetState").Return(otelcol.StateStarting)
-	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, zap.NewNop())
-	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger) (Service, error) {
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
 		return svc, nil
 	}
 
@@ -126,8 +137,8 @@ func TestReceiverStartServiceContext(t *testing.T) {
 	svc := &MockService{}
 	svc.On("Run", mock.Anything).Return(nil)
 	svc.On("GetState").Return(otelcol.StateStarting)
-	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, zap.NewNop())
-	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger) (Service, error) {
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
 		return svc, nil
 	}
 
@@ -155,8 +166,8 @@ func TestReceiverStartSuccess(t *testing.T) {
 	svc.On("Run", mock.Anything).WaitUntil(time.After(time.Second)).Return(errors.New("unexpected timeout"))
 	svc.On("GetState").Return(otelcol.StateRunning)
 
-	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, zap.NewNop())
-	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger) (Service, error) {
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
 		return svc, nil
 	}
 
@@ -190,8 +201,8 @@ func TestReceiverShutdown(t *testing.T) {
 		close(blockChan)
 	}).Return()
 
-	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, zap.NewNop())
-	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger) (Service, error) {
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
 		return svc, nil
 	}
 
@@ -229,8 +240,8 @@ func TestReceiverShutdownCancelledContext(t *testing.T) {
 	svc.On("GetState").Return(otelcol.StateRunning)
 	svc.On("Shutdown").Return()
 
-	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, zap.NewNop())
-	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger) (Service, error) {
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
 		return svc, nil
 	}
 
@@ -271,8 +282,8 @@ func TestReceiverShutdownWithError(t *testing.T) {
 		close(blockChan)
 	}).Return()
 
-	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, zap.NewNop())
-	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger) (Service, error) {
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
 		return svc, nil
 	}
 
@@ -283,6 +294,109 @@ func TestReceiverShutdownWithError(t *testing.T) {
 	require.ErrorContains(t, err, "an error occurred")
 }
 
+func TestReceiverStartConfiguresService(t *testing.T) {
+	nopType := component.MustNewType("nop")
+	nopFactory := receiver.NewFactory(nopType, nil)
+	ctx := context.Background()
+	host := &MockHost{}
+	host.On("GetFactory", mock.Anything, mock.Anything).Return(nopFactory)
+
+	renderedCfg := &RenderedConfig{
+		Receivers: map[string]any{
+			"nop": nil,
+		},
+	}
+
+	emitterFactory := createLogEmitterFactory(nil)
+
+	svc := &MockService{}
+	svc.On("Run", mock.Anything).WaitUntil(time.After(time.Second)).Return(nil)
+	svc.On("GetState").Return(otelcol.StateRunning)
+
+	var factories otelcol.Factories
+	var cfgProviderSettings otelcol.ConfigProviderSettings
+	var logLevel zapcore.Level
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.DebugLevel)
+	receiver.createService = func(f otelcol.Factories, s otelcol.ConfigProviderSettings, _ *zap.Logger, l zapcore.Level) (Service, error) {
+		factories, cfgProviderSettings, logLevel = f, s, l
+		return svc, nil
+	}
+
+	err := receiver.Start(ctx, host)
+	require.NoError(t, err)
+
+	require.Equal(t, zapcore.DebugLevel, logLevel)
+	require.Contains(t, factories.Extensions, statusWatcherType)
+	require.Contains(t, factories.Receivers, nopType)
+
+	// The status watcher is enabled in the internal service without modifying the rendered config
+	var serviceCfg RenderedConfig
+	uri := cfgProviderSettings.ResolverSettings.URIs[0]
+	require.NoError(t, yaml.Unmarshal([]byte(uri[len("yaml:"):]), &serviceCfg))
+	require.Equal(t, []string{"plugin_status"}, serviceCfg.Service.Extensions)
+	require.Contains(t, serviceCfg.Extensions, "plugin_status")
+	require.Empty(t, renderedCfg.Extensions)
+}
+
+func TestReceiverReportsServiceStopped(t *testing.T) {
+	nopType := component.MustNewType("nop")
+	nopFactory := receiver.NewFactory(nopType, nil)
+	ctx := context.Background()
+	host := &statusHost{MockHost: &MockHost{}, events: make(chan *componentstatus.Event, 1)}
+	host.On("GetFactory", mock.Anything, mock.Anything).Return(nopFactory)
+
+	renderedCfg := &RenderedConfig{
+		Receivers: map[string]any{
+			"nop": nil,
+		},
+	}
+
+	emitterFactory := createLogEmitterFactory(nil)
+
+	stopChan := make(chan struct{})
+
+	svc := &MockService{}
+	svc.On("Run", mock.Anything).Run(func(_ mock.Arguments) {
+		<-stopChan
+	}).Return(errors.New("pipeline failed"))
+	svc.On("GetState").Return(otelcol.StateRunning)
+	svc.On("Shutdown").Return()
+
+	receiver := NewReceiver(&Plugin{}, renderedCfg, emitterFactory, receivertest.NewNopSettings(), zapcore.ErrorLevel)
+	receiver.createService = func(_ otelcol.Factories, _ otelcol.ConfigProviderSettings, _ *zap.Logger, _ zapcore.Level) (Service, error) {
+		return svc, nil
+	}
+
+	err := receiver.Start(ctx, host)
+	require.NoError(t, err)
+
+	// The service stops on its own while the receiver is running
+	close(stopChan)
+
+	select {
+	case event := <-host.events:
+		require.Equal(t, componentstatus.StatusPermanentError, event.Status())
+		require.EqualError(t, event.Err(), "internal service stopped unexpectedly: pipeline failed")
+	case <-time.After(time.Second):
+		t.Fatal("expected status to be reported")
+	}
+
+	err = receiver.Shutdown(context.Background())
+	require.ErrorContains(t, err, "pipeline failed")
+	require.Empty(t, host.events)
+}
+
+// statusHost is a MockHost that records the status reported to it
+type statusHost struct {
+	*MockHost
+	events chan *componentstatus.Event
+}
+
+// Report records the reported status event
+func (h *statusHost) Report(event *componentstatus.Event) {
+	h.events <- event
+}
+
 // MockService is a mock type for the Service type
 type MockService struct {
 	mock.Mock
@@ -320,3 +434,70 @@ func (_m *MockService) Run(ctx context.Context) error {
 func (_m *MockService) Shutdown() {
 	_m.Called()
 }
+
+// testLogsConfig is the config of the receiver used in a rendered plugin
+type testLogsConfig struct {
+	Body string `mapstructure:"body"`
+}
+
+// testLogsReceiver emits a single log record when started
+type testLogsReceiver struct {
+	cfg  *testLogsConfig
+	next consumer.Logs
+}
+
+func (r *testLogsReceiver) Start(ctx context.Context, _ component.Host) error {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(r.cfg.Body)
+	return r.next.ConsumeLogs(ctx, logs)
+}
+
+func (r *testLogsReceiver) Shutdown(context.Context) error {
+	return nil
+}
+
+func TestReceiverRunsRenderedPlugin(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := receivertest.NewNopSettings()
+	set.ID = component.MustNewIDWithName("plugin", "logs")
+	set.TelemetrySettings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.TelemetrySettings.MetricsLevel = configtelemetry.LevelNormal
+
+	testLogsType := component.MustNewType("testlogs")
+	testLogsFactory := receiver.NewFactory(testLogsType,
+		func() component.Config { return &testLogsConfig{} },
+		receiver.WithLogs(func(ctx context.Context, s receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+			counter, err := s.MeterProvider.Meter("test").Int64Counter("otelcol_test_created")
+			if err != nil {
+				return nil, err
+			}
+			counter.Add(ctx, 1)
+			return &testLogsReceiver{cfg: cfg.(*testLogsConfig), next: next}, nil
+		}, component.StabilityLevelDevelopment),
+	)
+	host := &MockHost{}
+	host.On("GetFactory", component.KindReceiver, testLogsType).Return(testLogsFactory)
+
+	plugin, err := LoadPlugin("./testdata/plugin-logs.yaml")
+	require.NoError(t, err)
+	renderedCfg, err := plugin.Render(map[string]any{"body": "from plugin"}, set.ID)
+	require.NoError(t, err)
+
+	sink := &consumertest.LogsSink{}
+	r := NewReceiver(plugin, renderedCfg, createLogEmitterFactory(sink), set, zapcore.ErrorLevel)
+	require.NoError(t, r.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	body := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str()
+	require.Equal(t, "from plugin", body)
+
+	// The sub-pipeline's components record their metrics on the plugin receiver's meter provider
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	pluginID, _ := sum.DataPoints[0].Attributes.Value(attribute.Key("plugin_id"))
+	require.Equal(t, "plugin/logs", pluginID.AsString())
+}
@@ -86,6 +86,18 @@ func NewRenderedConfig(yamlBytes []byte) (*RenderedConfig, error) {
 	return &renderedCfg, nil
 }
 
+// withExtension returns a copy of the rendered config with an extension that has a default config enabled
+func (r *RenderedConfig) withExtension(id component.ID) *RenderedConfig {
+	cfg := *r
+	cfg.Extensions = make(map[string]any, len(r.Extensions)+1)
+	for key, value := range r.Extensions {
+		cfg.Extensions[key] = value
+	}
+	cfg.Extensions[id.String()] = nil
+	cfg.Service.Extensions = append(append([]string{}, r.Service.Extensions...), id.String())
+	return &cfg
+}
+
 // GetConfigProviderSettings returns config provider settings for the rendered config
 func (r *RenderedConfig) GetConfigProviderSettings() (*otelcol.ConfigProviderSettings, error) {
 	bytes, err := yaml.Marshal(r)
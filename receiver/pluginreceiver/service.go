@@ -30,25 +30,30 @@ type Service interface {
 }
 
 // createService creates a default Service for running an open telemetry pipeline
-func createService(factories otelcol.Factories, configProviderSettings otelcol.ConfigProviderSettings, logger *zap.Logger) (Service, error) {
+func createService(factories otelcol.Factories, configProviderSettings otelcol.ConfigProviderSettings, logger *zap.Logger, logLevel zapcore.Level) (Service, error) {
 	settings := otelcol.CollectorSettings{
 		Factories:               func() (otelcol.Factories, error) { return factories, nil },
 		DisableGracefulShutdown: true,
 		ConfigProviderSettings:  configProviderSettings,
-		LoggingOptions:          createServiceLoggerOpts(logger),
+		LoggingOptions:          createServiceLoggerOpts(logger, logLevel),
 	}
 
 	return otelcol.NewCollector(settings)
 }
 
-// createServiceLoggerOpts creates the default logger opts for a Service
-func createServiceLoggerOpts(baseLogger *zap.Logger) []zap.Option {
-	levelOpt := zap.IncreaseLevel(zap.ErrorLevel)
+// createServiceLoggerOpts creates the logger opts for a Service that logs to the base logger at the given level.
+// The level can only be raised above the level of the base logger.
+func createServiceLoggerOpts(baseLogger *zap.Logger, logLevel zapcore.Level) []zap.Option {
 	coreOpt := zap.WrapCore(func(zapcore.Core) zapcore.Core {
-		return baseLogger.Core()
+		core, err := zapcore.NewIncreaseLevelCore(baseLogger.Core(), logLevel)
+		if err != nil {
+			// The base logger already filters out the level
+			return baseLogger.Core()
+		}
+		return core
 	})
-	return []zap.Option{coreOpt, levelOpt}
+	return []zap.Option{coreOpt}
 }
 
 // createServiceFunc is a function used to create a service
-type createServiceFunc func(factories otelcol.Factories, configProviderSettings otelcol.ConfigProviderSettings, logger *zap.Logger) (Service, error)
+type createServiceFunc func(factories otelcol.Factories, configProviderSettings otelcol.ConfigProviderSettings, logger *zap.Logger, logLevel zapcore.Level) (Service, error)
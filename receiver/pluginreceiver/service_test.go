@@ -21,11 +21,12 @@ import (
 	"go.opentelemetry.io/collector/otelcol"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestWrapLogger(t *testing.T) {
 	baseLogger := zap.NewNop()
-	opts := createServiceLoggerOpts(baseLogger)
+	opts := createServiceLoggerOpts(baseLogger, zapcore.ErrorLevel)
 	serviceLogger := zap.NewNop().WithOptions(opts...)
 	require.Equal(t, baseLogger.Core(), serviceLogger.Core())
 
@@ -33,6 +34,39 @@ func TestWrapLogger(t *testing.T) {
 	require.False(t, infoLevel)
 }
 
+func TestServiceLoggerLevel(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		baseLevel    zapcore.Level
+		logLevel     zapcore.Level
+		enabledLevel zapcore.Level
+	}{
+		{
+			desc:         "Raised above base level",
+			baseLevel:    zapcore.InfoLevel,
+			logLevel:     zapcore.WarnLevel,
+			enabledLevel: zapcore.WarnLevel,
+		},
+		{
+			desc:         "Lowered below base level",
+			baseLevel:    zapcore.InfoLevel,
+			logLevel:     zapcore.DebugLevel,
+			enabledLevel: zapcore.InfoLevel,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			core, _ := observer.New(tc.baseLevel)
+			opts := createServiceLoggerOpts(zap.New(core), tc.logLevel)
+			serviceLogger := zap.NewNop().WithOptions(opts...)
+
+			require.True(t, serviceLogger.Core().Enabled(tc.enabledLevel))
+			require.False(t, serviceLogger.Core().Enabled(tc.enabledLevel-1))
+		})
+	}
+}
+
 func TestCreateService(t *testing.T) {
 	renderedCfg := &RenderedConfig{}
 	configProviderSettings, err := renderedCfg.GetConfigProviderSettings()
@@ -40,6 +74,6 @@ func TestCreateService(t *testing.T) {
 
 	factories := otelcol.Factories{}
 	logger := zap.NewNop()
-	_, err = createService(factories, *configProviderSettings, logger)
+	_, err = createService(factories, *configProviderSettings, logger, zapcore.ErrorLevel)
 	require.NoError(t, err)
 }
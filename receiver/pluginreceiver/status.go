@@ -0,0 +1,115 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension"
+)
+
+var statusWatcherType = component.MustNewType("plugin_status")
+
+// StatusWatcher is an extension added to a plugin's internal service that receives the status
+// of each component in the sub-pipeline. The status of the failing component is reported as the
+// status of the plugin receiver, so it is clear which inner component is failing.
+type StatusWatcher struct {
+	report func(*componentstatus.Event)
+
+	mu       sync.Mutex
+	statuses map[component.ID]*componentstatus.Event
+	kinds    map[component.ID]component.Kind
+	failing  bool
+}
+
+// newStatusWatcher creates a StatusWatcher that reports the plugin receiver's status with report
+func newStatusWatcher(report func(*componentstatus.Event)) *StatusWatcher {
+	return &StatusWatcher{
+		report:   report,
+		statuses: make(map[component.ID]*componentstatus.Event),
+		kinds:    make(map[component.ID]component.Kind),
+	}
+}
+
+// Start is a no-op that fulfills the component.Component interface
+func (s *StatusWatcher) Start(_ context.Context, _ component.Host) error {
+	return nil
+}
+
+// Shutdown is a no-op that fulfills the component.Component interface
+func (s *StatusWatcher) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// ComponentStatusChanged records the status of an inner component and reports an error
+// for the plugin receiver while any inner component is in an error state
+func (s *StatusWatcher) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := source.ComponentID()
+	s.statuses[id] = event
+	s.kinds[id] = source.Kind()
+
+	if componentstatus.StatusIsError(event.Status()) {
+		s.failing = true
+		s.report(s.errorEvent(id, event))
+		return
+	}
+
+	if !s.failing {
+		return
+	}
+
+	// Report the next failing component, or recovery once none are left
+	for otherID, other := range s.statuses {
+		if componentstatus.StatusIsError(other.Status()) {
+			s.report(s.errorEvent(otherID, other))
+			return
+		}
+	}
+
+	s.failing = false
+	s.report(componentstatus.NewEvent(componentstatus.StatusOK))
+}
+
+// errorEvent returns the event reported for the plugin receiver when an inner component fails.
+// Fatal errors are reported as permanent errors so a failing plugin does not stop the collector.
+func (s *StatusWatcher) errorEvent(id component.ID, event *componentstatus.Event) *componentstatus.Event {
+	err := fmt.Errorf("%s %s: %w", strings.ToLower(s.kinds[id].String()), id, event.Err())
+	if event.Status() == componentstatus.StatusRecoverableError {
+		return componentstatus.NewRecoverableErrorEvent(err)
+	}
+	return componentstatus.NewPermanentErrorEvent(err)
+}
+
+// createStatusWatcherFactory creates a factory for a StatusWatcher extension that reports with report
+func createStatusWatcherFactory(report func(*componentstatus.Event)) extension.Factory {
+	createExtension := func(_ context.Context, _ extension.Settings, _ component.Config) (extension.Extension, error) {
+		return newStatusWatcher(report), nil
+	}
+
+	return extension.NewFactory(
+		statusWatcherType,
+		func() component.Config { return &struct{}{} },
+		createExtension,
+		component.StabilityLevelUndefined,
+	)
+}
@@ -0,0 +1,71 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+)
+
+func TestStatusWatcher(t *testing.T) {
+	var reported []*componentstatus.Event
+	watcher := newStatusWatcher(func(event *componentstatus.Event) {
+		reported = append(reported, event)
+	})
+
+	filelog := componentstatus.NewInstanceID(component.MustNewID("filelog"), component.KindReceiver)
+	batch := componentstatus.NewInstanceID(component.MustNewID("batch"), component.KindProcessor)
+
+	// Healthy components are not reported while nothing has failed
+	watcher.ComponentStatusChanged(filelog, componentstatus.NewEvent(componentstatus.StatusOK))
+	watcher.ComponentStatusChanged(batch, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Empty(t, reported)
+
+	watcher.ComponentStatusChanged(filelog, componentstatus.NewRecoverableErrorEvent(errors.New("no such file")))
+	require.Len(t, reported, 1)
+	require.Equal(t, componentstatus.StatusRecoverableError, reported[0].Status())
+	require.EqualError(t, reported[0].Err(), "receiver filelog: no such file")
+
+	watcher.ComponentStatusChanged(batch, componentstatus.NewFatalErrorEvent(errors.New("failed")))
+	require.Len(t, reported, 2)
+	require.Equal(t, componentstatus.StatusPermanentError, reported[1].Status())
+	require.EqualError(t, reported[1].Err(), "processor batch: failed")
+
+	// The batch processor is still failing after the filelog receiver recovers
+	watcher.ComponentStatusChanged(filelog, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Len(t, reported, 3)
+	require.EqualError(t, reported[2].Err(), "processor batch: failed")
+
+	watcher.ComponentStatusChanged(batch, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.Len(t, reported, 4)
+	require.Equal(t, componentstatus.StatusOK, reported[3].Status())
+}
+
+func TestCreateStatusWatcherFactory(t *testing.T) {
+	factory := createStatusWatcherFactory(func(*componentstatus.Event) {})
+	require.Equal(t, statusWatcherType, factory.Type())
+
+	ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(), factory.CreateDefaultConfig())
+	require.NoError(t, err)
+	require.Implements(t, (*componentstatus.Watcher)(nil), ext)
+	require.NoError(t, ext.Start(context.Background(), nil))
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -0,0 +1,141 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Telemetry routes the internal metrics of the components in a plugin's sub-pipeline
+// to the meter provider of the collector running the plugin receiver.
+// The internal service of the plugin has its own telemetry disabled, so the
+// components record their otelcol_* metrics on the parent's meter provider instead,
+// with a plugin_id attribute set to the ID of the plugin receiver.
+type Telemetry struct {
+	meterProvider metric.MeterProvider
+	metricsLevel  configtelemetry.Level
+}
+
+// NewTelemetry creates a Telemetry for the plugin receiver with the given ID
+func NewTelemetry(set component.TelemetrySettings, id component.ID) *Telemetry {
+	return &Telemetry{
+		meterProvider: newAttributeMeterProvider(set.MeterProvider, attribute.String("plugin_id", id.String())),
+		metricsLevel:  set.MetricsLevel,
+	}
+}
+
+// InstrumentFactories wraps the factories so the components they create
+// record their internal metrics on the plugin receiver's meter provider
+func (t *Telemetry) InstrumentFactories(factories *otelcol.Factories) {
+	for receiverType, factory := range factories.Receivers {
+		factories.Receivers[receiverType] = t.instrumentReceiverFactory(factory)
+	}
+
+	for processorType, factory := range factories.Processors {
+		factories.Processors[processorType] = t.instrumentProcessorFactory(factory)
+	}
+
+	for exporterType, factory := range factories.Exporters {
+		factories.Exporters[exporterType] = t.instrumentExporterFactory(factory)
+	}
+
+	for extensionType, factory := range factories.Extensions {
+		factories.Extensions[extensionType] = t.instrumentExtensionFactory(factory)
+	}
+}
+
+// telemetrySettings returns the telemetry settings of a component with the plugin's meter provider and metrics level
+func (t *Telemetry) telemetrySettings(set component.TelemetrySettings) component.TelemetrySettings {
+	set.MeterProvider = t.meterProvider
+	set.MetricsLevel = t.metricsLevel
+	return set
+}
+
+// instrumentReceiverFactory returns a receiver factory that creates receivers with the plugin's telemetry settings.
+// Every signal is passed through, so the original factory decides which it supports whatever their stability.
+func (t *Telemetry) instrumentReceiverFactory(factory receiver.Factory) receiver.Factory {
+	return receiver.NewFactory(factory.Type(), factory.CreateDefaultConfig,
+		receiver.WithLogs(func(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Logs) (receiver.Logs, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateLogs(ctx, set, cfg, next)
+		}, factory.LogsStability()),
+		receiver.WithMetrics(func(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateMetrics(ctx, set, cfg, next)
+		}, factory.MetricsStability()),
+		receiver.WithTraces(func(ctx context.Context, set receiver.Settings, cfg component.Config, next consumer.Traces) (receiver.Traces, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateTraces(ctx, set, cfg, next)
+		}, factory.TracesStability()),
+	)
+}
+
+// instrumentProcessorFactory returns a processor factory that creates processors with the plugin's telemetry settings.
+// Every signal is passed through, so the original factory decides which it supports whatever their stability.
+func (t *Telemetry) instrumentProcessorFactory(factory processor.Factory) processor.Factory {
+	return processor.NewFactory(factory.Type(), factory.CreateDefaultConfig,
+		processor.WithLogs(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateLogs(ctx, set, cfg, next)
+		}, factory.LogsStability()),
+		processor.WithMetrics(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateMetrics(ctx, set, cfg, next)
+		}, factory.MetricsStability()),
+		processor.WithTraces(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateTraces(ctx, set, cfg, next)
+		}, factory.TracesStability()),
+	)
+}
+
+// instrumentExporterFactory returns an exporter factory that creates exporters with the plugin's telemetry settings.
+// Every signal is passed through, so the original factory decides which it supports whatever their stability.
+// The plugin's emitter registers its signal with an undefined stability.
+func (t *Telemetry) instrumentExporterFactory(factory exporter.Factory) exporter.Factory {
+	return exporter.NewFactory(factory.Type(), factory.CreateDefaultConfig,
+		exporter.WithLogs(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateLogs(ctx, set, cfg)
+		}, factory.LogsStability()),
+		exporter.WithMetrics(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateMetrics(ctx, set, cfg)
+		}, factory.MetricsStability()),
+		exporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+			return factory.CreateTraces(ctx, set, cfg)
+		}, factory.TracesStability()),
+	)
+}
+
+// instrumentExtensionFactory returns an extension factory that creates extensions with the plugin's telemetry settings
+func (t *Telemetry) instrumentExtensionFactory(factory extension.Factory) extension.Factory {
+	return extension.NewFactory(factory.Type(), factory.CreateDefaultConfig, func(ctx context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+		set.TelemetrySettings = t.telemetrySettings(set.TelemetrySettings)
+		return factory.Create(ctx, set, cfg)
+	}, factory.Stability())
+}
@@ -0,0 +1,159 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pluginreceiver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestTelemetryInstrumentFactories(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	set := componenttest.NewNopTelemetrySettings()
+	set.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	set.MetricsLevel = configtelemetry.LevelDetailed
+
+	// record counts a metric on the meter provider a component was created with
+	var levels []configtelemetry.Level
+	record := func(ts component.TelemetrySettings, id component.ID) {
+		levels = append(levels, ts.MetricsLevel)
+		counter, err := ts.MeterProvider.Meter("test").Int64Counter("otelcol_test_created")
+		require.NoError(t, err)
+		counter.Add(context.Background(), 1, metric.WithAttributes(attribute.String("component", id.String())))
+	}
+
+	innerType := component.MustNewType("inner")
+	defaultConfig := func() component.Config { return &struct{}{} }
+	factories := &otelcol.Factories{
+		Receivers: map[component.Type]receiver.Factory{
+			innerType: receiver.NewFactory(innerType, defaultConfig,
+				receiver.WithLogs(func(_ context.Context, s receiver.Settings, _ component.Config, _ consumer.Logs) (receiver.Logs, error) {
+					record(s.TelemetrySettings, s.ID)
+					return nil, nil
+				}, component.StabilityLevelBeta),
+			),
+		},
+		Processors: map[component.Type]processor.Factory{
+			innerType: processor.NewFactory(innerType, defaultConfig,
+				processor.WithLogs(func(_ context.Context, s processor.Settings, _ component.Config, _ consumer.Logs) (processor.Logs, error) {
+					record(s.TelemetrySettings, s.ID)
+					return nil, nil
+				}, component.StabilityLevelAlpha),
+			),
+		},
+		Exporters: map[component.Type]exporter.Factory{
+			innerType: exporter.NewFactory(innerType, defaultConfig,
+				exporter.WithLogs(func(_ context.Context, s exporter.Settings, _ component.Config) (exporter.Logs, error) {
+					record(s.TelemetrySettings, s.ID)
+					return nil, nil
+				}, component.StabilityLevelStable),
+			),
+			emitterType: createLogEmitterFactory(consumertest.NewNop()),
+		},
+		Extensions: map[component.Type]extension.Factory{
+			innerType: extension.NewFactory(innerType, defaultConfig,
+				func(_ context.Context, s extension.Settings, _ component.Config) (extension.Extension, error) {
+					record(s.TelemetrySettings, s.ID)
+					return nil, nil
+				}, component.StabilityLevelDevelopment),
+		},
+	}
+
+	NewTelemetry(set, component.MustNewIDWithName("plugin", "mysql")).InstrumentFactories(factories)
+
+	receiverFactory := factories.Receivers[innerType]
+	require.Equal(t, component.StabilityLevelBeta, receiverFactory.LogsStability())
+	require.Equal(t, component.StabilityLevelUndefined, receiverFactory.MetricsStability())
+	require.Equal(t, component.StabilityLevelAlpha, factories.Processors[innerType].LogsStability())
+	require.Equal(t, component.StabilityLevelStable, factories.Exporters[innerType].LogsStability())
+	require.Equal(t, component.StabilityLevelDevelopment, factories.Extensions[innerType].Stability())
+
+	ctx := context.Background()
+	receiverSet := receivertest.NewNopSettings()
+	receiverSet.ID = component.NewIDWithName(innerType, "receiver")
+	_, err := receiverFactory.CreateLogs(ctx, receiverSet, receiverFactory.CreateDefaultConfig(), consumertest.NewNop())
+	require.NoError(t, err)
+
+	processorSet := processortest.NewNopSettings()
+	processorSet.ID = component.NewIDWithName(innerType, "processor")
+	_, err = factories.Processors[innerType].CreateLogs(ctx, processorSet, &struct{}{}, consumertest.NewNop())
+	require.NoError(t, err)
+
+	exporterSet := exportertest.NewNopSettings()
+	exporterSet.ID = component.NewIDWithName(innerType, "exporter")
+	_, err = factories.Exporters[innerType].CreateLogs(ctx, exporterSet, &struct{}{})
+	require.NoError(t, err)
+
+	extensionSet := extensiontest.NewNopSettings()
+	extensionSet.ID = component.NewIDWithName(innerType, "extension")
+	_, err = factories.Extensions[innerType].Create(ctx, extensionSet, &struct{}{})
+	require.NoError(t, err)
+
+	// Signals are passed through whatever their stability, such as the emitter's
+	_, err = factories.Exporters[emitterType].CreateLogs(ctx, exportertest.NewNopSettings(), &struct{}{})
+	require.NoError(t, err)
+	_, err = receiverFactory.CreateMetrics(ctx, receiverSet, &struct{}{}, consumertest.NewNop())
+	require.ErrorIs(t, err, pipeline.ErrSignalNotSupported)
+
+	require.Equal(t, []configtelemetry.Level{
+		configtelemetry.LevelDetailed,
+		configtelemetry.LevelDetailed,
+		configtelemetry.LevelDetailed,
+		configtelemetry.LevelDetailed,
+	}, levels)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 1)
+
+	sum, ok := rm.ScopeMetrics[0].Metrics[0].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+
+	components := map[string]int64{}
+	for _, dp := range sum.DataPoints {
+		pluginID, _ := dp.Attributes.Value(attribute.Key("plugin_id"))
+		require.Equal(t, "plugin/mysql", pluginID.AsString())
+		id, _ := dp.Attributes.Value(attribute.Key("component"))
+		components[id.AsString()] = dp.Value
+	}
+	require.Equal(t, map[string]int64{
+		"inner/receiver":  1,
+		"inner/processor": 1,
+		"inner/exporter":  1,
+		"inner/extension": 1,
+	}, components)
+}
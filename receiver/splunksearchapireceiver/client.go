@@ -34,6 +34,7 @@ type splunkSearchAPIClient interface {
 	CreateSearchJob(search string) (CreateJobResponse, error)
 	GetJobStatus(searchID string) (SearchJobStatusResponse, error)
 	GetSearchResults(searchID string, offset int, batchSize int) (SearchResults, error)
+	ExportSearch(ctx context.Context, search string) (io.ReadCloser, error)
 }
 
 type defaultSplunkSearchAPIClient struct {
@@ -145,6 +146,37 @@ func (c *defaultSplunkSearchAPIClient) GetSearchResults(sid string, offset int,
 	return searchResults, nil
 }
 
+// ExportSearch runs a search using the export endpoint, returning the stream of newline delimited JSON results.
+// The caller is responsible for closing the returned stream.
+func (c *defaultSplunkSearchAPIClient) ExportSearch(ctx context.Context, search string) (io.ReadCloser, error) {
+	endpoint := fmt.Sprintf("%s/services/search/v2/jobs/export", c.endpoint)
+
+	if !strings.Contains(search, strings.ToLower("starttime=")) || !strings.Contains(search, strings.ToLower("endtime=")) || !strings.Contains(search, strings.ToLower("timeformat=")) {
+		return nil, fmt.Errorf("search query must contain starttime, endtime, and timeformat")
+	}
+
+	reqBody := fmt.Sprintf(`search=%s&output_mode=json`, url.QueryEscape(search))
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewBuffer([]byte(reqBody)))
+	if err != nil {
+		return nil, fmt.Errorf("new http request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	err = c.setSplunkRequestAuth(req)
+	if err != nil {
+		return nil, fmt.Errorf("set splunk request auth: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("client do request: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("export search: %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
 func (c *defaultSplunkSearchAPIClient) doSplunkRequest(method, endpoint string, body io.Reader) (*http.Response, error) {
 	req, err := http.NewRequest(method, endpoint, body)
 	if err != nil {
@@ -15,6 +15,7 @@
 package splunksearchapireceiver
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -85,7 +86,7 @@ func TestGetSearchResults(t *testing.T) {
 	resp, err := testClient.GetSearchResults("123456", 0, 5)
 	require.NoError(t, err)
 	require.Equal(t, 3, len(resp.Results))
-	require.Equal(t, "Hello, world!", resp.Results[0].Raw)
+	require.Equal(t, "Hello, world!", resp.Results[0]["_raw"])
 
 	// returns an error if the response status isn't 200
 	resp, err = testClient.GetSearchResults("654321", 0, 5)
@@ -98,6 +99,29 @@ func TestGetSearchResults(t *testing.T) {
 	require.Empty(t, resp)
 }
 
+func TestExportSearch(t *testing.T) {
+	server := newMockServer()
+	testClient := defaultSplunkSearchAPIClient{
+		client:   server.Client(),
+		endpoint: server.URL,
+	}
+
+	body, err := testClient.ExportSearch(context.Background(), "search index=otel starttime=\"\" endtime=\"\" timeformat=\"\"")
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, string(splunkExportResults), string(data))
+
+	// returns an error if the search doesn't have times
+	_, err = testClient.ExportSearch(context.Background(), "search index=otel")
+	require.EqualError(t, err, "search query must contain starttime, endtime, and timeformat")
+
+	// returns an error if the response status isn't 200
+	_, err = testClient.ExportSearch(context.Background(), "search index=fail_to_export starttime=\"\" endtime=\"\" timeformat=\"\"")
+	require.EqualError(t, err, "export search: 404")
+}
+
 func TestSetSplunkRequestAuth(t *testing.T) {
 	client := defaultSplunkSearchAPIClient{
 		username: "user",
@@ -155,6 +179,14 @@ func newMockServer() *httptest.Server {
 					</dict>
 				</content>
 			</response>`))
+		case "/services/search/v2/jobs/export":
+			body, _ := io.ReadAll(req.Body)
+			if strings.Contains(string(body), "index%3Dotel") && strings.Contains(string(body), "output_mode=json") {
+				rw.WriteHeader(http.StatusOK)
+				rw.Write(splunkExportResults)
+				return
+			}
+			rw.WriteHeader(http.StatusNotFound)
 		case "/services/search/v2/jobs/654321":
 			rw.WriteHeader(http.StatusNotFound)
 		case "/services/search/v2/jobs/098765":
@@ -174,3 +206,9 @@ func newMockServer() *httptest.Server {
 		}
 	}))
 }
+
+var splunkExportResults = []byte(`{"preview":true,"offset":0,"result":{"_raw":"partial","_time":"2024-11-14T13:02:31.000+00:00"}}
+{"preview":false,"offset":0,"result":{"_raw":"Hello, world!","_time":"2024-11-14T13:02:31.000+00:00","host":"web-1","index":"otel"}}
+{"preview":false,"offset":1,"result":{"_raw":"Goodbye, world!","_time":"2024-11-14T13:02:30.000+00:00","host":"web-2","index":"otel"}}
+{"preview":false,"offset":2,"lastrow":true,"result":{"_raw":"Last, world!","_time":"2024-11-14T13:02:29.000+00:00","host":"web-1","index":"otel"}}
+`)
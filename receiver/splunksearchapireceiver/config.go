@@ -43,6 +43,19 @@ type Config struct {
 	Searches                []Search      `mapstructure:"searches"`
 	JobPollInterval         time.Duration `mapstructure:"job_poll_interval"`
 	StorageID               *component.ID `mapstructure:"storage"`
+	FieldMapping            FieldMapping  `mapstructure:"field_mapping"`
+}
+
+// FieldMapping configures how the fields of a Splunk event are mapped onto a log record
+type FieldMapping struct {
+	// Timestamp is the field used as the log timestamp, parsed as RFC3339 or epoch seconds
+	Timestamp string `mapstructure:"timestamp"`
+	// Body is the field used as the log body
+	Body string `mapstructure:"body"`
+	// ResourceAttributes maps Splunk fields to resource attribute names, an empty name drops the field
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+	// Attributes maps Splunk fields to log attribute names, an empty name drops the field
+	Attributes map[string]string `mapstructure:"attributes"`
 }
 
 // Search struct to represent a Splunk search
@@ -52,6 +65,20 @@ type Search struct {
 	LatestTime     string `mapstructure:"latest_time"`
 	Limit          int    `mapstructure:"limit"`
 	EventBatchSize int    `mapstructure:"event_batch_size"`
+
+	// UseExportEndpoint streams results from the export endpoint instead of
+	// creating a search job and paging through its results
+	UseExportEndpoint bool `mapstructure:"use_export_endpoint"`
+
+	// Interval enables recurring mode, running the search every interval over
+	// the window between the previous high-water mark and now minus Lag
+	Interval time.Duration `mapstructure:"interval"`
+	Lag      time.Duration `mapstructure:"lag"`
+}
+
+// recurring returns true if the search runs continuously over sliding time windows
+func (s Search) recurring() bool {
+	return s.Interval > 0
 }
 
 // Validate validates the Splunk Search API receiver configuration
@@ -74,6 +101,13 @@ func (cfg *Config) Validate() error {
 		return errors.New("missing Splunk basic auth credentials, need username and password")
 	}
 
+	if cfg.FieldMapping.Timestamp == "" {
+		return errors.New("field_mapping.timestamp cannot be empty")
+	}
+	if cfg.FieldMapping.Body == "" {
+		return errors.New("field_mapping.body cannot be empty")
+	}
+
 	if len(cfg.Searches) == 0 {
 		return errors.New("at least one search must be provided")
 	}
@@ -105,6 +139,33 @@ func (cfg *Config) Validate() error {
 			return errors.New("time query parameters must be configured using only the 'earliest_time' and 'latest_time' configuration parameters")
 		}
 
+		if search.Interval < 0 {
+			return errors.New("interval must be a positive duration")
+		}
+		if search.Lag < 0 {
+			return errors.New("lag must be a positive duration")
+		}
+
+		if search.recurring() {
+			if search.LatestTime != "" {
+				return errors.New("latest_time cannot be used with a recurring search")
+			}
+			if search.Limit != 0 {
+				return errors.New("limit cannot be used with a recurring search")
+			}
+			// earliest_time is optional for recurring searches, and sets where the first window starts
+			if search.EarliestTime != "" {
+				if _, err := time.Parse(time.RFC3339, search.EarliestTime); err != nil {
+					return errors.New("earliest_time failed to parse as RFC3339")
+				}
+			}
+			continue
+		}
+
+		if search.Lag != 0 {
+			return errors.New("lag can only be used with a recurring search")
+		}
+
 		if search.EarliestTime == "" {
 			return errors.New("missing earliest_time in search")
 		}
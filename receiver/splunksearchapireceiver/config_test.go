@@ -16,6 +16,7 @@ package splunksearchapireceiver
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -31,6 +32,7 @@ func TestValidate(t *testing.T) {
 		tokenType   string
 		storage     string
 		searches    []Search
+		modify      func(cfg *Config)
 		errExpected bool
 		errText     string
 	}{
@@ -327,6 +329,120 @@ func TestValidate(t *testing.T) {
 			errExpected: true,
 			errText:     "time query parameters must be configured using only the 'earliest_time' and 'latest_time' configuration parameters",
 		},
+		{
+			desc:     "Empty timestamp field mapping",
+			endpoint: "http://localhost:8089",
+			username: "user",
+			password: "password",
+			storage:  "file_storage",
+			searches: []Search{
+				{
+					Query:        "search index=_internal",
+					EarliestTime: "2024-10-30T04:00:00.000Z",
+					LatestTime:   "2024-10-30T14:00:00.000Z",
+				},
+			},
+			modify: func(cfg *Config) {
+				cfg.FieldMapping.Timestamp = ""
+			},
+			errExpected: true,
+			errText:     "field_mapping.timestamp cannot be empty",
+		},
+		{
+			desc:     "Valid recurring search",
+			endpoint: "http://localhost:8089",
+			username: "user",
+			password: "password",
+			storage:  "file_storage",
+			searches: []Search{
+				{
+					Query:    "search index=_internal",
+					Interval: 5 * time.Minute,
+					Lag:      time.Minute,
+				},
+			},
+			errExpected: false,
+		},
+		{
+			desc:     "Valid recurring search with earliest_time",
+			endpoint: "http://localhost:8089",
+			username: "user",
+			password: "password",
+			storage:  "file_storage",
+			searches: []Search{
+				{
+					Query:        "search index=_internal",
+					EarliestTime: "2024-10-30T04:00:00.000Z",
+					Interval:     5 * time.Minute,
+				},
+			},
+			errExpected: false,
+		},
+		{
+			desc:     "Recurring search with latest_time",
+			endpoint: "http://localhost:8089",
+			username: "user",
+			password: "password",
+			storage:  "file_storage",
+			searches: []Search{
+				{
+					Query:      "search index=_internal",
+					LatestTime: "2024-10-30T14:00:00.000Z",
+					Interval:   5 * time.Minute,
+				},
+			},
+			errExpected: true,
+			errText:     "latest_time cannot be used with a recurring search",
+		},
+		{
+			desc:     "Recurring search with limit",
+			endpoint: "http://localhost:8089",
+			username: "user",
+			password: "password",
+			storage:  "file_storage",
+			searches: []Search{
+				{
+					Query:    "search index=_internal",
+					Interval: 5 * time.Minute,
+					Limit:    10,
+				},
+			},
+			errExpected: true,
+			errText:     "limit cannot be used with a recurring search",
+		},
+		{
+			desc:     "Lag without interval",
+			endpoint: "http://localhost:8089",
+			username: "user",
+			password: "password",
+			storage:  "file_storage",
+			searches: []Search{
+				{
+					Query:        "search index=_internal",
+					EarliestTime: "2024-10-30T04:00:00.000Z",
+					LatestTime:   "2024-10-30T14:00:00.000Z",
+					Lag:          time.Minute,
+				},
+			},
+			errExpected: true,
+			errText:     "lag can only be used with a recurring search",
+		},
+		{
+			desc:     "Valid export search",
+			endpoint: "http://localhost:8089",
+			username: "user",
+			password: "password",
+			storage:  "file_storage",
+			searches: []Search{
+				{
+					Query:             "search index=_internal",
+					EarliestTime:      "2024-10-30T04:00:00.000Z",
+					LatestTime:        "2024-10-30T14:00:00.000Z",
+					UseExportEndpoint: true,
+				},
+			},
+			errExpected: false,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -337,6 +453,9 @@ func TestValidate(t *testing.T) {
 			cfg.AuthToken = tc.authToken
 			cfg.TokenType = tc.tokenType
 			cfg.Searches = tc.searches
+			if tc.modify != nil {
+				tc.modify(cfg)
+			}
 			if tc.storage != "" {
 				cfg.StorageID = &component.ID{}
 			}
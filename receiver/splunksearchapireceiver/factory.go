@@ -32,6 +32,16 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		ClientConfig:    confighttp.NewDefaultClientConfig(),
 		JobPollInterval: 5 * time.Second,
+		FieldMapping: FieldMapping{
+			Timestamp: "_time",
+			Body:      "_raw",
+			ResourceAttributes: map[string]string{
+				"host":       "host.name",
+				"source":     "com.splunk.source",
+				"sourcetype": "com.splunk.sourcetype",
+				"index":      "com.splunk.index",
+			},
+		},
 	}
 }
 
@@ -0,0 +1,138 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunksearchapireceiver
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// logBuilder maps Splunk search results onto log records, grouping records
+// with the same resource attributes into a single resource
+type logBuilder struct {
+	mapping       FieldMapping
+	resourceOrder []string
+	logs          plog.Logs
+	resources     map[string]plog.LogRecordSlice
+}
+
+func newLogBuilder(mapping FieldMapping) *logBuilder {
+	resourceOrder := make([]string, 0, len(mapping.ResourceAttributes))
+	for field, attr := range mapping.ResourceAttributes {
+		if attr != "" {
+			resourceOrder = append(resourceOrder, field)
+		}
+	}
+	sort.Strings(resourceOrder)
+
+	return &logBuilder{
+		mapping:       mapping,
+		resourceOrder: resourceOrder,
+		logs:          plog.NewLogs(),
+		resources:     map[string]plog.LogRecordSlice{},
+	}
+}
+
+// timestamp returns the timestamp of a result using the configured timestamp field
+func (b *logBuilder) timestamp(result SearchResult) (time.Time, error) {
+	raw := resultField(result, b.mapping.Timestamp)
+	if raw == "" {
+		return time.Time{}, fmt.Errorf("result is missing timestamp field %q", b.mapping.Timestamp)
+	}
+	if ts, err := time.Parse(time.RFC3339, raw); err == nil {
+		return ts, nil
+	}
+	// fields such as _indextime are epoch seconds
+	epoch, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("timestamp %q is not RFC3339 or epoch seconds", raw)
+	}
+	sec, frac := math.Modf(epoch)
+	return time.Unix(int64(sec), int64(frac*1e9)), nil
+}
+
+// append adds a result to the logs with the given timestamp
+func (b *logBuilder) append(result SearchResult, ts time.Time) {
+	key := b.resourceKey(result)
+	records, ok := b.resources[key]
+	if !ok {
+		resourceLogs := b.logs.ResourceLogs().AppendEmpty()
+		resourceAttrs := resourceLogs.Resource().Attributes()
+		for _, field := range b.resourceOrder {
+			putField(resourceAttrs, b.mapping.ResourceAttributes[field], result[field])
+		}
+		records = resourceLogs.ScopeLogs().AppendEmpty().LogRecords()
+		b.resources[key] = records
+	}
+
+	log := records.AppendEmpty()
+	log.SetTimestamp(pcommon.NewTimestampFromTime(ts.UTC()))
+	log.Body().SetStr(resultField(result, b.mapping.Body))
+	for field, attr := range b.mapping.Attributes {
+		if attr != "" {
+			putField(log.Attributes(), attr, result[field])
+		}
+	}
+}
+
+// resourceKey identifies the resource a result belongs to
+func (b *logBuilder) resourceKey(result SearchResult) string {
+	values := make([]string, 0, len(b.resourceOrder))
+	for _, field := range b.resourceOrder {
+		values = append(values, resultField(result, field))
+	}
+	return strings.Join(values, "\x00")
+}
+
+// resultField returns the value of a result field as a string, joining multivalue fields
+func resultField(result SearchResult, field string) string {
+	switch v := result[field].(type) {
+	case string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return strings.Join(values, "\n")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// putField sets an attribute from a result field value, multivalue fields become slices
+func putField(attrs pcommon.Map, attr string, value any) {
+	switch v := value.(type) {
+	case nil:
+	case string:
+		attrs.PutStr(attr, v)
+	case []any:
+		slice := attrs.PutEmptySlice(attr)
+		for _, item := range v {
+			slice.AppendEmpty().SetStr(fmt.Sprint(item))
+		}
+	default:
+		attrs.PutStr(attr, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunksearchapireceiver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogBuilder(t *testing.T) {
+	mapping := createDefaultConfig().(*Config).FieldMapping
+	mapping.ResourceAttributes["index"] = ""
+	mapping.Attributes = map[string]string{"tag": "splunk.tag", "linecount": "splunk.linecount"}
+	builder := newLogBuilder(mapping)
+
+	results := []SearchResult{
+		{"_raw": "first", "_time": "2024-11-14T13:02:31.000+00:00", "host": "web-1", "source": "/var/log/app.log", "sourcetype": "app", "index": "otel", "tag": []any{"a", "b"}, "linecount": "1"},
+		{"_raw": "second", "_time": "2024-11-14T13:02:30.000+00:00", "host": "web-2", "source": "/var/log/app.log", "sourcetype": "app", "index": "otel"},
+		{"_raw": "third", "_time": "2024-11-14T13:02:29.000+00:00", "host": "web-1", "source": "/var/log/app.log", "sourcetype": "app", "index": "otel"},
+	}
+	for _, result := range results {
+		ts, err := builder.timestamp(result)
+		require.NoError(t, err)
+		builder.append(result, ts)
+	}
+
+	logs := builder.logs
+	require.Equal(t, 3, logs.LogRecordCount())
+	require.Equal(t, 2, logs.ResourceLogs().Len())
+
+	resource := logs.ResourceLogs().At(0).Resource().Attributes()
+	require.Equal(t, map[string]any{
+		"host.name":             "web-1",
+		"com.splunk.source":     "/var/log/app.log",
+		"com.splunk.sourcetype": "app",
+	}, resource.AsRaw())
+
+	records := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	require.Equal(t, "first", records.At(0).Body().Str())
+	require.Equal(t, time.Date(2024, 11, 14, 13, 2, 31, 0, time.UTC), records.At(0).Timestamp().AsTime())
+	require.Equal(t, map[string]any{
+		"splunk.tag":       []any{"a", "b"},
+		"splunk.linecount": "1",
+	}, records.At(0).Attributes().AsRaw())
+	require.Equal(t, "third", records.At(1).Body().Str())
+}
+
+func TestLogBuilderTimestamp(t *testing.T) {
+	builder := newLogBuilder(FieldMapping{Timestamp: "_indextime", Body: "_raw"})
+
+	ts, err := builder.timestamp(SearchResult{"_indextime": "1731589351.5"})
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1731589351, 500000000), ts)
+
+	_, err = builder.timestamp(SearchResult{"_indextime": "yesterday"})
+	require.EqualError(t, err, `timestamp "yesterday" is not RFC3339 or epoch seconds`)
+
+	_, err = builder.timestamp(SearchResult{})
+	require.EqualError(t, err, `result is missing timestamp field "_indextime"`)
+}
@@ -14,6 +14,8 @@
 
 package splunksearchapireceiver
 
+import "time"
+
 // CreateJobResponse struct to represent the XML response from Splunk create job endpoint
 // https://docs.splunk.com/Documentation/Splunk/9.3.1/RESTREF/RESTsearch#search.2Fjobs
 type CreateJobResponse struct {
@@ -55,15 +57,36 @@ type List struct {
 // SearchResults struct to represent the JSON response from Splunk search results endpoint
 // https://docs.splunk.com/Documentation/Splunk/9.3.1/RESTREF/RESTsearch#search.2Fv2.2Fjobs.2F.7Bsearch_id.7D.2Fresults
 type SearchResults struct {
-	InitOffset int `json:"init_offset"`
-	Results    []struct {
-		Raw  string `json:"_raw"`
-		Time string `json:"_time"`
-	} `json:"results"`
+	InitOffset int            `json:"init_offset"`
+	Results    []SearchResult `json:"results"`
+}
+
+// SearchResult is a single event returned by Splunk, keyed by field name.
+// Values are strings, or lists of strings for multivalue fields.
+type SearchResult map[string]any
+
+// ExportResult struct to represent a single line streamed from the Splunk export endpoint
+// https://docs.splunk.com/Documentation/Splunk/9.3.1/RESTREF/RESTsearch#search.2Fv2.2Fjobs.2Fexport
+type ExportResult struct {
+	Preview bool         `json:"preview"`
+	Offset  int          `json:"offset"`
+	LastRow bool         `json:"lastrow,omitempty"`
+	Result  SearchResult `json:"result"`
 }
 
 // EventRecord struct stores the offset of the last event exported successfully
 type EventRecord struct {
-	Offset int    `json:"offset"`
-	Search string `json:"search"`
+	Offset  int                      `json:"offset"`
+	Search  string                   `json:"search"`
+	Windows map[string]*WindowRecord `json:"windows,omitempty"`
+}
+
+// WindowRecord tracks the progress of a recurring search
+type WindowRecord struct {
+	// HighWaterMark is the end of the last window that was fully exported
+	HighWaterMark time.Time `json:"high_water_mark"`
+	// WindowEnd is the end of the window being exported, zero when no window is in progress
+	WindowEnd *time.Time `json:"window_end,omitempty"`
+	// Offset is the number of results already exported from the window in progress
+	Offset int `json:"offset,omitempty"`
 }
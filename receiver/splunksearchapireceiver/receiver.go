@@ -17,15 +17,16 @@ package splunksearchapireceiver
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
-	"go.opentelemetry.io/collector/pdata/pcommon"
-	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap"
 )
 
@@ -50,6 +51,8 @@ type splunksearchapireceiver struct {
 	client           splunkSearchAPIClient
 	storageClient    storage.Client
 	checkpointRecord *EventRecord
+	mu               sync.Mutex // protects checkpointRecord
+	wg               sync.WaitGroup
 }
 
 func newSSAPIReceiver(
@@ -86,11 +89,25 @@ func (ssapir *splunksearchapireceiver) Start(ctx context.Context, host component
 	}
 	ssapir.storageClient = storageClient
 
+	// recurring searches are collected before the checkpoint is initialized,
+	// as resuming a one-time search may skip searches earlier in the list
+	var recurring []Search
+	for _, search := range ssapir.config.Searches {
+		if search.recurring() {
+			recurring = append(recurring, search)
+		}
+	}
+
 	err = ssapir.initCheckpoint(cancelCtx)
 	if err != nil {
 		return fmt.Errorf("failed to initialize checkpoint: %w", err)
 	}
 	go ssapir.runQueries(cancelCtx)
+
+	for _, search := range recurring {
+		ssapir.wg.Add(1)
+		go ssapir.runRecurringSearch(cancelCtx, search)
+	}
 	return nil
 }
 
@@ -99,6 +116,7 @@ func (ssapir *splunksearchapireceiver) Shutdown(ctx context.Context) error {
 	if ssapir.cancel != nil {
 		ssapir.cancel()
 	}
+	ssapir.wg.Wait()
 
 	if ssapir.storageClient != nil {
 		if err := ssapir.checkpoint(ctx); err != nil {
@@ -111,8 +129,15 @@ func (ssapir *splunksearchapireceiver) Shutdown(ctx context.Context) error {
 
 func (ssapir *splunksearchapireceiver) runQueries(ctx context.Context) {
 	for _, search := range ssapir.config.Searches {
+		// recurring searches run on their own schedule
+		if search.recurring() {
+			continue
+		}
+
 		// set current search query
+		ssapir.mu.Lock()
 		ssapir.checkpointRecord.Search = search.Query
+		ssapir.mu.Unlock()
 
 		// set default event batch size (matches Splunk API default)
 		if search.EventBatchSize == 0 {
@@ -124,39 +149,34 @@ func (ssapir *splunksearchapireceiver) runQueries(ctx context.Context) {
 		latestTime, _ := time.Parse(time.RFC3339, search.LatestTime)
 
 		// create search in Splunk
-		searchID, err := ssapir.createSplunkSearch(search)
+		reader, err := ssapir.openResults(ctx, search, offset)
 		if err != nil {
 			ssapir.logger.Error("error creating search", zap.Error(err))
 			return
 		}
 
-		// wait for search to complete
-		if err = ssapir.pollSearchCompletion(ctx, searchID); err != nil {
-			ssapir.logger.Error("error polling for search completion", zap.Error(err))
-			return
-		}
-
 		for {
 			if ctx.Err() != nil {
 				ssapir.logger.Error("context cancelled, stopping search result export", zap.Error(ctx.Err()))
+				reader.close()
 				return
 			}
 
 			ssapir.logger.Info("fetching search results")
-			results, err := ssapir.getSplunkSearchResults(searchID, offset, search.EventBatchSize)
+			results, err := reader.next()
 			if err != nil {
 				ssapir.logger.Error("error fetching search results", zap.Error(err))
 			}
-			ssapir.logger.Info("search results fetched", zap.Int("num_results", len(results.Results)))
+			ssapir.logger.Info("search results fetched", zap.Int("num_results", len(results)))
 
-			logs := plog.NewLogs()
-			for idx, splunkLog := range results.Results {
+			builder := newLogBuilder(ssapir.config.FieldMapping)
+			for idx, splunkLog := range results {
 				if (idx+exportedEvents) >= search.Limit && search.Limit != 0 {
 					limitReached = true
 					break
 				}
 				// convert log timestamp to ISO 8601 (UTC() makes RFC 3339 into ISO 8601)
-				logTimestamp, err := time.Parse(time.RFC3339, splunkLog.Time)
+				logTimestamp, err := builder.timestamp(splunkLog)
 				if err != nil {
 					ssapir.logger.Error("error parsing log timestamp", zap.Error(err))
 					break
@@ -169,14 +189,11 @@ func (ssapir *splunksearchapireceiver) runQueries(ctx context.Context) {
 					ssapir.logger.Info("skipping log entry - timestamp after latestTime", zap.Time("time", logTimestamp.UTC()), zap.Time("latestTime", latestTime.UTC()))
 					continue
 				}
-				log := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
-				// convert time to timestamp
-				timestamp := pcommon.NewTimestampFromTime(logTimestamp.UTC())
-				log.SetTimestamp(timestamp)
-				log.Body().SetStr(splunkLog.Raw)
+				builder.append(splunkLog, logTimestamp)
 			}
+			logs := builder.logs
 
-			if logs.ResourceLogs().Len() == 0 {
+			if logs.LogRecordCount() == 0 {
 				ssapir.logger.Info("search returned no logs within the given time range")
 				break
 			}
@@ -186,14 +203,17 @@ func (ssapir *splunksearchapireceiver) runQueries(ctx context.Context) {
 			if err != nil {
 				// error from down the pipeline, freak out
 				ssapir.logger.Error("error exporting logs", zap.Error(err))
+				reader.close()
 				return
 			}
 			// last batch of logs has been successfully exported
-			exportedEvents += logs.ResourceLogs().Len()
-			offset += len(results.Results)
+			exportedEvents += logs.LogRecordCount()
+			offset += len(results)
 
 			// update checkpoint
+			ssapir.mu.Lock()
 			ssapir.checkpointRecord.Offset = offset
+			ssapir.mu.Unlock()
 			err = ssapir.checkpoint(ctx)
 			if err != nil {
 				ssapir.logger.Error("error writing checkpoint", zap.Error(err))
@@ -203,16 +223,141 @@ func (ssapir *splunksearchapireceiver) runQueries(ctx context.Context) {
 				break
 			}
 			// if the number of results is less than the results per request, we have queried all pages for the search
-			if len(results.Results) < search.EventBatchSize {
+			if len(results) < search.EventBatchSize {
 				ssapir.logger.Debug("results less than batch size, stopping search result export")
 				break
 			}
 		}
+		reader.close()
 		ssapir.logger.Info("search results exported", zap.String("query", search.Query), zap.Int("total results", exportedEvents))
 	}
 	ssapir.logger.Info("all search results exported")
 }
 
+// runRecurringSearch runs a search every interval over the window between the
+// search's high-water mark and now minus the configured lag
+func (ssapir *splunksearchapireceiver) runRecurringSearch(ctx context.Context, search Search) {
+	defer ssapir.wg.Done()
+
+	if search.EventBatchSize == 0 {
+		search.EventBatchSize = splunkDefaultEventBatchSize
+	}
+
+	t := time.NewTicker(search.Interval)
+	defer t.Stop()
+	for {
+		if err := ssapir.runWindow(ctx, search, time.Now()); err != nil {
+			ssapir.logger.Error("error running recurring search", zap.String("query", search.Query), zap.Error(err))
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runWindow exports the results of a single window of a recurring search, resuming a partially exported window if one was checkpointed
+func (ssapir *splunksearchapireceiver) runWindow(ctx context.Context, search Search, now time.Time) error {
+	window := ssapir.windowRecord(search, now)
+	start := window.HighWaterMark
+	end := now.Add(-search.Lag).UTC().Truncate(time.Second)
+	offset := 0
+	if window.WindowEnd != nil {
+		end = *window.WindowEnd
+		offset = window.Offset
+	}
+	if !end.After(start) {
+		ssapir.logger.Debug("window is empty, waiting for next interval", zap.String("query", search.Query))
+		return nil
+	}
+
+	windowSearch := search
+	windowSearch.EarliestTime = start.Format(time.RFC3339)
+	windowSearch.LatestTime = end.Format(time.RFC3339)
+	reader, err := ssapir.openResults(ctx, windowSearch, offset)
+	if err != nil {
+		return fmt.Errorf("create search: %w", err)
+	}
+	defer reader.close()
+
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		results, err := reader.next()
+		if err != nil {
+			return fmt.Errorf("fetch search results: %w", err)
+		}
+
+		builder := newLogBuilder(ssapir.config.FieldMapping)
+		for _, splunkLog := range results {
+			logTimestamp, err := builder.timestamp(splunkLog)
+			if err != nil {
+				ssapir.logger.Error("error parsing log timestamp", zap.Error(err))
+				continue
+			}
+			// windows are half open so that events on a boundary are only exported once
+			if logTimestamp.Before(start) || !logTimestamp.Before(end) {
+				continue
+			}
+			builder.append(splunkLog, logTimestamp)
+		}
+
+		if builder.logs.LogRecordCount() > 0 {
+			if err = ssapir.logsConsumer.ConsumeLogs(ctx, builder.logs); err != nil {
+				return fmt.Errorf("export logs: %w", err)
+			}
+		}
+		offset += len(results)
+
+		done := len(results) < search.EventBatchSize
+		ssapir.mu.Lock()
+		if done {
+			window.HighWaterMark = end
+			window.WindowEnd = nil
+			window.Offset = 0
+		} else {
+			window.WindowEnd = &end
+			window.Offset = offset
+		}
+		ssapir.mu.Unlock()
+		if err = ssapir.checkpoint(ctx); err != nil {
+			ssapir.logger.Error("error writing checkpoint", zap.Error(err))
+		}
+
+		if done {
+			ssapir.logger.Debug("window exported", zap.String("query", search.Query), zap.Time("high_water_mark", end), zap.Int("results", offset))
+			return nil
+		}
+	}
+}
+
+// windowRecord returns the checkpointed window for a recurring search, creating one if the search has not run before
+func (ssapir *splunksearchapireceiver) windowRecord(search Search, now time.Time) *WindowRecord {
+	ssapir.mu.Lock()
+	defer ssapir.mu.Unlock()
+
+	if ssapir.checkpointRecord.Windows == nil {
+		ssapir.checkpointRecord.Windows = map[string]*WindowRecord{}
+	}
+	if window, ok := ssapir.checkpointRecord.Windows[search.Query]; ok {
+		return window
+	}
+
+	// first window starts at earliest_time if configured, otherwise one interval before the first window end
+	start := now.Add(-search.Lag - search.Interval).UTC().Truncate(time.Second)
+	if search.EarliestTime != "" {
+		earliestTime, _ := time.Parse(time.RFC3339, search.EarliestTime)
+		start = earliestTime.UTC()
+	}
+	window := &WindowRecord{HighWaterMark: start}
+	ssapir.checkpointRecord.Windows[search.Query] = window
+	return window
+}
+
 func (ssapir *splunksearchapireceiver) pollSearchCompletion(ctx context.Context, searchID string) error {
 	t := time.NewTicker(ssapir.config.JobPollInterval)
 	defer t.Stop()
@@ -236,9 +381,51 @@ func (ssapir *splunksearchapireceiver) pollSearchCompletion(ctx context.Context,
 	}
 }
 
-func (ssapir *splunksearchapireceiver) createSplunkSearch(search Search) (string, error) {
+// openResults starts a search and returns a reader for its results, skipping the first offset results
+func (ssapir *splunksearchapireceiver) openResults(ctx context.Context, search Search, offset int) (resultReader, error) {
+	if search.UseExportEndpoint {
+		searchQuery := buildSearchQuery(search)
+		ssapir.logger.Info("exporting search", zap.String("query", searchQuery))
+		body, err := ssapir.client.ExportSearch(ctx, searchQuery)
+		if err != nil {
+			return nil, err
+		}
+		reader := &exportResultReader{
+			body:      body,
+			decoder:   json.NewDecoder(body),
+			batchSize: search.EventBatchSize,
+		}
+		// the export endpoint has no offset, so already exported results are read and discarded
+		if err = reader.skip(offset); err != nil {
+			reader.close()
+			return nil, fmt.Errorf("skip exported results: %w", err)
+		}
+		return reader, nil
+	}
+
+	searchID, err := ssapir.createSplunkSearch(search)
+	if err != nil {
+		return nil, err
+	}
+	if err = ssapir.pollSearchCompletion(ctx, searchID); err != nil {
+		return nil, fmt.Errorf("poll search completion: %w", err)
+	}
+	return &jobResultReader{
+		ssapir:    ssapir,
+		searchID:  searchID,
+		offset:    offset,
+		batchSize: search.EventBatchSize,
+	}, nil
+}
+
+// buildSearchQuery adds the search time range to the query
+func buildSearchQuery(search Search) string {
 	timeFormat := "%Y-%m-%dT%H:%M:%S"
-	searchQuery := fmt.Sprintf("%s starttime=\"%s\" endtime=\"%s\" timeformat=\"%s\"", search.Query, search.EarliestTime, search.LatestTime, timeFormat)
+	return fmt.Sprintf("%s starttime=\"%s\" endtime=\"%s\" timeformat=\"%s\"", search.Query, search.EarliestTime, search.LatestTime, timeFormat)
+}
+
+func (ssapir *splunksearchapireceiver) createSplunkSearch(search Search) (string, error) {
+	searchQuery := buildSearchQuery(search)
 	ssapir.logger.Info("creating search", zap.String("query", searchQuery))
 	resp, err := ssapir.client.CreateSearchJob(searchQuery)
 	if err != nil {
@@ -267,6 +454,81 @@ func (ssapir *splunksearchapireceiver) getSplunkSearchResults(sid string, offset
 	return resp, nil
 }
 
+// resultReader returns successive batches of search results. A batch smaller than the batch size ends the search.
+type resultReader interface {
+	next() ([]SearchResult, error)
+	close()
+}
+
+// jobResultReader pages through the results of a completed search job
+type jobResultReader struct {
+	ssapir    *splunksearchapireceiver
+	searchID  string
+	offset    int
+	batchSize int
+}
+
+func (r *jobResultReader) next() ([]SearchResult, error) {
+	results, err := r.ssapir.getSplunkSearchResults(r.searchID, r.offset, r.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	r.offset += len(results.Results)
+	return results.Results, nil
+}
+
+func (r *jobResultReader) close() {}
+
+// exportResultReader reads batches of results from the export endpoint stream
+type exportResultReader struct {
+	body      io.ReadCloser
+	decoder   *json.Decoder
+	batchSize int
+	done      bool
+}
+
+func (r *exportResultReader) next() ([]SearchResult, error) {
+	results := make([]SearchResult, 0, r.batchSize)
+	for !r.done && len(results) < r.batchSize {
+		var line ExportResult
+		err := r.decoder.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			r.done = true
+			break
+		}
+		if err != nil {
+			return results, fmt.Errorf("decode export result: %w", err)
+		}
+		if line.LastRow {
+			r.done = true
+		}
+		// preview results are partial and are repeated once final
+		if line.Preview || line.Result == nil {
+			continue
+		}
+		results = append(results, line.Result)
+	}
+	return results, nil
+}
+
+func (r *exportResultReader) skip(n int) error {
+	for n > 0 && !r.done {
+		batchSize := r.batchSize
+		r.batchSize = min(n, batchSize)
+		results, err := r.next()
+		r.batchSize = batchSize
+		if err != nil {
+			return err
+		}
+		n -= len(results)
+	}
+	return nil
+}
+
+func (r *exportResultReader) close() {
+	_ = r.body.Close()
+}
+
 func (ssapir *splunksearchapireceiver) initCheckpoint(ctx context.Context) error {
 	ssapir.logger.Debug("initializing checkpoint")
 	// if a checkpoint already exists, use the offset from the checkpoint
@@ -290,6 +552,8 @@ func (ssapir *splunksearchapireceiver) initCheckpoint(ctx context.Context) error
 }
 
 func (ssapir *splunksearchapireceiver) checkpoint(ctx context.Context) error {
+	ssapir.mu.Lock()
+	defer ssapir.mu.Unlock()
 	if ssapir.checkpointRecord == nil {
 		return nil
 	}
@@ -15,8 +15,11 @@
 package splunksearchapireceiver
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"encoding/xml"
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -25,6 +28,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
 )
@@ -167,6 +171,149 @@ func TestLoadCheckpoint(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestRunWindow(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.JobPollInterval = 10 * time.Millisecond
+	search := Search{
+		Query:          "search index=otel",
+		Interval:       5 * time.Minute,
+		Lag:            time.Minute,
+		EventBatchSize: 2,
+	}
+	cfg.Searches = []Search{search}
+
+	sink := &consumertest.LogsSink{}
+	rcvr := newSSAPIReceiver(logger, cfg, settings, id)
+	rcvr.logsConsumer = sink
+	mockStorage := &mockStorage{}
+	mockStorage.On("Set", mock.Anything, eventStorageKey, mock.Anything).Return(nil)
+	rcvr.storageClient = mockStorage
+	client := &mockLogsClient{}
+	rcvr.client = client
+
+	now := time.Date(2024, 10, 30, 12, 0, 0, 0, time.UTC)
+	statusFile := filepath.Join("testdata", "logs", "testPollJobStatus", "input-done.xml")
+	client.On("CreateSearchJob", `search index=otel starttime="2024-10-30T11:54:00Z" endtime="2024-10-30T11:59:00Z" timeformat="%Y-%m-%dT%H:%M:%S"`).Return(CreateJobResponse{SID: "123"}, nil).Once()
+	client.On("GetJobStatus", "123").Return(client.loadTestStatusResponse(t, statusFile), nil)
+	client.On("GetSearchResults", "123", 0, 2).Return(testSearchResults("2024-10-30T11:58:00Z", "2024-10-30T11:57:00Z"), nil).Once()
+	client.On("GetSearchResults", "123", 2, 2).Return(testSearchResults("2024-10-30T11:54:00Z", "2024-10-30T11:53:59Z"), nil).Once()
+	client.On("GetSearchResults", "123", 4, 2).Return(SearchResults{}, nil).Once()
+
+	err := rcvr.runWindow(context.Background(), search, now)
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	// event before the window start is dropped
+	require.Equal(t, 3, sink.LogRecordCount())
+	require.Equal(t, time.Date(2024, 10, 30, 11, 59, 0, 0, time.UTC), rcvr.checkpointRecord.Windows[search.Query].HighWaterMark)
+	require.Nil(t, rcvr.checkpointRecord.Windows[search.Query].WindowEnd)
+
+	// next window starts at the high-water mark
+	client.On("CreateSearchJob", `search index=otel starttime="2024-10-30T11:59:00Z" endtime="2024-10-30T12:04:00Z" timeformat="%Y-%m-%dT%H:%M:%S"`).Return(CreateJobResponse{SID: "456"}, nil).Once()
+	client.On("GetJobStatus", "456").Return(client.loadTestStatusResponse(t, statusFile), nil)
+	client.On("GetSearchResults", "456", 0, 2).Return(testSearchResults("2024-10-30T12:04:00Z"), nil).Once()
+
+	err = rcvr.runWindow(context.Background(), search, now.Add(5*time.Minute))
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	// event on the window end belongs to the next window
+	require.Equal(t, 3, sink.LogRecordCount())
+	require.Equal(t, time.Date(2024, 10, 30, 12, 4, 0, 0, time.UTC), rcvr.checkpointRecord.Windows[search.Query].HighWaterMark)
+}
+
+func TestRunWindowResume(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.JobPollInterval = 10 * time.Millisecond
+	search := Search{
+		Query:          "search index=otel",
+		Interval:       5 * time.Minute,
+		EventBatchSize: 2,
+	}
+
+	sink := &consumertest.LogsSink{}
+	rcvr := newSSAPIReceiver(logger, cfg, settings, id)
+	rcvr.logsConsumer = sink
+	mockStorage := &mockStorage{}
+	mockStorage.On("Set", mock.Anything, eventStorageKey, mock.Anything).Return(nil)
+	mockStorage.Value = []byte(`{"offset":0,"search":"","windows":{"search index=otel":{"high_water_mark":"2024-10-30T11:00:00Z","window_end":"2024-10-30T11:05:00Z","offset":2}}}`)
+	rcvr.storageClient = mockStorage
+	require.NoError(t, rcvr.loadCheckpoint(context.Background()))
+	client := &mockLogsClient{}
+	rcvr.client = client
+
+	// the partially exported window is resumed from its offset rather than a new window being created
+	statusFile := filepath.Join("testdata", "logs", "testPollJobStatus", "input-done.xml")
+	client.On("CreateSearchJob", `search index=otel starttime="2024-10-30T11:00:00Z" endtime="2024-10-30T11:05:00Z" timeformat="%Y-%m-%dT%H:%M:%S"`).Return(CreateJobResponse{SID: "123"}, nil).Once()
+	client.On("GetJobStatus", "123").Return(client.loadTestStatusResponse(t, statusFile), nil)
+	client.On("GetSearchResults", "123", 2, 2).Return(testSearchResults("2024-10-30T11:01:00Z"), nil).Once()
+
+	err := rcvr.runWindow(context.Background(), search, time.Date(2024, 10, 30, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	client.AssertExpectations(t)
+	require.Equal(t, 1, sink.LogRecordCount())
+	require.Equal(t, time.Date(2024, 10, 30, 11, 5, 0, 0, time.UTC), rcvr.checkpointRecord.Windows[search.Query].HighWaterMark)
+	require.Contains(t, string(mockStorage.Value), `"high_water_mark":"2024-10-30T11:05:00Z"`)
+}
+
+func TestRunQueriesExport(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Searches = []Search{
+		{
+			Query:             "search index=otel",
+			EarliestTime:      "2024-11-14T00:00:00.000Z",
+			LatestTime:        "2024-11-14T23:59:59.000Z",
+			EventBatchSize:    2,
+			UseExportEndpoint: true,
+		},
+	}
+	offset, exportedEvents, limitReached = 0, 0, false
+	defer func() { offset, exportedEvents, limitReached = 0, 0, false }()
+
+	sink := &consumertest.LogsSink{}
+	rcvr := newSSAPIReceiver(logger, cfg, settings, id)
+	rcvr.logsConsumer = sink
+	mockStorage := &mockStorage{}
+	mockStorage.On("Set", mock.Anything, eventStorageKey, mock.Anything).Return(nil)
+	rcvr.storageClient = mockStorage
+	client := &mockLogsClient{}
+	rcvr.client = client
+	client.On("ExportSearch", mock.Anything, `search index=otel starttime="2024-11-14T00:00:00.000Z" endtime="2024-11-14T23:59:59.000Z" timeformat="%Y-%m-%dT%H:%M:%S"`).Return(splunkExportResults, nil).Once()
+
+	rcvr.runQueries(context.Background())
+	client.AssertExpectations(t)
+
+	// preview results are dropped, and results are grouped by host and index
+	require.Equal(t, 3, sink.LogRecordCount())
+	require.Equal(t, 3, rcvr.checkpointRecord.Offset)
+	first := sink.AllLogs()[0]
+	require.Equal(t, 2, first.ResourceLogs().Len())
+	host, ok := first.ResourceLogs().At(0).Resource().Attributes().Get("host.name")
+	require.True(t, ok)
+	require.Equal(t, "web-1", host.Str())
+	index, ok := first.ResourceLogs().At(0).Resource().Attributes().Get("com.splunk.index")
+	require.True(t, ok)
+	require.Equal(t, "otel", index.Str())
+	require.Equal(t, "Hello, world!", first.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestExportResultReaderSkip(t *testing.T) {
+	body := io.NopCloser(bytes.NewReader(splunkExportResults))
+	reader := &exportResultReader{body: body, decoder: json.NewDecoder(body), batchSize: 5}
+	require.NoError(t, reader.skip(2))
+	results, err := reader.next()
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "Last, world!", results[0]["_raw"])
+	reader.close()
+}
+
+func testSearchResults(times ...string) SearchResults {
+	var results SearchResults
+	for _, ts := range times {
+		results.Results = append(results.Results, SearchResult{"_raw": "event at " + ts, "_time": ts})
+	}
+	return results
+}
+
 type mockLogsClient struct {
 	mock.Mock
 }
@@ -195,6 +342,14 @@ func (m *mockLogsClient) GetSearchResults(searchID string, offset int, batchSize
 	return args.Get(0).(SearchResults), args.Error(1)
 }
 
+func (m *mockLogsClient) ExportSearch(ctx context.Context, searchQuery string) (io.ReadCloser, error) {
+	args := m.Called(ctx, searchQuery)
+	if body, ok := args.Get(0).([]byte); ok {
+		return io.NopCloser(bytes.NewReader(body)), args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
 type mockStorage struct {
 	mock.Mock
 	Key   string
@@ -0,0 +1,146 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signature verifies the detached signatures of release artifacts
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	_ "embed"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// FileExtension is the extension of a detached signature file, written next to the artifact it signs
+const FileExtension = ".sig"
+
+// ErrMissingSignature is returned when there is no signature to verify
+var ErrMissingSignature = errors.New("missing signature")
+
+// ErrInvalidSignature is returned when the signature was not made by any of the trusted keys
+var ErrInvalidSignature = errors.New("signature does not match any trusted key")
+
+// releaseKey is the public key release artifacts are signed with
+//
+//go:embed bp_agent_key.pub
+var releaseKey []byte
+
+// Verifier checks detached signatures against a set of trusted public keys.
+// Any of the keys may have made the signature so keys can be rotated by trusting both the old and new key.
+type Verifier struct {
+	keys []*ecdsa.PublicKey
+}
+
+// NewVerifier creates a Verifier trusting the embedded release key and the PEM encoded keys at keyPaths
+func NewVerifier(keyPaths ...string) (*Verifier, error) {
+	v := &Verifier{}
+	if err := v.AddKey(releaseKey); err != nil {
+		return nil, fmt.Errorf("failed to load release key: %w", err)
+	}
+
+	for _, keyPath := range keyPaths {
+		keyPathClean := filepath.Clean(keyPath)
+		data, err := os.ReadFile(keyPathClean)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", keyPath, err)
+		}
+
+		if err := v.AddKey(data); err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", keyPath, err)
+		}
+	}
+
+	return v, nil
+}
+
+// AddKey trusts every PEM encoded ECDSA public key in data
+func (v *Verifier) AddKey(data []byte) error {
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("failed to parse public key: %w", err)
+		}
+
+		ecdsaKey, ok := pub.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("unsupported public key type %T", pub)
+		}
+
+		v.keys = append(v.keys, ecdsaKey)
+		found = true
+	}
+
+	if !found {
+		return errors.New("no PEM encoded public key found")
+	}
+	return nil
+}
+
+// Verify checks that sig is a signature of the contents of r by one of the trusted keys.
+// The signature is an ASN.1 encoded ECDSA signature of the SHA-256 digest, raw or base64 encoded as written by cosign.
+func (v *Verifier) Verify(r io.Reader, sig []byte) error {
+	trimmed := bytes.TrimSpace(sig)
+	if len(trimmed) == 0 {
+		return ErrMissingSignature
+	}
+
+	// cosign writes base64 encoded signatures, also accept the raw ASN.1 encoding
+	sigs := [][]byte{sig}
+	if decoded, err := base64.StdEncoding.DecodeString(string(trimmed)); err == nil {
+		sigs = append([][]byte{decoded}, sigs...)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
+		return fmt.Errorf("failed to calculate digest: %w", err)
+	}
+	digest := hash.Sum(nil)
+
+	for _, key := range v.keys {
+		for _, s := range sigs {
+			if ecdsa.VerifyASN1(key, digest, s) {
+				return nil
+			}
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// VerifyFile checks that sig is a signature of the file at path by one of the trusted keys
+func (v *Verifier) VerifyFile(path string, sig []byte) error {
+	pathClean := filepath.Clean(path)
+	f, err := os.Open(pathClean)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	return v.Verify(f, sig)
+}
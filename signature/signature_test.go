@@ -0,0 +1,158 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestKey generates a key pair, writing the PEM encoded public key into dir
+func newTestKey(t *testing.T, dir string) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(dir, "key.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	return priv, keyPath
+}
+
+// sign creates a raw ASN.1 signature of data with priv
+func sign(t *testing.T, priv *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	require.NoError(t, err)
+	return sig
+}
+
+func TestNewVerifier(t *testing.T) {
+	t.Run("Release key only", func(t *testing.T) {
+		v, err := NewVerifier()
+		require.NoError(t, err)
+		require.Len(t, v.keys, 1)
+	})
+
+	t.Run("Configured key", func(t *testing.T) {
+		_, keyPath := newTestKey(t, t.TempDir())
+
+		v, err := NewVerifier(keyPath)
+		require.NoError(t, err)
+		require.Len(t, v.keys, 2)
+	})
+
+	t.Run("Missing key file", func(t *testing.T) {
+		_, err := NewVerifier(filepath.Join(t.TempDir(), "missing.pub"))
+		require.ErrorContains(t, err, "failed to read key")
+	})
+
+	t.Run("Not a PEM file", func(t *testing.T) {
+		keyPath := filepath.Join(t.TempDir(), "key.pub")
+		require.NoError(t, os.WriteFile(keyPath, []byte("not a key"), 0600))
+
+		_, err := NewVerifier(keyPath)
+		require.ErrorContains(t, err, "no PEM encoded public key found")
+	})
+}
+
+func TestVerify(t *testing.T) {
+	data := []byte("observiq-otel-collector archive")
+
+	priv, keyPath := newTestKey(t, t.TempDir())
+	rotatedPriv, rotatedKeyPath := newTestKey(t, t.TempDir())
+	untrustedPriv, _ := newTestKey(t, t.TempDir())
+
+	v, err := NewVerifier(keyPath, rotatedKeyPath)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		sig         []byte
+		expectedErr error
+	}{
+		{
+			desc: "Raw signature",
+			sig:  sign(t, priv, data),
+		},
+		{
+			desc: "Base64 signature",
+			sig:  []byte(base64.StdEncoding.EncodeToString(sign(t, priv, data)) + "\n"),
+		},
+		{
+			desc: "Rotated key",
+			sig:  []byte(base64.StdEncoding.EncodeToString(sign(t, rotatedPriv, data))),
+		},
+		{
+			desc:        "Untrusted key",
+			sig:         sign(t, untrustedPriv, data),
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			desc:        "Signature of other data",
+			sig:         sign(t, priv, []byte("other")),
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			desc:        "Missing signature",
+			sig:         []byte("\n"),
+			expectedErr: ErrMissingSignature,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := v.Verify(bytes.NewReader(data), tc.sig)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestVerifyFile(t *testing.T) {
+	dir := t.TempDir()
+	priv, keyPath := newTestKey(t, dir)
+
+	v, err := NewVerifier(keyPath)
+	require.NoError(t, err)
+
+	data := []byte("observiq-otel-collector archive")
+	artifactPath := filepath.Join(dir, "artifact.tar.gz")
+	require.NoError(t, os.WriteFile(artifactPath, data, 0600))
+
+	require.NoError(t, v.VerifyFile(artifactPath, sign(t, priv, data)))
+
+	err = v.VerifyFile(filepath.Join(dir, "missing.tar.gz"), sign(t, priv, data))
+	require.ErrorContains(t, err, "failed to open file")
+}
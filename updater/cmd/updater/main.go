@@ -29,6 +29,7 @@ import (
 
 func main() {
 	var showVersion = pflag.BoolP("version", "v", false, "Prints the version of the updater and exits, if specified.")
+	var dryRun = pflag.Bool("dry-run", false, "Plans the update and saves the plan to the package status file, without stopping the service or changing the installation.")
 	pflag.Parse()
 
 	if *showVersion {
@@ -51,6 +52,20 @@ func main() {
 		log.Fatalf("Failed to create logger: %s\n", err)
 	}
 
+	if *dryRun {
+		dryRunUpdater, err := updater.NewDryRunUpdater(logger, installDir)
+		if err != nil {
+			logger.Fatal("Failed to create updater", zap.Error(err))
+		}
+
+		if err := dryRunUpdater.DryRun(); err != nil {
+			logger.Fatal("Failed to dry run update", zap.Error(err))
+		}
+
+		logger.Info("Updater dry run finished successfully")
+		return
+	}
+
 	updater, err := updater.NewUpdater(logger, installDir)
 	if err != nil {
 		logger.Fatal("Failed to create updater", zap.Error(err))
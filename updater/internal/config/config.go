@@ -0,0 +1,98 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package config reads the settings the updater uses from the agent's manager config
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/updater/internal/path"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultReconnectTimeout is how long to wait for the new collector to reconnect to the server
+	DefaultReconnectTimeout = 10 * time.Second
+
+	// DefaultHealthEndpoint is the default endpoint of the collector's health check extension
+	DefaultHealthEndpoint = "http://localhost:13133/"
+)
+
+// ManagerConfig holds the fields of the manager config used by the updater
+type ManagerConfig struct {
+	// PackageSigningKeys are paths to additional keys trusted to sign the archive
+	PackageSigningKeys []string `yaml:"package_signing_keys"`
+
+	// UpdateHealthCheck configures the checks made before an update is committed
+	UpdateHealthCheck UpdateHealthCheck `yaml:"update_health_check"`
+}
+
+// UpdateHealthCheck configures the checks the new collector must pass before an update is committed
+type UpdateHealthCheck struct {
+	// ReconnectTimeout is how long to wait for the new collector to reconnect to the server
+	ReconnectTimeout time.Duration `yaml:"reconnect_timeout"`
+
+	// Window is how long the new collector is watched after it reconnects. Zero disables the health endpoint checks.
+	Window time.Duration `yaml:"window"`
+
+	// Endpoint is the collector's health check endpoint polled during the window
+	Endpoint string `yaml:"endpoint"`
+
+	// MaxRestarts is how many times the collector may restart during the window before it's considered crash looping
+	MaxRestarts int `yaml:"max_restarts"`
+}
+
+// LoadManagerConfig reads the manager config of the installation, filling in defaults.
+// A missing manager config is not an error, the defaults are returned.
+func LoadManagerConfig(installDir string) (*ManagerConfig, error) {
+	cfg := &ManagerConfig{}
+
+	managerPath := filepath.Clean(path.ManagerConfigFile(installDir))
+	data, err := os.ReadFile(managerPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read manager config: %w", err)
+	default:
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse manager config: %w", err)
+		}
+	}
+
+	cfg.setDefaults()
+	return cfg, nil
+}
+
+// DefaultManagerConfig returns the config used when the manager config has no updater fields.
+// The health check window is disabled.
+func DefaultManagerConfig() *ManagerConfig {
+	cfg := &ManagerConfig{}
+	cfg.setDefaults()
+	return cfg
+}
+
+// setDefaults fills in the fields that weren't set
+func (cfg *ManagerConfig) setDefaults() {
+	if cfg.UpdateHealthCheck.ReconnectTimeout <= 0 {
+		cfg.UpdateHealthCheck.ReconnectTimeout = DefaultReconnectTimeout
+	}
+	if cfg.UpdateHealthCheck.Endpoint == "" {
+		cfg.UpdateHealthCheck.Endpoint = DefaultHealthEndpoint
+	}
+}
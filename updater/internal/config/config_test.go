@@ -0,0 +1,84 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/updater/internal/path"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadManagerConfig(t *testing.T) {
+	t.Run("Missing manager config", func(t *testing.T) {
+		cfg, err := LoadManagerConfig(t.TempDir())
+		require.NoError(t, err)
+		require.Equal(t, &ManagerConfig{
+			UpdateHealthCheck: UpdateHealthCheck{
+				ReconnectTimeout: DefaultReconnectTimeout,
+				Endpoint:         DefaultHealthEndpoint,
+			},
+		}, cfg)
+		require.Equal(t, DefaultManagerConfig(), cfg)
+	})
+
+	t.Run("Manager config without updater fields", func(t *testing.T) {
+		installDir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.ManagerConfigFile(installDir), []byte("endpoint: ws://localhost:3001/v1/opamp\n"), 0600))
+
+		cfg, err := LoadManagerConfig(installDir)
+		require.NoError(t, err)
+		require.Empty(t, cfg.PackageSigningKeys)
+		require.Equal(t, DefaultReconnectTimeout, cfg.UpdateHealthCheck.ReconnectTimeout)
+		require.Equal(t, DefaultHealthEndpoint, cfg.UpdateHealthCheck.Endpoint)
+		require.Zero(t, cfg.UpdateHealthCheck.Window)
+	})
+
+	t.Run("Manager config with updater fields", func(t *testing.T) {
+		installDir := t.TempDir()
+		managerConfig := `endpoint: ws://localhost:3001/v1/opamp
+package_signing_keys:
+  - /etc/keys/signing_key.pub
+update_health_check:
+  reconnect_timeout: 30s
+  window: 5m
+  endpoint: http://localhost:8080/health
+  max_restarts: 2
+`
+		require.NoError(t, os.WriteFile(path.ManagerConfigFile(installDir), []byte(managerConfig), 0600))
+
+		cfg, err := LoadManagerConfig(installDir)
+		require.NoError(t, err)
+		require.Equal(t, &ManagerConfig{
+			PackageSigningKeys: []string{"/etc/keys/signing_key.pub"},
+			UpdateHealthCheck: UpdateHealthCheck{
+				ReconnectTimeout: 30 * time.Second,
+				Window:           5 * time.Minute,
+				Endpoint:         "http://localhost:8080/health",
+				MaxRestarts:      2,
+			},
+		}, cfg)
+	})
+
+	t.Run("Invalid manager config", func(t *testing.T) {
+		installDir := t.TempDir()
+		require.NoError(t, os.WriteFile(path.ManagerConfigFile(installDir), []byte("update_health_check: {"), 0600))
+
+		_, err := LoadManagerConfig(installDir)
+		require.ErrorContains(t, err, "failed to parse manager config")
+	})
+}
@@ -0,0 +1,156 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package health checks the updated collector stays healthy before an update is committed
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/updater/internal/config"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrCrashLoop is the error when the collector restarts more than allowed during the window
+	ErrCrashLoop = errors.New("collector is crash looping")
+
+	// ErrUnhealthy is the error when the collector's health endpoint is not OK at the end of the window
+	ErrUnhealthy = errors.New("collector is not healthy")
+)
+
+// pollInterval is how often the health endpoint is polled during the window
+var pollInterval = 5 * time.Second
+
+// Checker checks the health of the collector after it's updated
+//
+//go:generate mockery --name Checker --filename mock_checker.go --structname MockChecker
+type Checker interface {
+	// Check watches the collector for the health check window.
+	// It returns an error if the collector crash loops or is unhealthy at the end of the window.
+	Check(ctx context.Context) error
+}
+
+// EndpointChecker polls the collector's health check extension during the window
+type EndpointChecker struct {
+	cfg    config.UpdateHealthCheck
+	client *http.Client
+	logger *zap.Logger
+}
+
+// NewChecker creates a new Checker for the health check config
+func NewChecker(logger *zap.Logger, cfg config.UpdateHealthCheck) Checker {
+	return &EndpointChecker{
+		cfg:    cfg,
+		client: &http.Client{Timeout: pollInterval},
+		logger: logger.Named("health-checker"),
+	}
+}
+
+// healthResponse is the body returned by the health check extension
+type healthResponse struct {
+	UpSince time.Time `json:"upSince"`
+}
+
+// Check polls the health endpoint until the end of the window, when it must be OK.
+// A failed poll after the collector was up, or a change in the time it has been up since, is counted as a restart.
+// Failed polls before the collector is first up are treated as it starting.
+func (e *EndpointChecker) Check(ctx context.Context) error {
+	if e.cfg.Window <= 0 {
+		return nil
+	}
+
+	e.logger.Info("Watching collector health", zap.Duration("window", e.cfg.Window), zap.String("endpoint", e.cfg.Endpoint))
+
+	windowCtx, cancel := context.WithTimeout(ctx, e.cfg.Window)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var upSince time.Time
+	up := false
+	restarts := 0
+	for {
+		select {
+		case <-windowCtx.Done():
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			// The collector must be healthy at the end of the window
+			if _, err := e.poll(ctx); err != nil {
+				return fmt.Errorf("%w: %w", ErrUnhealthy, err)
+			}
+			return nil
+		case <-ticker.C:
+			since, err := e.poll(ctx)
+			var restarted bool
+			if err != nil {
+				e.logger.Debug("Collector health check failed", zap.Error(err))
+				// Going down is counted, coming back up is part of the same restart
+				restarted = up
+				up = false
+			} else {
+				restarted = up && !since.Equal(upSince)
+				up = true
+				upSince = since
+			}
+
+			if restarted {
+				restarts++
+				e.logger.Warn("Collector restarted during health check window", zap.Int("restarts", restarts))
+				if restarts > e.cfg.MaxRestarts {
+					return fmt.Errorf("%w: restarted %d times", ErrCrashLoop, restarts)
+				}
+			}
+		}
+	}
+}
+
+// poll requests the health endpoint, returning the time the collector has been up since
+func (e *EndpointChecker) poll(ctx context.Context) (time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.cfg.Endpoint, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to reach health endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read health response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return time.Time{}, fmt.Errorf("health endpoint returned status %d", resp.StatusCode)
+	}
+
+	var health healthResponse
+	if err := json.Unmarshal(body, &health); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse health response: %w", err)
+	}
+
+	return health.UpSince, nil
+}
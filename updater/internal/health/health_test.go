@@ -0,0 +1,162 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-otel-collector/updater/internal/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newHealthServer creates a health endpoint reporting the up since time and status returned by handle
+func newHealthServer(t *testing.T, handle func(poll int64) (time.Time, int)) *httptest.Server {
+	t.Helper()
+
+	var polls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		upSince, status := handle(polls.Add(1))
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"status":"Server available","upSince":%q,"uptime":"1s"}`, upSince.Format(time.RFC3339Nano))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func setPollInterval(t *testing.T, interval time.Duration) {
+	t.Helper()
+
+	previous := pollInterval
+	pollInterval = interval
+	t.Cleanup(func() { pollInterval = previous })
+}
+
+func TestEndpointCheckerCheck(t *testing.T) {
+	setPollInterval(t, 10*time.Millisecond)
+	start := time.Now()
+
+	t.Run("Window disabled", func(t *testing.T) {
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: "http://localhost:0/"})
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("Healthy", func(t *testing.T) {
+		srv := newHealthServer(t, func(int64) (time.Time, int) {
+			return start, http.StatusOK
+		})
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: 100 * time.Millisecond})
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("Crash loop", func(t *testing.T) {
+		srv := newHealthServer(t, func(poll int64) (time.Time, int) {
+			return start.Add(time.Duration(poll) * time.Second), http.StatusOK
+		})
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: time.Second})
+		require.ErrorIs(t, checker.Check(context.Background()), ErrCrashLoop)
+	})
+
+	t.Run("Restarts within allowance", func(t *testing.T) {
+		srv := newHealthServer(t, func(poll int64) (time.Time, int) {
+			if poll < 3 {
+				return start, http.StatusOK
+			}
+			return start.Add(time.Second), http.StatusOK
+		})
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: 100 * time.Millisecond, MaxRestarts: 1})
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("Going down counts as a restart", func(t *testing.T) {
+		srv := newHealthServer(t, func(poll int64) (time.Time, int) {
+			if poll%2 == 0 {
+				return start, http.StatusServiceUnavailable
+			}
+			return start, http.StatusOK
+		})
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: time.Second, MaxRestarts: 1})
+		require.ErrorIs(t, checker.Check(context.Background()), ErrCrashLoop)
+	})
+
+	t.Run("Outage counts as one restart", func(t *testing.T) {
+		srv := newHealthServer(t, func(poll int64) (time.Time, int) {
+			switch {
+			case poll < 3:
+				return start, http.StatusOK
+			case poll < 6:
+				return start, http.StatusServiceUnavailable
+			default:
+				return start.Add(time.Second), http.StatusOK
+			}
+		})
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: 200 * time.Millisecond, MaxRestarts: 1})
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("Failures while starting are not restarts", func(t *testing.T) {
+		srv := newHealthServer(t, func(poll int64) (time.Time, int) {
+			if poll < 3 {
+				return start, http.StatusServiceUnavailable
+			}
+			return start, http.StatusOK
+		})
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: 100 * time.Millisecond})
+		require.NoError(t, checker.Check(context.Background()))
+	})
+
+	t.Run("Unhealthy at end of window", func(t *testing.T) {
+		srv := newHealthServer(t, func(int64) (time.Time, int) {
+			return start, http.StatusInternalServerError
+		})
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: 50 * time.Millisecond})
+		require.ErrorIs(t, checker.Check(context.Background()), ErrUnhealthy)
+	})
+
+	t.Run("Endpoint unreachable", func(t *testing.T) {
+		srv := newHealthServer(t, func(int64) (time.Time, int) {
+			return start, http.StatusOK
+		})
+		srv.Close()
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: 50 * time.Millisecond})
+		require.ErrorIs(t, checker.Check(context.Background()), ErrUnhealthy)
+	})
+
+	t.Run("Context canceled", func(t *testing.T) {
+		srv := newHealthServer(t, func(int64) (time.Time, int) {
+			return start, http.StatusOK
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		checker := NewChecker(zap.NewNop(), config.UpdateHealthCheck{Endpoint: srv.URL, Window: time.Minute})
+		require.ErrorIs(t, checker.Check(ctx), context.Canceled)
+	})
+}
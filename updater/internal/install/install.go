@@ -16,12 +16,16 @@
 package install
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 
+	"github.com/observiq/bindplane-otel-collector/packagestate"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/action"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/file"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/path"
@@ -36,6 +40,9 @@ import (
 type Installer interface {
 	// Install installs new artifacts over the old ones.
 	Install(rollback.Rollbacker) error
+
+	// Plan returns the files Install would write and validates the new service configuration, without changing anything.
+	Plan() (*packagestate.UpdatePlan, error)
 }
 
 // archiveInstaller allows you to install files from latestDir into installDir,
@@ -92,6 +99,113 @@ func (i archiveInstaller) Install(rb rollback.Rollbacker) error {
 	return nil
 }
 
+// Plan walks the unpacked artifacts in latestDir the same way Install does, recording where each file would be written
+// and whether it would create, replace, or leave the file unchanged. Config files are skipped, as they are by Install.
+// The installer never deletes files, so files missing from the new artifacts are left in place.
+func (i archiveInstaller) Plan() (*packagestate.UpdatePlan, error) {
+	plan := &packagestate.UpdatePlan{}
+
+	// The JMX jar is installed outside of the install directory if it's already there
+	latestJarPath := path.LatestJMXJarFile(i.latestDir)
+	jarPath := path.SpecialJMXJarFile(i.installDir)
+	specialJar := false
+	_, err := os.Stat(jarPath)
+	switch {
+	case err == nil:
+		specialJar = true
+		change, err := planFile(latestJarPath, jarPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan special JMX jar: %w", err)
+		}
+		plan.Files = append(plan.Files, *change)
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("failed determine where currently installed JMX jar is: %w", err)
+	}
+
+	err = filepath.WalkDir(i.latestDir, func(inPath string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir():
+			return nil
+		case skipConfigFiles(inPath):
+			return nil
+		case specialJar && inPath == latestJarPath:
+			// Already planned, Install removes it from latestDir once it's copied
+			return nil
+		}
+
+		relPath, err := filepath.Rel(i.latestDir, inPath)
+		if err != nil {
+			return err
+		}
+
+		change, err := planFile(inPath, filepath.Join(i.installDir, relPath))
+		if err != nil {
+			return err
+		}
+		plan.Files = append(plan.Files, *change)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk latest dir: %w", err)
+	}
+
+	plan.ServiceChanged, err = i.svc.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate service: %w", err)
+	}
+
+	return plan, nil
+}
+
+// planFile determines what copying the file at inPath to outPath would do
+func planFile(inPath, outPath string) (*packagestate.FileChange, error) {
+	change := &packagestate.FileChange{Path: outPath}
+
+	_, err := os.Stat(outPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		change.Action = packagestate.FileActionCreate
+		return change, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to stat %s: %w", outPath, err)
+	}
+
+	inHash, err := hashFile(inPath)
+	if err != nil {
+		return nil, err
+	}
+
+	outHash, err := hashFile(outPath)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Action = packagestate.FileActionReplace
+	if bytes.Equal(inHash, outHash) {
+		change.Action = packagestate.FileActionUnchanged
+	}
+
+	return change, nil
+}
+
+// hashFile returns the SHA-256 hash of the file's contents
+func hashFile(filePath string) ([]byte, error) {
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+
+	return h.Sum(nil), nil
+}
+
 // installFiles moves the file tree rooted at inputPath to installDir,
 // skipping configuration files. Appends CopyFileAction-s to the Rollbacker as it copies file.
 func installFiles(logger *zap.Logger, inputPath, installDir, backupDir string, rb rollback.Rollbacker) error {
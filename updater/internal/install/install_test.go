@@ -22,6 +22,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/observiq/bindplane-otel-collector/packagestate"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/action"
 	rb_mocks "github.com/observiq/bindplane-otel-collector/updater/internal/rollback/mocks"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/service/mocks"
@@ -364,6 +365,126 @@ func TestInstallArtifacts(t *testing.T) {
 	})
 }
 
+func TestInstallerPlan(t *testing.T) {
+	// writeFiles writes the files, keyed by path relative to dir
+	writeFiles := func(t *testing.T, dir string, files map[string]string) {
+		t.Helper()
+		for relPath, contents := range files {
+			filePath := filepath.Join(dir, relPath)
+			require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0750))
+			require.NoError(t, os.WriteFile(filePath, []byte(contents), 0600))
+		}
+	}
+
+	t.Run("Plans artifacts without installing", func(t *testing.T) {
+		latestDir := t.TempDir()
+		installDir := filepath.Join(t.TempDir(), "installdir")
+		writeFiles(t, latestDir, map[string]string{
+			"test.txt":                     "# The new test file",
+			"same.txt":                     "# The same file",
+			filepath.Join("plugins", "a"):  "# A new plugin",
+			"config.yaml":                  "# The new config file",
+			filepath.Join("install", "sv"): "# The new service file",
+		})
+		writeFiles(t, installDir, map[string]string{
+			"test.txt":    "# The original test file",
+			"same.txt":    "# The same file",
+			"config.yaml": "# The original config file",
+		})
+
+		svc := mocks.NewMockService(t)
+		svc.On("Validate").Once().Return(true, nil)
+
+		installer := &archiveInstaller{
+			latestDir:  latestDir,
+			installDir: installDir,
+			svc:        svc,
+			logger:     zaptest.NewLogger(t),
+		}
+
+		plan, err := installer.Plan()
+		require.NoError(t, err)
+		require.True(t, plan.ServiceChanged)
+		require.ElementsMatch(t, []packagestate.FileChange{
+			{Path: filepath.Join(installDir, "test.txt"), Action: packagestate.FileActionReplace},
+			{Path: filepath.Join(installDir, "same.txt"), Action: packagestate.FileActionUnchanged},
+			{Path: filepath.Join(installDir, "plugins", "a"), Action: packagestate.FileActionCreate},
+			{Path: filepath.Join(installDir, "install", "sv"), Action: packagestate.FileActionCreate},
+		}, plan.Files)
+
+		contentsEqual(t, filepath.Join(installDir, "test.txt"), "# The original test file")
+		contentsEqual(t, filepath.Join(installDir, "config.yaml"), "# The original config file")
+		require.NoDirExists(t, filepath.Join(installDir, "plugins"))
+		require.NoDirExists(t, filepath.Join(installDir, "install"))
+	})
+
+	if runtime.GOOS != "windows" {
+		t.Run("Plans linux jmx jar", func(t *testing.T) {
+			jarDir := t.TempDir()
+			installDir := filepath.Join(jarDir, "installdir")
+			latestDir := t.TempDir()
+			writeFiles(t, jarDir, map[string]string{
+				"opentelemetry-java-contrib-jmx-metrics.jar": "# The original jar file",
+			})
+			writeFiles(t, latestDir, map[string]string{
+				"opentelemetry-java-contrib-jmx-metrics.jar": "# The new jar file",
+			})
+
+			svc := mocks.NewMockService(t)
+			svc.On("Validate").Once().Return(false, nil)
+
+			installer := &archiveInstaller{
+				latestDir:  latestDir,
+				installDir: installDir,
+				svc:        svc,
+				logger:     zaptest.NewLogger(t),
+			}
+
+			plan, err := installer.Plan()
+			require.NoError(t, err)
+			require.False(t, plan.ServiceChanged)
+			require.Equal(t, []packagestate.FileChange{
+				{Path: filepath.Join(installDir, "..", "opentelemetry-java-contrib-jmx-metrics.jar"), Action: packagestate.FileActionReplace},
+			}, plan.Files)
+
+			contentsEqual(t, filepath.Join(jarDir, "opentelemetry-java-contrib-jmx-metrics.jar"), "# The original jar file")
+			require.FileExists(t, filepath.Join(latestDir, "opentelemetry-java-contrib-jmx-metrics.jar"))
+		})
+	}
+
+	t.Run("Service validation fails", func(t *testing.T) {
+		latestDir := t.TempDir()
+		writeFiles(t, latestDir, map[string]string{"test.txt": "# The new test file"})
+
+		svc := mocks.NewMockService(t)
+		svc.On("Validate").Once().Return(false, errors.New("new service file is empty"))
+
+		installer := &archiveInstaller{
+			latestDir:  latestDir,
+			installDir: filepath.Join(t.TempDir(), "installdir"),
+			svc:        svc,
+			logger:     zaptest.NewLogger(t),
+		}
+
+		plan, err := installer.Plan()
+		require.ErrorContains(t, err, "failed to validate service")
+		require.Nil(t, plan)
+	})
+
+	t.Run("Latest dir does not exist", func(t *testing.T) {
+		installer := &archiveInstaller{
+			latestDir:  filepath.Join(t.TempDir(), "latest"),
+			installDir: filepath.Join(t.TempDir(), "installdir"),
+			svc:        mocks.NewMockService(t),
+			logger:     zaptest.NewLogger(t),
+		}
+
+		plan, err := installer.Plan()
+		require.ErrorContains(t, err, "failed to walk latest dir")
+		require.Nil(t, plan)
+	})
+}
+
 func contentsEqual(t *testing.T, path, expectedContents string) {
 	t.Helper()
 
@@ -3,8 +3,10 @@
 package mocks
 
 import (
-	rollback "github.com/observiq/bindplane-otel-collector/updater/internal/rollback"
+	packagestate "github.com/observiq/bindplane-otel-collector/packagestate"
 	mock "github.com/stretchr/testify/mock"
+
+	rollback "github.com/observiq/bindplane-otel-collector/updater/internal/rollback"
 )
 
 // MockInstaller is an autogenerated mock type for the Installer type
@@ -30,6 +32,36 @@ func (_m *MockInstaller) Install(_a0 rollback.Rollbacker) error {
 	return r0
 }
 
+// Plan provides a mock function with no fields
+func (_m *MockInstaller) Plan() (*packagestate.UpdatePlan, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Plan")
+	}
+
+	var r0 *packagestate.UpdatePlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*packagestate.UpdatePlan, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *packagestate.UpdatePlan); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*packagestate.UpdatePlan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockInstaller creates a new instance of MockInstaller. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockInstaller(t interface {
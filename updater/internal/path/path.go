@@ -34,6 +34,11 @@ func BackupDir(installDir string) string {
 	return filepath.Join(TempDir(installDir), "rollback")
 }
 
+// DryRunBackupDir gets the path to the "dry-run" dir, where a dry run backs up current artifacts.
+func DryRunBackupDir(installDir string) string {
+	return filepath.Join(TempDir(installDir), "dry-run")
+}
+
 // ServiceFileDir gets the directory of the service file definitions
 func ServiceFileDir(installDir string) string {
 	return filepath.Join(installDir, "install")
@@ -64,3 +69,8 @@ func LatestJMXJarFile(latestDir string) string {
 func SpecialJMXJarFile(installDir string) string {
 	return filepath.Join(SpecialJarDir(installDir), "opentelemetry-java-contrib-jmx-metrics.jar")
 }
+
+// ManagerConfigFile returns the full path to the manager config of the installation
+func ManagerConfigFile(installDir string) string {
+	return filepath.Join(installDir, "manager.yaml")
+}
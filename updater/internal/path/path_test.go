@@ -33,6 +33,10 @@ func TestBackupDir(t *testing.T) {
 	require.Equal(t, filepath.Join("install", "tmp", "rollback"), BackupDir("install"))
 }
 
+func TestDryRunBackupDir(t *testing.T) {
+	require.Equal(t, filepath.Join("install", "tmp", "dry-run"), DryRunBackupDir("install"))
+}
+
 func TestServiceFileDir(t *testing.T) {
 	require.Equal(t, filepath.Join("install", "install"), ServiceFileDir("install"))
 }
@@ -56,3 +60,7 @@ func TestLatestJMXJarFile(t *testing.T) {
 func TestSpecialJMXJarFile(t *testing.T) {
 	require.Equal(t, filepath.Join("install", "..", "opentelemetry-java-contrib-jmx-metrics.jar"), SpecialJMXJarFile("install"))
 }
+
+func TestManagerConfigFile(t *testing.T) {
+	require.Equal(t, filepath.Join("install", "manager.yaml"), ManagerConfigFile("install"))
+}
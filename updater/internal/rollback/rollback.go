@@ -65,6 +65,17 @@ func NewRollbacker(logger *zap.Logger, installDir string) Rollbacker {
 	}
 }
 
+// NewScratchRollbacker returns a Rollbacker that backs up the installation into backupDir, for a dry run.
+// It doesn't back up the service configuration, which always goes to the rollback dir;
+// a dry run checks the service configuration with Service.Validate instead.
+func NewScratchRollbacker(logger *zap.Logger, installDir, backupDir string) Rollbacker {
+	return &filesystemRollbacker{
+		backupDir:  backupDir,
+		installDir: installDir,
+		logger:     logger.Named("scratch-rollbacker"),
+	}
+}
+
 // AppendAction records the action that was performed, so that it may be undone later.
 func (r *filesystemRollbacker) AppendAction(action action.RollbackableAction) {
 	r.actions = append(r.actions, action)
@@ -96,8 +107,10 @@ func (r filesystemRollbacker) Backup() error {
 	}
 
 	// Backup the service configuration so we can reload it in case of rollback
-	if err := r.originalSvc.Backup(); err != nil {
-		return fmt.Errorf("failed to backup service configuration: %w", err)
+	if r.originalSvc != nil {
+		if err := r.originalSvc.Backup(); err != nil {
+			return fmt.Errorf("failed to backup service configuration: %w", err)
+		}
 	}
 
 	return nil
@@ -106,6 +106,20 @@ func TestRollbackerBackup(t *testing.T) {
 		require.NoDirExists(t, filepath.Join(outDir, "tmp-dir"))
 		require.NoFileExists(t, leftoverFile)
 	})
+
+	t.Run("Scratch rollbacker backs up files without service", func(t *testing.T) {
+		outDir := filepath.Join(t.TempDir(), "dry-run")
+		installDir := filepath.Join("testdata", "rollbacker")
+
+		rb := NewScratchRollbacker(zaptest.NewLogger(t), installDir, outDir)
+
+		err := rb.Backup()
+		require.NoError(t, err)
+
+		require.FileExists(t, filepath.Join(outDir, "some-file.txt"))
+		require.FileExists(t, filepath.Join(outDir, "plugins-dir", "plugin.txt"))
+		require.NoDirExists(t, filepath.Join(outDir, "tmp-dir"))
+	})
 }
 
 func TestRollbackerRollback(t *testing.T) {
@@ -17,6 +17,8 @@ package service
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 )
@@ -36,6 +38,10 @@ type Service interface {
 
 	// Backup backs the current service configuration
 	Backup() error
+
+	// Validate checks the new service configuration could be installed, without changing the installed one.
+	// It returns true if Update would change the installed service configuration.
+	Validate() (bool, error)
 }
 
 // replaceInstallDir replaces "[INSTALLDIR]" with the given installDir string.
@@ -44,3 +50,31 @@ func replaceInstallDir(unformattedBytes []byte, installDir string) []byte {
 	installDirClean := filepath.Clean(installDir) + string(os.PathSeparator)
 	return bytes.ReplaceAll(unformattedBytes, []byte("[INSTALLDIR]"), []byte(installDirClean))
 }
+
+// readNewServiceFile reads the new service file, failing if it is empty
+func readNewServiceFile(newServiceFilePath string) ([]byte, error) {
+	newBytes, err := os.ReadFile(filepath.Clean(newServiceFilePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read new service file: %w", err)
+	}
+
+	if len(bytes.TrimSpace(newBytes)) == 0 {
+		return nil, fmt.Errorf("new service file %s is empty", newServiceFilePath)
+	}
+
+	return newBytes, nil
+}
+
+// serviceFileChanged returns true if the installed service file differs from newBytes.
+// A missing installed service file is a change.
+func serviceFileChanged(newBytes []byte, installedServiceFilePath string) (bool, error) {
+	installedBytes, err := os.ReadFile(filepath.Clean(installedServiceFilePath))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return true, nil
+	case err != nil:
+		return false, fmt.Errorf("failed to read installed service file: %w", err)
+	}
+
+	return !bytes.Equal(newBytes, installedBytes), nil
+}
@@ -28,7 +28,8 @@ import (
 )
 
 const (
-	darwinServiceFilePath = "/Library/LaunchDaemons/com.observiq.collector.plist"
+	darwinServiceFileName = "com.observiq.collector.plist"
+	darwinServiceFilePath = "/Library/LaunchDaemons/" + darwinServiceFileName
 )
 
 // Option is an extra option for creating a Service
@@ -41,10 +42,15 @@ func WithServiceFile(svcFilePath string) Option {
 	}
 }
 
+// WithServiceFileDir returns an option setting the directory the service file to use when updating is in
+func WithServiceFileDir(svcFileDir string) Option {
+	return WithServiceFile(filepath.Join(svcFileDir, darwinServiceFileName))
+}
+
 // NewService returns an instance of the Service interface for managing the observiq-otel-collector service on the current OS.
 func NewService(logger *zap.Logger, installDir string, opts ...Option) Service {
 	darwinSvc := &darwinService{
-		newServiceFilePath:       filepath.Join(path.ServiceFileDir(installDir), "com.observiq.collector.plist"),
+		newServiceFilePath:       filepath.Join(path.ServiceFileDir(installDir), darwinServiceFileName),
 		installedServiceFilePath: darwinServiceFilePath,
 		installDir:               path.DarwinInstallDir,
 		logger:                   logger.Named("darwin-service"),
@@ -139,6 +145,16 @@ func (d darwinService) Update() error {
 	return nil
 }
 
+// Validate checks the new plist can be read, returning true if it differs from the installed one once expanded
+func (d darwinService) Validate() (bool, error) {
+	serviceFileBytes, err := readNewServiceFile(d.newServiceFilePath)
+	if err != nil {
+		return false, err
+	}
+
+	return serviceFileChanged(replaceInstallDir(serviceFileBytes, d.installDir), d.installedServiceFilePath)
+}
+
 func (d darwinService) Backup() error {
 	if err := file.CopyFileNoOverwrite(d.logger.Named("copy-file"), d.installedServiceFilePath, path.BackupServiceFile(d.installDir)); err != nil {
 		return fmt.Errorf("failed to copy service file: %w", err)
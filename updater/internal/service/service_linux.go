@@ -41,6 +41,11 @@ func WithServiceFile(svcFilePath string) Option {
 	}
 }
 
+// WithServiceFileDir returns an option setting the directory the service file to use when updating is in
+func WithServiceFileDir(svcFileDir string) Option {
+	return WithServiceFile(filepath.Join(svcFileDir, filepath.Base(path.LinuxServiceFilePath())))
+}
+
 // NewService returns an instance of the Service interface for managing the observiq-otel-collector service on the current OS.
 func NewService(logger *zap.Logger, installDir string, opts ...Option) Service {
 	// Get some information from the environment
@@ -186,6 +191,16 @@ func (l linuxSystemdService) Update() error {
 	return nil
 }
 
+// Validate checks the new unit file can be read, returning true if it differs from the installed one
+func (l linuxSystemdService) Validate() (bool, error) {
+	newBytes, err := readNewServiceFile(l.newServiceFilePath)
+	if err != nil {
+		return false, err
+	}
+
+	return serviceFileChanged(newBytes, l.installedServiceFilePath)
+}
+
 func (l linuxSystemdService) Backup() error {
 	if err := file.CopyFileNoOverwrite(l.logger.Named("copy-file"), l.installedServiceFilePath, path.BackupServiceFile(l.installDir)); err != nil {
 		return fmt.Errorf("failed to copy service file: %w", err)
@@ -294,6 +309,16 @@ func (l linuxSysVService) Update() error {
 	return nil
 }
 
+// Validate checks the new init script can be read, returning true if it differs from the installed one
+func (l linuxSysVService) Validate() (bool, error) {
+	newBytes, err := readNewServiceFile(l.newServiceFilePath)
+	if err != nil {
+		return false, err
+	}
+
+	return serviceFileChanged(newBytes, l.installedServiceFilePath)
+}
+
 func (l linuxSysVService) Backup() error {
 	if err := file.CopyFileNoOverwrite(l.logger.Named("copy-file"), l.installedServiceFilePath, path.BackupServiceFile(l.installDir)); err != nil {
 		return fmt.Errorf("failed to copy service file: %w", err)
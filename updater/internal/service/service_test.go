@@ -52,3 +52,44 @@ func TestReplaceInstallDir(t *testing.T) {
 		})
 	}
 }
+
+func TestReadNewServiceFile(t *testing.T) {
+	t.Run("Reads service file", func(t *testing.T) {
+		svcFile := filepath.Join(t.TempDir(), "service")
+		require.NoError(t, os.WriteFile(svcFile, []byte("new service"), 0600))
+
+		out, err := readNewServiceFile(svcFile)
+		require.NoError(t, err)
+		require.Equal(t, []byte("new service"), out)
+	})
+
+	t.Run("Missing service file", func(t *testing.T) {
+		_, err := readNewServiceFile(filepath.Join(t.TempDir(), "service"))
+		require.ErrorContains(t, err, "failed to read new service file")
+	})
+
+	t.Run("Empty service file", func(t *testing.T) {
+		svcFile := filepath.Join(t.TempDir(), "service")
+		require.NoError(t, os.WriteFile(svcFile, []byte("\n"), 0600))
+
+		_, err := readNewServiceFile(svcFile)
+		require.ErrorContains(t, err, "is empty")
+	})
+}
+
+func TestServiceFileChanged(t *testing.T) {
+	installedFile := filepath.Join(t.TempDir(), "installed.service")
+
+	changed, err := serviceFileChanged([]byte("new service"), installedFile)
+	require.NoError(t, err)
+	require.True(t, changed, "missing installed service file is a change")
+
+	require.NoError(t, os.WriteFile(installedFile, []byte("old service"), 0600))
+	changed, err = serviceFileChanged([]byte("new service"), installedFile)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	changed, err = serviceFileChanged([]byte("old service"), installedFile)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
@@ -36,6 +36,9 @@ const (
 	defaultProductName = "observIQ Distro for OpenTelemetry Collector"
 	defaultServiceName = "observiq-otel-collector"
 
+	// windowsServiceFileName is the name of the file defining the service config
+	windowsServiceFileName = "windows_service.json"
+
 	// defaultRecoveryDelay is the duration in which to wait between service restarts due to failures
 	defaultRecoveryDelay = 5 * time.Second
 	// defaultResetPeriod is the time in which to reset the service failure count to zero
@@ -52,10 +55,15 @@ func WithServiceFile(svcFilePath string) Option {
 	}
 }
 
+// WithServiceFileDir returns an option setting the directory the service file to use when updating is in
+func WithServiceFileDir(svcFileDir string) Option {
+	return WithServiceFile(filepath.Join(svcFileDir, windowsServiceFileName))
+}
+
 // NewService returns an instance of the Service interface for managing the observiq-otel-collector service on the current OS.
 func NewService(logger *zap.Logger, installDir string, opts ...Option) Service {
 	winSvc := &windowsService{
-		newServiceFilePath: filepath.Join(path.ServiceFileDir(installDir), "windows_service.json"),
+		newServiceFilePath: filepath.Join(path.ServiceFileDir(installDir), windowsServiceFileName),
 		serviceName:        defaultServiceName,
 		productName:        defaultProductName,
 		installDir:         installDir,
@@ -193,6 +201,27 @@ func (w windowsService) Update() error {
 	return nil
 }
 
+// Validate checks the new service config can be read and parsed, returning true if it differs from the installed service's config
+func (w windowsService) Validate() (bool, error) {
+	wsc, err := readWindowsServiceConfig(w.newServiceFilePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read service config: %w", err)
+	}
+
+	if _, _, err := winapiStartType(wsc.Service.Start); err != nil {
+		return false, fmt.Errorf("failed to parse start type in service config: %w", err)
+	}
+
+	current, err := w.currentServiceConfig()
+	if err != nil {
+		return false, fmt.Errorf("failed to construct current service config: %w", err)
+	}
+
+	// The installed service's arguments have the install directory expanded, but keep quotes as '&quot;'
+	wsc.Service.Arguments = string(replaceInstallDir([]byte(wsc.Service.Arguments), w.installDir))
+	return *wsc != *current, nil
+}
+
 func (w windowsService) Backup() error {
 
 	wsc, err := w.currentServiceConfig()
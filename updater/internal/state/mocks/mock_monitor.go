@@ -5,8 +5,11 @@ package mocks
 import (
 	context "context"
 
-	protobufs "github.com/open-telemetry/opamp-go/protobufs"
 	mock "github.com/stretchr/testify/mock"
+
+	packagestate "github.com/observiq/bindplane-otel-collector/packagestate"
+
+	protobufs "github.com/open-telemetry/opamp-go/protobufs"
 )
 
 // MockMonitor is an autogenerated mock type for the Monitor type
@@ -32,6 +35,24 @@ func (_m *MockMonitor) MonitorForSuccess(ctx context.Context, packageName string
 	return r0
 }
 
+// SetPlan provides a mock function with given fields: plan
+func (_m *MockMonitor) SetPlan(plan *packagestate.UpdatePlan) error {
+	ret := _m.Called(plan)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetPlan")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*packagestate.UpdatePlan) error); ok {
+		r0 = rf(plan)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetState provides a mock function with given fields: packageName, status, statusErr
 func (_m *MockMonitor) SetState(packageName string, status protobufs.PackageStatusEnum, statusErr error) error {
 	ret := _m.Called(packageName, status, statusErr)
@@ -43,6 +43,9 @@ type Monitor interface {
 	// MonitorForSuccess will periodically check the state of the package. It will keep checking until the context is canceled or a failed/success state is detected.
 	// It will return an error if status is Failed or if the context times out.
 	MonitorForSuccess(ctx context.Context, packageName string) error
+
+	// SetPlan saves the plan made by a dry run alongside the package statuses
+	SetPlan(plan *packagestate.UpdatePlan) error
 }
 
 // CollectorMonitor implements Monitor interface for monitoring the Collector Package Status file
@@ -94,6 +97,11 @@ func (c *CollectorMonitor) SetState(packageName string, status protobufs.Package
 	return c.stateManager.SaveStatuses(c.currentStatus)
 }
 
+// SetPlan saves the plan to the package status file
+func (c *CollectorMonitor) SetPlan(plan *packagestate.UpdatePlan) error {
+	return c.stateManager.SavePlan(plan)
+}
+
 // MonitorForSuccess intermittently checks the package status file for either an install failed or success status.
 // If an InstallFailed status is read this returns ErrFailedStatus error.
 // If the context is canceled the context error will be returned.
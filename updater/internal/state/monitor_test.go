@@ -20,6 +20,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/observiq/bindplane-otel-collector/packagestate"
 	"github.com/observiq/bindplane-otel-collector/packagestate/mocks"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
@@ -180,6 +181,54 @@ func TestCollectorMonitorSetState(t *testing.T) {
 	}
 }
 
+func TestCollectorMonitorSetPlan(t *testing.T) {
+	plan := &packagestate.UpdatePlan{
+		Files: []packagestate.FileChange{
+			{Path: "/opt/observiq-otel-collector/observiq-otel-collector", Action: packagestate.FileActionReplace},
+		},
+	}
+
+	testCases := []struct {
+		desc     string
+		testFunc func(*testing.T)
+	}{
+		{
+			desc: "Saves plan",
+			testFunc: func(t *testing.T) {
+				mockStateManger := mocks.NewMockStateManager(t)
+				mockStateManger.On("SavePlan", plan).Return(nil)
+
+				collectorMonitor := &CollectorMonitor{
+					stateManager: mockStateManger,
+				}
+
+				err := collectorMonitor.SetPlan(plan)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			desc: "StateManager fails to save",
+			testFunc: func(t *testing.T) {
+				expectedErr := errors.New("bad")
+
+				mockStateManger := mocks.NewMockStateManager(t)
+				mockStateManger.On("SavePlan", plan).Return(expectedErr)
+
+				collectorMonitor := &CollectorMonitor{
+					stateManager: mockStateManger,
+				}
+
+				err := collectorMonitor.SetPlan(plan)
+				assert.ErrorIs(t, err, expectedErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, tc.testFunc)
+	}
+}
+
 func TestCollectorMonitorMonitorForSuccess(t *testing.T) {
 	testCases := []struct {
 		desc     string
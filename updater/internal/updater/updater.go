@@ -24,11 +24,14 @@ import (
 
 	"github.com/observiq/bindplane-otel-collector/packagestate"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/action"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/config"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/health"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/install"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/path"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/rollback"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/service"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/state"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/verify"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"go.uber.org/zap"
 )
@@ -40,7 +43,12 @@ type Updater struct {
 	svc        service.Service
 	rollbacker rollback.Rollbacker
 	monitor    state.Monitor
+	verifier   verify.Verifier
+	checker    health.Checker
 	logger     *zap.Logger
+
+	// reconnectTimeout is how long to wait for the new collector to report a successful install
+	reconnectTimeout time.Duration
 }
 
 // NewUpdater creates a new updater which can be used to update the installation based at
@@ -51,19 +59,106 @@ func NewUpdater(logger *zap.Logger, installDir string) (*Updater, error) {
 		return nil, fmt.Errorf("failed to create monitor: %w", err)
 	}
 
+	// An unreadable manager config shouldn't block the update, the health checks just aren't configured
+	cfg, err := config.LoadManagerConfig(installDir)
+	if err != nil {
+		logger.Error("Failed to load manager config, using the default update health check", zap.Error(err))
+		cfg = config.DefaultManagerConfig()
+	}
+
 	svc := service.NewService(logger, installDir)
+	return &Updater{
+		installDir:       installDir,
+		installer:        install.NewInstaller(logger, installDir, svc),
+		svc:              svc,
+		rollbacker:       rollback.NewRollbacker(logger, installDir),
+		monitor:          monitor,
+		verifier:         verify.NewVerifier(logger, installDir),
+		checker:          health.NewChecker(logger, cfg.UpdateHealthCheck),
+		logger:           logger,
+		reconnectTimeout: cfg.UpdateHealthCheck.ReconnectTimeout,
+	}, nil
+}
+
+// NewDryRunUpdater creates a new updater which plans the update of the installation at installDir, without changing it.
+func NewDryRunUpdater(logger *zap.Logger, installDir string) (*Updater, error) {
+	monitor, err := state.NewCollectorMonitor(logger, installDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create monitor: %w", err)
+	}
+
+	// The new service configuration hasn't been copied into the install directory,
+	// so validate the one unpacked with the new artifacts.
+	svc := service.NewService(logger, installDir, service.WithServiceFileDir(path.ServiceFileDir(path.LatestDir(installDir))))
 	return &Updater{
 		installDir: installDir,
 		installer:  install.NewInstaller(logger, installDir, svc),
 		svc:        svc,
-		rollbacker: rollback.NewRollbacker(logger, installDir),
+		rollbacker: rollback.NewScratchRollbacker(logger, installDir, path.DryRunBackupDir(installDir)),
 		monitor:    monitor,
+		verifier:   verify.NewVerifier(logger, installDir),
 		logger:     logger,
 	}, nil
 }
 
+// DryRun plans the update of the collector without stopping the service or changing the installation.
+// The plan, or why the update would fail, is saved to the package status file.
+func (u *Updater) DryRun() error {
+	plan, err := u.plan()
+	if err != nil {
+		u.logger.Error("Update would fail", zap.Error(err))
+		plan = &packagestate.UpdatePlan{Error: err.Error()}
+	}
+
+	if setErr := u.monitor.SetPlan(plan); setErr != nil {
+		return fmt.Errorf("failed to save plan: %w", setErr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to plan update: %w", err)
+	}
+
+	for _, change := range plan.Files {
+		u.logger.Info("Planned file change", zap.String("path", change.Path), zap.String("action", string(change.Action)))
+	}
+	u.logger.Info("Dry run complete", zap.Int("files", len(plan.Files)), zap.Bool("service_changed", plan.ServiceChanged))
+	return nil
+}
+
+// plan runs the steps of Update that don't change the installation
+func (u *Updater) plan() (*packagestate.UpdatePlan, error) {
+	if err := u.verifier.Verify(); err != nil {
+		return nil, fmt.Errorf("failed to verify archive: %w", err)
+	}
+
+	// Make sure the installation can be backed up, then throw the backup away
+	defer u.removeDryRunBackupDir()
+	if err := u.rollbacker.Backup(); err != nil {
+		return nil, fmt.Errorf("failed to backup: %w", err)
+	}
+
+	plan, err := u.installer.Plan()
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan install: %w", err)
+	}
+
+	return plan, nil
+}
+
 // Update performs the update of the collector binary
 func (u *Updater) Update() error {
+	// Refuse to install an archive without a valid signature before touching the running collector
+	if err := u.verifier.Verify(); err != nil {
+		u.logger.Error("Failed to verify archive", zap.Error(err))
+
+		if setErr := u.monitor.SetState(packagestate.CollectorPackageName, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, err); setErr != nil {
+			u.logger.Error("Failed to set state on verification failure", zap.Error(setErr))
+		}
+
+		u.removeTmpDir()
+		return fmt.Errorf("failed to verify archive: %w", err)
+	}
+
 	// Stop the service before backing up the install directory;
 	// We want to stop as early as possible so that we don't hit the collector's timeout
 	// while it waits to be shutdown.
@@ -109,8 +204,9 @@ func (u *Updater) Update() error {
 		return fmt.Errorf("failed to install: %w", err)
 	}
 
-	// Create a context with timeout to wait for a success or failed status
-	checkCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	// Create a context with timeout to wait for a success or failed status.
+	// The collector reports success once it reconnects to the server with the new version.
+	checkCtx, cancel := context.WithTimeout(context.Background(), u.reconnectTimeout)
 	defer cancel()
 
 	u.logger.Debug("Installation successful, begin monitor for success")
@@ -133,6 +229,23 @@ func (u *Updater) Update() error {
 		return fmt.Errorf("failed while monitoring for success: %w", err)
 	}
 
+	u.logger.Debug("Collector reconnected, begin health check")
+
+	// Watch the new collector before committing the update
+	if err := u.checker.Check(context.Background()); err != nil {
+		u.logger.Error("Failed health check", zap.Error(err))
+
+		// The collector already reported success, so replace it with the failure
+		if setErr := u.monitor.SetState(packagestate.CollectorPackageName, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, err); setErr != nil {
+			u.logger.Error("Failed to set state on health check failure", zap.Error(setErr))
+		}
+
+		u.rollbacker.Rollback()
+
+		u.logger.Error("Rollback complete")
+		return fmt.Errorf("failed health check: %w", err)
+	}
+
 	// Successful update
 	u.logger.Info("Update Complete")
 	return nil
@@ -145,3 +258,11 @@ func (u *Updater) removeTmpDir() {
 		u.logger.Error("failed to remove temporary directory", zap.Error(err))
 	}
 }
+
+// removeDryRunBackupDir removes the scratch directory a dry run backs up to.
+func (u *Updater) removeDryRunBackupDir() {
+	err := os.RemoveAll(path.DryRunBackupDir(u.installDir))
+	if err != nil {
+		u.logger.Error("failed to remove dry run backup directory", zap.Error(err))
+	}
+}
@@ -16,15 +16,22 @@ package updater
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/observiq/bindplane-otel-collector/packagestate"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/action"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/config"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/health"
+	health_mocks "github.com/observiq/bindplane-otel-collector/updater/internal/health/mocks"
 	install_mocks "github.com/observiq/bindplane-otel-collector/updater/internal/install/mocks"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/path"
 	rollback_mocks "github.com/observiq/bindplane-otel-collector/updater/internal/rollback/mocks"
 	service_mocks "github.com/observiq/bindplane-otel-collector/updater/internal/service/mocks"
 	"github.com/observiq/bindplane-otel-collector/updater/internal/state"
 	state_mocks "github.com/observiq/bindplane-otel-collector/updater/internal/state/mocks"
+	verify_mocks "github.com/observiq/bindplane-otel-collector/updater/internal/verify/mocks"
 	"github.com/open-telemetry/opamp-go/protobufs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -43,10 +50,28 @@ func TestNewUpdater(t *testing.T) {
 		assert.NotNil(t, updater.svc)
 		assert.NotNil(t, updater.rollbacker)
 		assert.NotNil(t, updater.monitor)
+		assert.NotNil(t, updater.verifier)
+		assert.NotNil(t, updater.checker)
+		assert.Equal(t, config.DefaultReconnectTimeout, updater.reconnectTimeout)
 		assert.NotNil(t, updater.logger)
 		assert.Equal(t, installDir, updater.installDir)
 	})
 
+	t.Run("New updater uses defaults when manager config is invalid", func(t *testing.T) {
+		installDir := t.TempDir()
+		statuses, err := os.ReadFile(filepath.Join("testdata", "package_statuses.json"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(installDir, "package_statuses.json"), statuses, 0600))
+		require.NoError(t, os.WriteFile(path.ManagerConfigFile(installDir), []byte("update_health_check: [\n"), 0600))
+
+		logger := zaptest.NewLogger(t)
+		updater, err := NewUpdater(logger, installDir)
+		require.NoError(t, err)
+		require.NotNil(t, updater)
+		assert.Equal(t, config.DefaultReconnectTimeout, updater.reconnectTimeout)
+		assert.Equal(t, health.NewChecker(logger, config.DefaultManagerConfig().UpdateHealthCheck), updater.checker)
+	})
+
 	t.Run("New updater fails due to missing package statuses", func(t *testing.T) {
 		installDir := t.TempDir()
 		logger := zaptest.NewLogger(t)
@@ -56,6 +81,120 @@ func TestNewUpdater(t *testing.T) {
 	})
 }
 
+func TestNewDryRunUpdater(t *testing.T) {
+	t.Run("New dry run updater is created successfully", func(t *testing.T) {
+		installDir := "testdata"
+		logger := zaptest.NewLogger(t)
+		updater, err := NewDryRunUpdater(logger, installDir)
+		require.NoError(t, err)
+		require.NotNil(t, updater)
+		assert.NotNil(t, updater.installer)
+		assert.NotNil(t, updater.svc)
+		assert.NotNil(t, updater.rollbacker)
+		assert.NotNil(t, updater.monitor)
+		assert.NotNil(t, updater.verifier)
+		assert.NotNil(t, updater.logger)
+		assert.Equal(t, installDir, updater.installDir)
+	})
+
+	t.Run("New dry run updater fails due to missing package statuses", func(t *testing.T) {
+		installDir := t.TempDir()
+		logger := zaptest.NewLogger(t)
+		updater, err := NewDryRunUpdater(logger, installDir)
+		require.ErrorContains(t, err, "failed to create monitor")
+		require.Nil(t, updater)
+	})
+}
+
+func TestUpdaterDryRun(t *testing.T) {
+	// newDryRunUpdater creates an updater with mocks, none of which expect the service to be stopped or the installation changed
+	newDryRunUpdater := func(t *testing.T) (*Updater, *install_mocks.MockInstaller, *rollback_mocks.MockRollbacker, *state_mocks.MockMonitor, *verify_mocks.MockVerifier) {
+		installer := install_mocks.NewMockInstaller(t)
+		rollbacker := rollback_mocks.NewMockRollbacker(t)
+		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+
+		return &Updater{
+			installDir: t.TempDir(),
+			installer:  installer,
+			svc:        service_mocks.NewMockService(t),
+			rollbacker: rollbacker,
+			monitor:    monitor,
+			verifier:   verifier,
+			logger:     zaptest.NewLogger(t),
+		}, installer, rollbacker, monitor, verifier
+	}
+
+	t.Run("Dry run is successful", func(t *testing.T) {
+		updater, installer, rollbacker, monitor, verifier := newDryRunUpdater(t)
+
+		plan := &packagestate.UpdatePlan{
+			Files: []packagestate.FileChange{
+				{Path: filepath.Join(updater.installDir, "observiq-otel-collector"), Action: packagestate.FileActionReplace},
+			},
+			ServiceChanged: true,
+		}
+
+		backupDir := path.DryRunBackupDir(updater.installDir)
+		verifier.On("Verify").Times(1).Return(nil)
+		rollbacker.On("Backup").Times(1).Run(func(mock.Arguments) {
+			require.NoError(t, os.MkdirAll(backupDir, 0750))
+		}).Return(nil)
+		installer.On("Plan").Times(1).Return(plan, nil)
+		monitor.On("SetPlan", plan).Times(1).Return(nil)
+
+		err := updater.DryRun()
+		require.NoError(t, err)
+		require.NoDirExists(t, backupDir)
+	})
+
+	t.Run("Archive verification fails", func(t *testing.T) {
+		updater, _, _, monitor, verifier := newDryRunUpdater(t)
+
+		verifier.On("Verify").Times(1).Return(errors.New("signature is invalid"))
+		monitor.On("SetPlan", &packagestate.UpdatePlan{Error: "failed to verify archive: signature is invalid"}).Times(1).Return(nil)
+
+		err := updater.DryRun()
+		require.ErrorContains(t, err, "failed to verify archive")
+	})
+
+	t.Run("Backup fails", func(t *testing.T) {
+		updater, _, rollbacker, monitor, verifier := newDryRunUpdater(t)
+
+		verifier.On("Verify").Times(1).Return(nil)
+		rollbacker.On("Backup").Times(1).Return(errors.New("no space left on device"))
+		monitor.On("SetPlan", &packagestate.UpdatePlan{Error: "failed to backup: no space left on device"}).Times(1).Return(nil)
+
+		err := updater.DryRun()
+		require.ErrorContains(t, err, "failed to backup")
+	})
+
+	t.Run("Plan fails", func(t *testing.T) {
+		updater, installer, rollbacker, monitor, verifier := newDryRunUpdater(t)
+
+		verifier.On("Verify").Times(1).Return(nil)
+		rollbacker.On("Backup").Times(1).Return(nil)
+		installer.On("Plan").Times(1).Return(nil, errors.New("failed to validate service"))
+		monitor.On("SetPlan", &packagestate.UpdatePlan{Error: "failed to plan install: failed to validate service"}).Times(1).Return(nil)
+
+		err := updater.DryRun()
+		require.ErrorContains(t, err, "failed to plan install")
+	})
+
+	t.Run("Saving plan fails", func(t *testing.T) {
+		updater, installer, rollbacker, monitor, verifier := newDryRunUpdater(t)
+
+		plan := &packagestate.UpdatePlan{}
+		verifier.On("Verify").Times(1).Return(nil)
+		rollbacker.On("Backup").Times(1).Return(nil)
+		installer.On("Plan").Times(1).Return(plan, nil)
+		monitor.On("SetPlan", plan).Times(1).Return(errors.New("insufficient permissions"))
+
+		err := updater.DryRun()
+		require.ErrorContains(t, err, "failed to save plan")
+	})
+}
+
 func TestUpdaterUpdate(t *testing.T) {
 	t.Run("Update is successful", func(t *testing.T) {
 		installDir := t.TempDir()
@@ -64,6 +203,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -71,19 +212,53 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		svc.On("Stop").Times(1).Return(nil)
 		rollbacker.On("AppendAction", action.NewServiceStopAction(svc)).Times(1).Return()
 		rollbacker.On("Backup").Times(1).Return(nil)
 		installer.On("Install", rollbacker).Times(1).Return(nil)
 		monitor.On("MonitorForSuccess", mock.Anything, packagestate.CollectorPackageName).Times(1).Return(nil)
+		checker.On("Check", mock.Anything).Times(1).Return(nil)
 
 		err := updater.Update()
 		require.NoError(t, err)
 	})
 
+	t.Run("Archive verification fails", func(t *testing.T) {
+		installDir := t.TempDir()
+
+		installer := install_mocks.NewMockInstaller(t)
+		svc := service_mocks.NewMockService(t)
+		rollbacker := rollback_mocks.NewMockRollbacker(t)
+		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
+
+		updater := &Updater{
+			installDir: installDir,
+			installer:  installer,
+			svc:        svc,
+			rollbacker: rollbacker,
+			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
+			logger:     zaptest.NewLogger(t),
+		}
+
+		err := errors.New("signature does not match any trusted key")
+		verifier.On("Verify").Times(1).Return(err)
+		monitor.On("SetState", packagestate.CollectorPackageName, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, err).Times(1).Return(nil)
+
+		err = updater.Update()
+		require.ErrorContains(t, err, "failed to verify archive")
+		svc.AssertNotCalled(t, "Stop")
+	})
+
 	t.Run("Service stop fails", func(t *testing.T) {
 		installDir := t.TempDir()
 
@@ -91,6 +266,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -98,9 +275,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		svc.On("Stop").Times(1).Return(errors.New("insufficient permissions"))
 
 		err := updater.Update()
@@ -114,6 +294,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -121,9 +303,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		err := errors.New("insufficient permissions")
 
 		svc.On("Stop").Times(1).Return(nil)
@@ -143,6 +328,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -150,9 +337,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		err := errors.New("insufficient permissions")
 
 		svc.On("Stop").Times(1).Return(nil)
@@ -172,6 +362,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -179,9 +371,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		err := errors.New("insufficient permissions")
 
 		svc.On("Stop").Times(1).Return(nil)
@@ -202,6 +397,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -209,9 +406,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		err := errors.New("insufficient permissions")
 
 		svc.On("Stop").Times(1).Return(nil)
@@ -232,6 +432,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -239,9 +441,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		err := errors.New("insufficient permissions")
 
 		svc.On("Stop").Times(1).Return(nil)
@@ -263,6 +468,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -270,9 +477,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		err := errors.New("insufficient permissions")
 
 		svc.On("Stop").Times(1).Return(nil)
@@ -294,6 +504,8 @@ func TestUpdaterUpdate(t *testing.T) {
 		svc := service_mocks.NewMockService(t)
 		rollbacker := rollback_mocks.NewMockRollbacker(t)
 		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
 
 		updater := &Updater{
 			installDir: installDir,
@@ -301,9 +513,12 @@ func TestUpdaterUpdate(t *testing.T) {
 			svc:        svc,
 			rollbacker: rollbacker,
 			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
 			logger:     zaptest.NewLogger(t),
 		}
 
+		verifier.On("Verify").Times(1).Return(nil)
 		svc.On("Stop").Times(1).Return(nil)
 		rollbacker.On("AppendAction", action.NewServiceStopAction(svc)).Times(1).Return()
 		rollbacker.On("Backup").Times(1).Return(nil)
@@ -314,4 +529,80 @@ func TestUpdaterUpdate(t *testing.T) {
 		err := updater.Update()
 		require.ErrorContains(t, err, "failed while monitoring for success")
 	})
+
+	t.Run("Health check fails", func(t *testing.T) {
+		installDir := t.TempDir()
+
+		installer := install_mocks.NewMockInstaller(t)
+		svc := service_mocks.NewMockService(t)
+		rollbacker := rollback_mocks.NewMockRollbacker(t)
+		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
+
+		updater := &Updater{
+			installDir: installDir,
+			installer:  installer,
+			svc:        svc,
+			rollbacker: rollbacker,
+			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
+			logger:     zaptest.NewLogger(t),
+		}
+
+		verifier.On("Verify").Times(1).Return(nil)
+		err := health.ErrCrashLoop
+
+		svc.On("Stop").Times(1).Return(nil)
+		rollbacker.On("AppendAction", action.NewServiceStopAction(svc)).Times(1).Return()
+		rollbacker.On("Backup").Times(1).Return(nil)
+		installer.On("Install", rollbacker).Times(1).Return(nil)
+		monitor.On("MonitorForSuccess", mock.Anything, packagestate.CollectorPackageName).Times(1).Return(nil)
+		checker.On("Check", mock.Anything).Times(1).Return(err)
+		monitor.On("SetState", packagestate.CollectorPackageName, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, err).Times(1).Return(nil)
+		rollbacker.On("Rollback").Times(1).Return()
+
+		err = updater.Update()
+		require.ErrorIs(t, err, health.ErrCrashLoop)
+		require.ErrorContains(t, err, "failed health check")
+	})
+
+	t.Run("Health check fails, set state fails", func(t *testing.T) {
+		installDir := t.TempDir()
+
+		installer := install_mocks.NewMockInstaller(t)
+		svc := service_mocks.NewMockService(t)
+		rollbacker := rollback_mocks.NewMockRollbacker(t)
+		monitor := state_mocks.NewMockMonitor(t)
+		verifier := verify_mocks.NewMockVerifier(t)
+		checker := health_mocks.NewMockChecker(t)
+
+		updater := &Updater{
+			installDir: installDir,
+			installer:  installer,
+			svc:        svc,
+			rollbacker: rollbacker,
+			monitor:    monitor,
+			verifier:   verifier,
+			checker:    checker,
+			logger:     zaptest.NewLogger(t),
+		}
+
+		verifier.On("Verify").Times(1).Return(nil)
+		err := health.ErrCrashLoop
+
+		svc.On("Stop").Times(1).Return(nil)
+		rollbacker.On("AppendAction", action.NewServiceStopAction(svc)).Times(1).Return()
+		rollbacker.On("Backup").Times(1).Return(nil)
+		installer.On("Install", rollbacker).Times(1).Return(nil)
+		monitor.On("MonitorForSuccess", mock.Anything, packagestate.CollectorPackageName).Times(1).Return(nil)
+		checker.On("Check", mock.Anything).Times(1).Return(err)
+		monitor.On("SetState", packagestate.CollectorPackageName, protobufs.PackageStatusEnum_PackageStatusEnum_InstallFailed, err).Times(1).Return(errors.New("insufficient permissions"))
+		rollbacker.On("Rollback").Times(1).Return()
+
+		err = updater.Update()
+		require.ErrorIs(t, err, health.ErrCrashLoop)
+		require.ErrorContains(t, err, "failed health check")
+	})
 }
@@ -0,0 +1,90 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package verify checks the signature of the downloaded archive before it is installed
+package verify
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/observiq/bindplane-otel-collector/signature"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/config"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/path"
+	"go.uber.org/zap"
+)
+
+// Verifier checks the downloaded archive may be installed
+//
+//go:generate mockery --name Verifier --filename mock_verifier.go --structname MockVerifier
+type Verifier interface {
+	// Verify returns an error if the archive does not have a valid signature from a trusted key
+	Verify() error
+}
+
+// ArchiveVerifier verifies the signature the collector saved next to the archive it downloaded
+type ArchiveVerifier struct {
+	installDir string
+	logger     *zap.Logger
+}
+
+// NewVerifier creates a new Verifier for the archive downloaded into the install directory
+func NewVerifier(logger *zap.Logger, installDir string) Verifier {
+	return &ArchiveVerifier{
+		installDir: installDir,
+		logger:     logger.Named("verifier"),
+	}
+}
+
+// Verify checks the archive signature against the release key and the keys configured in the manager config
+func (a ArchiveVerifier) Verify() error {
+	cfg, err := config.LoadManagerConfig(a.installDir)
+	if err != nil {
+		return fmt.Errorf("failed to read signing keys: %w", err)
+	}
+
+	verifier, err := signature.NewVerifier(cfg.PackageSigningKeys...)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	sigPaths, err := filepath.Glob(filepath.Join(path.TempDir(a.installDir), "*"+signature.FileExtension))
+	if err != nil {
+		return fmt.Errorf("failed to find signature: %w", err)
+	}
+
+	switch len(sigPaths) {
+	case 0:
+		return signature.ErrMissingSignature
+	case 1:
+	default:
+		return fmt.Errorf("found %d signatures, expected one", len(sigPaths))
+	}
+
+	sigPath := filepath.Clean(sigPaths[0])
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %w", err)
+	}
+
+	archivePath := strings.TrimSuffix(sigPath, signature.FileExtension)
+	if err := verifier.VerifyFile(archivePath, sig); err != nil {
+		return fmt.Errorf("failed to verify %s: %w", filepath.Base(archivePath), err)
+	}
+
+	a.logger.Debug("Verified archive signature", zap.String("archive", archivePath))
+	return nil
+}
@@ -0,0 +1,121 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package verify
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/observiq/bindplane-otel-collector/signature"
+	"github.com/observiq/bindplane-otel-collector/updater/internal/path"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var archive = []byte("observiq-otel-collector archive")
+
+// setupInstallDir creates an install dir with the archive downloaded into the tmp dir.
+// The returned key is trusted through the manager config.
+func setupInstallDir(t *testing.T) (string, *ecdsa.PrivateKey) {
+	t.Helper()
+	installDir := t.TempDir()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	keyPath := filepath.Join(installDir, "signing_key.pub")
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+	managerConfig := fmt.Sprintf("endpoint: ws://localhost:3001/v1/opamp\npackage_signing_keys:\n  - %s\n", keyPath)
+	require.NoError(t, os.WriteFile(path.ManagerConfigFile(installDir), []byte(managerConfig), 0600))
+
+	require.NoError(t, os.MkdirAll(path.TempDir(installDir), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(path.TempDir(installDir), "observiq-otel-collector.tar.gz"), archive, 0600))
+
+	return installDir, priv
+}
+
+// writeSignature signs data with priv and writes the base64 signature next to the archive
+func writeSignature(t *testing.T, installDir string, priv *ecdsa.PrivateKey, data []byte) {
+	t.Helper()
+
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, priv, digest[:])
+	require.NoError(t, err)
+
+	sigPath := filepath.Join(path.TempDir(installDir), "observiq-otel-collector.tar.gz"+signature.FileExtension)
+	require.NoError(t, os.WriteFile(sigPath, []byte(base64.StdEncoding.EncodeToString(sig)), 0600))
+}
+
+func TestArchiveVerifierVerify(t *testing.T) {
+	t.Run("Valid signature", func(t *testing.T) {
+		installDir, priv := setupInstallDir(t)
+		writeSignature(t, installDir, priv, archive)
+
+		require.NoError(t, NewVerifier(zap.NewNop(), installDir).Verify())
+	})
+
+	t.Run("Signature of other data", func(t *testing.T) {
+		installDir, priv := setupInstallDir(t)
+		writeSignature(t, installDir, priv, []byte("tampered"))
+
+		err := NewVerifier(zap.NewNop(), installDir).Verify()
+		require.ErrorIs(t, err, signature.ErrInvalidSignature)
+	})
+
+	t.Run("Key not trusted", func(t *testing.T) {
+		installDir, priv := setupInstallDir(t)
+		writeSignature(t, installDir, priv, archive)
+		require.NoError(t, os.Remove(path.ManagerConfigFile(installDir)))
+
+		err := NewVerifier(zap.NewNop(), installDir).Verify()
+		require.ErrorIs(t, err, signature.ErrInvalidSignature)
+	})
+
+	t.Run("Missing signature", func(t *testing.T) {
+		installDir, _ := setupInstallDir(t)
+
+		err := NewVerifier(zap.NewNop(), installDir).Verify()
+		require.ErrorIs(t, err, signature.ErrMissingSignature)
+	})
+
+	t.Run("Missing archive", func(t *testing.T) {
+		installDir, priv := setupInstallDir(t)
+		writeSignature(t, installDir, priv, archive)
+		require.NoError(t, os.Remove(filepath.Join(path.TempDir(installDir), "observiq-otel-collector.tar.gz")))
+
+		err := NewVerifier(zap.NewNop(), installDir).Verify()
+		require.ErrorContains(t, err, "failed to open file")
+	})
+
+	t.Run("Invalid manager config", func(t *testing.T) {
+		installDir, priv := setupInstallDir(t)
+		writeSignature(t, installDir, priv, archive)
+		require.NoError(t, os.WriteFile(path.ManagerConfigFile(installDir), []byte("package_signing_keys: {"), 0600))
+
+		err := NewVerifier(zap.NewNop(), installDir).Verify()
+		require.ErrorContains(t, err, "failed to parse manager config")
+	})
+}